@@ -4,20 +4,27 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/zap"
+	"net/http"
 	"os"
 	"os/signal"
 	"siwuai/internal/infrastructure/cache"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
 	"siwuai/internal/infrastructure/loggers"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
 	"siwuai/internal/infrastructure/redis_utils"
 	"siwuai/internal/infrastructure/utils"
+	"siwuai/internal/infrastructure/vectorstore"
 	"syscall"
 	"time"
 
+	serviceimpl "siwuai/internal/domain/service/impl"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/etcd"
 	"siwuai/internal/infrastructure/grpc"
 	mysqlInfra "siwuai/internal/infrastructure/persistence"
+	mysqlImpl "siwuai/internal/infrastructure/persistence/impl"
 )
 
 func main() {
@@ -57,6 +64,53 @@ func main() {
 		return
 	}
 
+	// 初始化大模型提供方
+	provider, err := llm.NewProvider(cfg)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("初始化大模型提供方失败: %v", err))
+		return
+	}
+	// 记录每次调用的 token 用量
+	provider = llm.NewUsageProvider(provider, serviceimpl.NewUsageDomainService(mysqlImpl.NewMySQLUsageRepository(db), cfg), cfg)
+	zap.L().Info("初始化大模型提供方成功", zap.String("provider", cfg.Llm.Provider))
+
+	// 启动指标服务，大模型调用的次数、重试、熔断等指标通过 /debug/vars 暴露
+	if cfg.Server.MetricsPort != "" {
+		go func() {
+			if err := http.ListenAndServe("0.0.0.0:"+cfg.Server.MetricsPort, nil); err != nil {
+				zap.L().Error("指标服务启动失败", zap.Error(err))
+			}
+		}()
+	}
+
+	// 加载并校验提示词模板
+	registry, err := prompt.NewRegistry(cfg, constant.ArticleAICode, constant.ArticleChunkCode, constant.ArticleTranslateCode, constant.ArticleSEOCode, constant.CodeAICode, constant.QuestionAICode, constant.QuestionAnswerCode, constant.QuestionRAGCode, constant.RepairAICode)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("加载提示词模板失败: %v", err))
+		return
+	}
+
+	// 初始化向量索引，从数据库加载已保存的向量
+	vectorIndex, err := vectorstore.NewIndex(cfg)
+	if err != nil {
+		zap.L().Error(fmt.Sprintf("初始化向量索引失败: %v", err))
+		return
+	}
+	vectors := serviceimpl.NewVectorDomainService(mysqlImpl.NewMySQLEmbeddingRepository(db), vectorIndex, provider, cfg)
+	if err = vectors.LoadIndex(); err != nil {
+		zap.L().Error(fmt.Sprintf("加载向量索引失败: %v", err))
+		return
+	}
+	knowledge := serviceimpl.NewKnowledgeDomainService(vectors, mysqlImpl.NewArticleRepository(db), mysqlImpl.NewMySQLCodeRepository(db), cfg)
+	// 加载标签体系，加载失败时之后的标签映射会重试
+	tags := serviceimpl.NewTagDomainService(mysqlImpl.NewMySQLTagRepository(db), vectors, cfg)
+	if err = tags.LoadTaxonomy(); err != nil {
+		zap.L().Error(fmt.Sprintf("加载标签体系失败: %v", err))
+	}
+
+	// 缓存未命中、调用大模型生成内容前检查用户和调用方的配额
+	limiter := quota.NewRedisLimiter(redisClient, cfg)
+
 	// 获取布隆过滤器
 	bf := bfm.GetBloomFilter()
 
@@ -95,19 +149,35 @@ func main() {
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 		<-sigCh
+		// 取消 ctx 后 gRPC 服务器开始关闭，main 正常返回并执行 defer
+		defer cancel()
 		zap.L().Info(fmt.Sprintln("接收到退出信号，开始注销etcd服务..."))
-		if err = etcdRegistry.Deregister(ctx); err != nil {
+		if err := etcdRegistry.Deregister(ctx); err != nil {
 			zap.L().Error(fmt.Sprintf("etcd 注销服务失败: %v", err))
 			return
 		}
 		etcdRegistry.Close()
-		cancel()
-		os.Exit(0)
+	}()
+
+	// 在后台将尚未写入向量存储的文章和代码解释补充写入，服务关闭时停止
+	if cfg.Rag.Backfill {
+		go func() {
+			if err := knowledge.Backfill(ctx); err != nil {
+				zap.L().Error("站内内容写入向量存储失败", zap.Error(err))
+			}
+		}()
+	}
+
+	// 在后台为缺少向量的标准标签生成向量，用于按语义映射标签
+	go func() {
+		if err := tags.SyncVectors(ctx); err != nil {
+			zap.L().Error("标签写入向量存储失败", zap.Error(err))
+		}
 	}()
 
 	// 启动 gRPC 服务，使用配置文件中指定的端口（例如：cfg.Server.Port）
 	port := cfg.Server.Port
-	if err = grpc.RunGRPCServer(port, db, redisClient, bf, cfg, cacheManager, jc); err != nil {
+	if err = grpc.RunGRPCServer(ctx, port, db, redisClient, bf, cfg, cacheManager, jc, provider, registry, vectors, knowledge, tags, limiter); err != nil {
 		zap.L().Error(fmt.Sprintf("启动 gRPC 服务器失败: %v", err))
 		return
 	}
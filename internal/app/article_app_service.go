@@ -1,13 +1,23 @@
 package app
 
 import (
+	"context"
 	"siwuai/internal/domain/model/dto"
 	"siwuai/internal/domain/model/entity"
 )
 
 type ArticleAppServiceInterface interface {
-	GetArticleInfoFirst(content string, tags []string, articleID uint) (*dto.ArticleFirst, error)
-	SaveArticleID(key string, articleID uint) error
-	GetArticleInfo(articleID uint, userID uint) (*dto.ArticleSecond, []entity.Code, error)
-	DelArticleInfo(articleID uint) error
+	GetArticleInfoFirst(ctx context.Context, content string, tags []string, articleID uint, language string, withSEO bool) (*dto.ArticleFirst, error)
+	GetArticleInfoFirstStream(ctx context.Context, content string, tags []string, articleID uint, language string, withSEO bool, onEvent func(event dto.ArticleEvent) error) error
+	SaveArticleID(ctx context.Context, key string, articleID uint) error
+	UpdateArticleInfo(ctx context.Context, content string, tags []string, articleID uint, language string, force bool, withSEO bool) (*dto.ArticleUpdate, error)
+	GetArticleInfo(articleID uint, userID uint, language string) (*dto.ArticleSecond, []entity.Code, error)
+	DelArticleInfo(ctx context.Context, articleID uint) error
+	GetRelatedArticles(ctx context.Context, articleID uint, k int) ([]dto.RelatedArticle, error)
+	ListArticleVersions(articleID uint) ([]dto.ArticleVersion, error)
+	GetArticleVersion(articleID uint, versionID uint) (*dto.ArticleVersion, error)
+	PinArticleVersion(ctx context.Context, articleID uint, versionID uint) (*dto.ArticleVersion, error)
+	SaveArticleOverride(ctx context.Context, override *dto.ArticleOverride) error
+	ListArticlesByTag(tag string, afterID uint, limit int) (*dto.ArticlesByTag, error)
+	TranslateArticle(ctx context.Context, articleID uint, content string, language string, onChunk func(chunk string) error) (*dto.ArticleTranslation, error)
 }
@@ -1,10 +1,11 @@
 package app
 
 import (
+	"context"
 	"siwuai/internal/domain/model/dto"
 )
 
 // CodeApp 定义用户用例接口
 type CodeApp interface {
-	ExplainCode(req *dto.CodeReq) (*dto.Code, error)
+	ExplainCode(ctx context.Context, req *dto.CodeReq) (*dto.Code, error)
 }
@@ -1,72 +1,196 @@
 package impl
 
 import (
+	"context"
 	"fmt"
+	"go.uber.org/zap"
 	"siwuai/internal/app"
 	"siwuai/internal/domain/model/dto"
 	"siwuai/internal/domain/model/entity"
 	"siwuai/internal/domain/service"
 	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
 	"siwuai/internal/infrastructure/utils"
 	"strconv"
 )
 
 type articleAppService struct {
-	repo service.ArticleDomainServiceInterface
-	code persistence.CodeRepository
+	repo    service.ArticleDomainServiceInterface
+	code    persistence.CodeRepository
+	limiter quota.Limiter
 }
 
-func NewArticleAppService(repo service.ArticleDomainServiceInterface, code persistence.CodeRepository) app.ArticleAppServiceInterface {
+func NewArticleAppService(repo service.ArticleDomainServiceInterface, code persistence.CodeRepository, limiter quota.Limiter) app.ArticleAppServiceInterface {
 	return &articleAppService{
-		repo: repo,
-		code: code,
+		repo:    repo,
+		code:    code,
+		limiter: limiter,
 	}
 }
 
-// GetArticleInfoFirst 第一次获取文章的摘要、总结、标签
-func (a *articleAppService) GetArticleInfoFirst(content string, tags []string, articleID uint) (*dto.ArticleFirst, error) {
-	// 根据文章的内容生成 hash值
-	hashValue, err := utils.Hash(content)
+// GetArticleInfoFirst 第一次获取文章的摘要、总结、标签，language 为空时使用默认语言
+// withSEO 为 true 时同时生成 SEO 信息，已经生成过的不再重新生成
+func (a *articleAppService) GetArticleInfoFirst(ctx context.Context, content string, tags []string, articleID uint, language string, withSEO bool) (*dto.ArticleFirst, error) {
+	// 根据文章的内容和语言生成 hash值
+	language = prompt.NormalizeLanguage(language)
+	hashValue, err := utils.HashLanguage(content, language)
 	if err != nil {
 		return nil, fmt.Errorf("(r *ArticleRepository) GetArticleInfoFirst -> %v", err)
 	}
 
+	// 封装数据
+	ap := &dto.ArticlePrompt{
+		Content:   content,
+		Tags:      tags,
+		ArticleID: articleID,
+		Language:  language,
+	}
 	articleInfo, err := a.repo.VerifyHash(hashValue)
 	if err != nil {
 		if err.Error() == "数据库中没有该 hash值" {
-			// 封装数据
-			ap := &dto.ArticlePrompt{
-				Content:   content,
-				Tags:      tags,
-				ArticleID: articleID,
-			}
 			// 调用AI，提炼文章的摘要、总结、标签
-			articleFirst, err := a.repo.AskAI(hashValue, ap)
+			if err = a.limiter.Acquire(ctx); err != nil {
+				return nil, err
+			}
+			articleInfo, err = a.repo.AskAI(ctx, hashValue, ap)
 			if err != nil {
-				return nil, fmt.Errorf("(r *ArticleRepository) GetArticleInfoFirst -> %v", err)
+				return nil, fmt.Errorf("(r *ArticleRepository) GetArticleInfoFirst -> %w", err)
 			}
-			return articleFirst, nil
 		} else {
 			return nil, fmt.Errorf("(r *ArticleRepository) GetArticleInfoFirst -> %v", err)
 		}
 	}
 
-	// 如果hash存在，直接返回数据
+	// 如果hash存在，直接返回数据，需要时补充 SEO 信息
+	if withSEO {
+		a.articleSEO(ctx, hashValue, ap, articleInfo)
+	}
 	return articleInfo, nil
 }
 
+// GetArticleInfoFirstStream 流式获取文章的摘要、总结、标签，已经生成过的文章直接返回完整内容
+// withSEO 为 true 时在 done 事件之前生成 SEO 信息，随最终结果返回
+func (a *articleAppService) GetArticleInfoFirstStream(ctx context.Context, content string, tags []string, articleID uint, language string, withSEO bool, onEvent func(event dto.ArticleEvent) error) error {
+	language = prompt.NormalizeLanguage(language)
+	hashValue, err := utils.HashLanguage(content, language)
+	if err != nil {
+		return fmt.Errorf("(a *articleAppService) GetArticleInfoFirstStream -> %v", err)
+	}
+
+	ap := &dto.ArticlePrompt{
+		Content:   content,
+		Tags:      tags,
+		ArticleID: articleID,
+		Language:  language,
+	}
+	if withSEO {
+		next := onEvent
+		onEvent = func(event dto.ArticleEvent) error {
+			if event.Type == dto.ArticleEventDone && event.Result != nil {
+				a.articleSEO(ctx, hashValue, ap, event.Result)
+			}
+			return next(event)
+		}
+	}
+
+	articleInfo, err := a.repo.VerifyHash(hashValue)
+	if err != nil {
+		if err.Error() != "数据库中没有该 hash值" {
+			return fmt.Errorf("(a *articleAppService) GetArticleInfoFirstStream -> %v", err)
+		}
+		if err = a.limiter.Acquire(ctx); err != nil {
+			return err
+		}
+		if _, err = a.repo.AskAIStream(ctx, hashValue, ap, onEvent); err != nil {
+			return fmt.Errorf("(a *articleAppService) GetArticleInfoFirstStream -> %w", err)
+		}
+		return nil
+	}
+
+	// 如果hash存在，按相同的事件顺序一次性返回
+	events := []dto.ArticleEvent{
+		{Type: dto.ArticleEventAbstract, Text: articleInfo.Abstract},
+		{Type: dto.ArticleEventSummary, Text: articleInfo.Summary},
+	}
+	if len(articleInfo.Tags) > 0 {
+		events = append(events, dto.ArticleEvent{Type: dto.ArticleEventTags, Tags: articleInfo.Tags})
+	}
+	events = append(events, dto.ArticleEvent{Type: dto.ArticleEventDone, Result: articleInfo})
+	for _, event := range events {
+		if err = onEvent(event); err != nil {
+			return fmt.Errorf("(a *articleAppService) GetArticleInfoFirstStream -> %w", err)
+		}
+	}
+	return nil
+}
+
 // SaveArticleID 保存文章的ID
-func (a *articleAppService) SaveArticleID(key string, articleID uint) error {
-	err := a.repo.SaveArticleID(key, articleID)
+func (a *articleAppService) SaveArticleID(ctx context.Context, key string, articleID uint) error {
+	err := a.repo.SaveArticleID(ctx, key, articleID)
 	if err != nil {
 		return fmt.Errorf("(a *articleAppService) SaveArticleID -> %v", err)
 	}
 	return nil
 }
 
+// UpdateArticleInfo 文章内容修改后更新文章信息，withSEO 为 true 时为没有 SEO 信息的新版本生成
+func (a *articleAppService) UpdateArticleInfo(ctx context.Context, content string, tags []string, articleID uint, language string, force bool, withSEO bool) (*dto.ArticleUpdate, error) {
+	language = prompt.NormalizeLanguage(language)
+	hashValue, err := utils.HashLanguage(content, language)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) UpdateArticleInfo -> %v", err)
+	}
+
+	ap := &dto.ArticlePrompt{
+		Content:   content,
+		Tags:      tags,
+		ArticleID: articleID,
+		Language:  language,
+	}
+	// 沿用已有摘要和总结的修改不调用大模型，不计入配额
+	var update *dto.ArticleUpdate
+	if !force {
+		if update, err = a.repo.ReuseArticleInfo(ctx, hashValue, ap); err != nil {
+			return nil, fmt.Errorf("(a *articleAppService) UpdateArticleInfo -> %w", err)
+		}
+	}
+	if update == nil {
+		if err = a.limiter.Acquire(ctx); err != nil {
+			return nil, err
+		}
+		if update, err = a.repo.UpdateArticleInfo(ctx, hashValue, ap, force); err != nil {
+			return nil, fmt.Errorf("(a *articleAppService) UpdateArticleInfo -> %w", err)
+		}
+	}
+	if withSEO {
+		a.articleSEO(ctx, hashValue, ap, &update.ArticleFirst)
+	}
+	return update, nil
+}
+
+// articleSEO 文章还没有 SEO 信息时生成并填入 article，没有摘要和总结时不生成
+// SEO 信息是附加的结果，生成失败只记录日志，不影响摘要和总结的返回，下次请求时重新生成
+func (a *articleAppService) articleSEO(ctx context.Context, key string, ap *dto.ArticlePrompt, article *dto.ArticleFirst) {
+	if !article.SEO.Empty() || (article.Abstract == "" && article.Summary == "") {
+		return
+	}
+	// 与摘要在同一请求内生成时不重复占用配额
+	if err := a.limiter.Acquire(ctx); err != nil {
+		zap.L().Warn("配额不足，未生成文章的 SEO 信息", zap.String("key", key), zap.Error(err))
+		return
+	}
+	seo, err := a.repo.GenerateArticleSEO(ctx, key, ap, article)
+	if err != nil {
+		zap.L().Error("生成文章的 SEO 信息失败", zap.String("key", key), zap.Error(err))
+		return
+	}
+	article.SEO = *seo
+}
+
 // GetArticleInfo 非首次获取文章的信息
-func (a *articleAppService) GetArticleInfo(articleID uint, userID uint) (*dto.ArticleSecond, []entity.Code, error) {
-	articleSecond, err := a.repo.GetArticleInfo(articleID)
+func (a *articleAppService) GetArticleInfo(articleID uint, userID uint, language string) (*dto.ArticleSecond, []entity.Code, error) {
+	articleSecond, err := a.repo.GetArticleInfo(articleID, language)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -88,7 +212,78 @@ func (a *articleAppService) GetArticleInfo(articleID uint, userID uint) (*dto.Ar
 }
 
 // DelArticleInfo 删除文章信息
-func (a *articleAppService) DelArticleInfo(articleID uint) error {
-	err := a.repo.DelArticleInfo(articleID)
+func (a *articleAppService) DelArticleInfo(ctx context.Context, articleID uint) error {
+	err := a.repo.DelArticleInfo(ctx, articleID)
 	return err
 }
+
+// GetRelatedArticles 获取与文章相关的文章
+func (a *articleAppService) GetRelatedArticles(ctx context.Context, articleID uint, k int) ([]dto.RelatedArticle, error) {
+	related, err := a.repo.GetRelatedArticles(ctx, articleID, k)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) GetRelatedArticles -> %w", err)
+	}
+	return related, nil
+}
+
+// ListArticleVersions 查询文章的全部摘要和总结版本
+func (a *articleAppService) ListArticleVersions(articleID uint) ([]dto.ArticleVersion, error) {
+	versions, err := a.repo.ListArticleVersions(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) ListArticleVersions -> %w", err)
+	}
+	return versions, nil
+}
+
+// GetArticleVersion 查询文章的指定版本
+func (a *articleAppService) GetArticleVersion(articleID uint, versionID uint) (*dto.ArticleVersion, error) {
+	version, err := a.repo.GetArticleVersion(articleID, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) GetArticleVersion -> %w", err)
+	}
+	return version, nil
+}
+
+// PinArticleVersion 将指定版本设为文章当前使用的摘要和总结
+func (a *articleAppService) PinArticleVersion(ctx context.Context, articleID uint, versionID uint) (*dto.ArticleVersion, error) {
+	version, err := a.repo.PinArticleVersion(ctx, articleID, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) PinArticleVersion -> %w", err)
+	}
+	return version, nil
+}
+
+// SaveArticleOverride 保存编辑人工修改的摘要、总结和标签
+func (a *articleAppService) SaveArticleOverride(ctx context.Context, override *dto.ArticleOverride) error {
+	if err := a.repo.SaveArticleOverride(ctx, override); err != nil {
+		return fmt.Errorf("(a *articleAppService) SaveArticleOverride -> %w", err)
+	}
+	return nil
+}
+
+// ListArticlesByTag 按标签分页查询文章ID
+func (a *articleAppService) ListArticlesByTag(tag string, afterID uint, limit int) (*dto.ArticlesByTag, error) {
+	res, err := a.repo.ListArticlesByTag(tag, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) ListArticlesByTag -> %v", err)
+	}
+	return res, nil
+}
+
+// TranslateArticle 将文章翻译为指定语言，每翻译完一段调用一次 onChunk，相同内容和语言的译文直接返回
+func (a *articleAppService) TranslateArticle(ctx context.Context, articleID uint, content string, language string, onChunk func(chunk string) error) (*dto.ArticleTranslation, error) {
+	saved, err := a.repo.TranslationSaved(articleID, content, language)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) TranslateArticle -> %w", err)
+	}
+	if !saved {
+		if err = a.limiter.Acquire(ctx); err != nil {
+			return nil, err
+		}
+	}
+	translation, err := a.repo.TranslateArticle(ctx, articleID, content, language, onChunk)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleAppService) TranslateArticle -> %w", err)
+	}
+	return translation, nil
+}
@@ -1,30 +1,46 @@
 package impl
 
 import (
+	"context"
 	"fmt"
 	"siwuai/internal/app"
 	"siwuai/internal/domain/model/dto"
 	"siwuai/internal/domain/service"
 	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/quota"
 )
 
 type codeApp struct {
 	repo              persistence.CodeRepository
 	codeDomainService service.CodeDomainService
+	limiter           quota.Limiter
 }
 
 // NewCodeApp 构造函数
-func NewCodeApp(r persistence.CodeRepository, ds service.CodeDomainService) app.CodeApp {
+func NewCodeApp(r persistence.CodeRepository, ds service.CodeDomainService, limiter quota.Limiter) app.CodeApp {
 	return &codeApp{
 		repo:              r,
 		codeDomainService: ds,
+		limiter:           limiter,
 	}
 }
 
-func (uc *codeApp) ExplainCode(req *dto.CodeReq) (code1 *dto.Code, err error) {
-	code1, err = uc.codeDomainService.ExplainCode(req)
+// ExplainCode 获取代码解释，没有已生成的解释时先占用配额再生成，命中缓存不计入配额
+func (uc *codeApp) ExplainCode(ctx context.Context, req *dto.CodeReq) (code1 *dto.Code, err error) {
+	cached, err := uc.codeDomainService.Cached(req)
 	if err != nil {
-		err = fmt.Errorf("uc.codeDomainService.ExplainCode() %v", err)
+		err = fmt.Errorf("uc.codeDomainService.Cached() %v", err)
+		return
+	}
+	if !cached {
+		if err = uc.limiter.Acquire(ctx); err != nil {
+			return
+		}
+	}
+
+	code1, err = uc.codeDomainService.ExplainCode(ctx, req)
+	if err != nil {
+		err = fmt.Errorf("uc.codeDomainService.ExplainCode() %w", err)
 		return
 	}
 	return
@@ -0,0 +1,134 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"siwuai/internal/app"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
+	"siwuai/internal/infrastructure/utils"
+)
+
+type questionAppService struct {
+	repo    service.QuestionDomainServiceInterface
+	limiter quota.Limiter
+}
+
+func NewQuestionAppService(repo service.QuestionDomainServiceInterface, limiter quota.Limiter) app.QuestionAppServiceInterface {
+	return &questionAppService{
+		repo:    repo,
+		limiter: limiter,
+	}
+}
+
+// GenerateQuestionTitles 获取问题的标题和标签，相同内容和语言的问题直接返回已生成的结果，否则占用配额后生成
+func (q *questionAppService) GenerateQuestionTitles(ctx context.Context, content string, questionID uint, language string) (*dto.Question, error) {
+	language = prompt.NormalizeLanguage(language)
+	hashValue, question, err := q.verify(content, language)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionAppService) GenerateQuestionTitles -> %v", err)
+	}
+	if question != nil && len(question.Titles) > 0 {
+		return question, nil
+	}
+	if err = q.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	qp := &dto.QuestionPrompt{
+		Content:    content,
+		QuestionID: questionID,
+		Language:   language,
+	}
+	question, err = q.repo.AskTitles(ctx, hashValue, qp)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionAppService) GenerateQuestionTitles -> %w", err)
+	}
+	return question, nil
+}
+
+// GetAnswer 获取问题的答案，相同内容的问题直接返回已生成的答案，否则占用配额后流式生成，每收到一段内容调用一次 onChunk
+// 结合站内内容生成的答案每次重新检索和生成，不使用已生成的答案
+func (q *questionAppService) GetAnswer(ctx context.Context, content string, opts dto.AnswerOptions, onChunk func(chunk string) error) (*dto.QuestionAnswer, error) {
+	language := prompt.NormalizeLanguage(opts.Language)
+	if opts.WithSources {
+		hashValue, err := utils.HashLanguage(content, language)
+		if err != nil {
+			return nil, fmt.Errorf("(q *questionAppService) GetAnswer -> %v", err)
+		}
+		if err = q.limiter.Acquire(ctx); err != nil {
+			return nil, err
+		}
+		answer, err := q.repo.AskAnswerWithSources(ctx, hashValue, &dto.QuestionPrompt{Content: content, Language: language}, opts.TopK, onChunk)
+		if err != nil {
+			return nil, fmt.Errorf("(q *questionAppService) GetAnswer -> %w", err)
+		}
+		return answer, nil
+	}
+
+	hashValue, question, err := q.verify(content, language)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionAppService) GetAnswer -> %v", err)
+	}
+	if question != nil && question.Answer != "" {
+		if err = onChunk(question.Answer); err != nil {
+			return nil, fmt.Errorf("(q *questionAppService) GetAnswer -> %w", err)
+		}
+		return &dto.QuestionAnswer{
+			Key:          hashValue,
+			Answer:       question.Answer,
+			Model:        question.AnswerModel,
+			FinishReason: "stop",
+			Cached:       true,
+			Language:     question.Language,
+		}, nil
+	}
+
+	if err = q.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	qp := &dto.QuestionPrompt{
+		Content:  content,
+		Language: language,
+	}
+	answer, err := q.repo.AskAnswer(ctx, hashValue, qp, onChunk)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionAppService) GetAnswer -> %w", err)
+	}
+	return answer, nil
+}
+
+// SaveQuestionID 保存问题的ID
+func (q *questionAppService) SaveQuestionID(ctx context.Context, key string, questionID uint) error {
+	err := q.repo.SaveQuestionID(ctx, key, questionID)
+	if err != nil {
+		return fmt.Errorf("(q *questionAppService) SaveQuestionID -> %v", err)
+	}
+	return nil
+}
+
+// FindSimilarQuestions 检索内容相近的已有问题
+func (q *questionAppService) FindSimilarQuestions(ctx context.Context, content string, topK int, excludeID uint) ([]dto.SimilarQuestion, error) {
+	questions, err := q.repo.FindSimilarQuestions(ctx, content, topK, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionAppService) FindSimilarQuestions -> %w", err)
+	}
+	return questions, nil
+}
+
+// verify 根据问题的内容和语言生成 hash值并查询已保存的问题，没有记录时 question 为 nil
+func (q *questionAppService) verify(content string, language string) (hashValue string, question *dto.Question, err error) {
+	hashValue, err = utils.HashLanguage(content, language)
+	if err != nil {
+		return "", nil, err
+	}
+	question, err = q.repo.VerifyHash(hashValue)
+	if err != nil && !errors.Is(err, persistence.ErrQuestionNotFound) {
+		return "", nil, err
+	}
+	return hashValue, question, nil
+}
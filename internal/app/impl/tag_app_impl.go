@@ -0,0 +1,69 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"siwuai/internal/app"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+)
+
+type tagApp struct {
+	tags service.TagDomainService
+}
+
+// NewTagApp 构造函数
+func NewTagApp(tags service.TagDomainService) app.TagApp {
+	return &tagApp{
+		tags: tags,
+	}
+}
+
+// CreateTag 创建标准标签
+func (ta *tagApp) CreateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error) {
+	res, err := ta.tags.CreateTag(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("(ta *tagApp) CreateTag -> %w", err)
+	}
+	return res, nil
+}
+
+// UpdateTag 更新标签
+func (ta *tagApp) UpdateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error) {
+	res, err := ta.tags.UpdateTag(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("(ta *tagApp) UpdateTag -> %w", err)
+	}
+	return res, nil
+}
+
+// DelTag 删除标签
+func (ta *tagApp) DelTag(ctx context.Context, id uint) error {
+	if err := ta.tags.DelTag(ctx, id); err != nil {
+		return fmt.Errorf("(ta *tagApp) DelTag -> %w", err)
+	}
+	return nil
+}
+
+// GetTag 查询标签
+func (ta *tagApp) GetTag(id uint) (*dto.Tag, error) {
+	res, err := ta.tags.GetTag(id)
+	if err != nil {
+		return nil, fmt.Errorf("(ta *tagApp) GetTag -> %w", err)
+	}
+	return res, nil
+}
+
+// ListTags 查询标签列表
+func (ta *tagApp) ListTags(status string) ([]dto.Tag, error) {
+	res, err := ta.tags.ListTags(status)
+	if err != nil {
+		return nil, fmt.Errorf("(ta *tagApp) ListTags -> %w", err)
+	}
+	return res, nil
+}
+
+// NormalizeTags 将标签映射到标准标签
+func (ta *tagApp) NormalizeTags(ctx context.Context, tags []string) *dto.TagMapping {
+	return ta.tags.NormalizeTags(ctx, tags)
+}
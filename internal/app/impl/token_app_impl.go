@@ -24,7 +24,7 @@ func NewTokenApp(service1 service.TokenDomainService, cfg config.Config) app.Tok
 }
 
 func (app *tokenApp) GenerateToken(req *dto.TokenReq) (resp *dto.TokenResp, err error) {
-	token, err := app.tokenDomainService.GenerateToken(req, app.generateTokenKey, app.secretKey)
+	token, err := app.tokenDomainService.GenerateToken(req, app.secretKey, app.generateTokenKey)
 	if err != nil {
 		err = fmt.Errorf("app.tokenDomainService.GenerateToken() %v", err)
 		return
@@ -0,0 +1,29 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"siwuai/internal/app"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+)
+
+type usageApp struct {
+	usageDomainService service.UsageDomainService
+}
+
+// NewUsageApp 构造函数
+func NewUsageApp(ds service.UsageDomainService) app.UsageApp {
+	return &usageApp{
+		usageDomainService: ds,
+	}
+}
+
+func (uc *usageApp) GetUsage(ctx context.Context, query *dto.UsageQuery) (stats []dto.UsageStat, total dto.UsageStat, err error) {
+	stats, total, err = uc.usageDomainService.GetUsage(ctx, query)
+	if err != nil {
+		err = fmt.Errorf("uc.usageDomainService.GetUsage() %v", err)
+		return
+	}
+	return
+}
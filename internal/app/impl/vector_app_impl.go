@@ -0,0 +1,43 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"siwuai/internal/app"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+)
+
+type vectorApp struct {
+	vectorDomainService service.VectorDomainService
+}
+
+// NewVectorApp 构造函数
+func NewVectorApp(ds service.VectorDomainService) app.VectorApp {
+	return &vectorApp{
+		vectorDomainService: ds,
+	}
+}
+
+func (va *vectorApp) UpsertVector(ctx context.Context, doc *dto.VectorDoc) error {
+	if err := va.vectorDomainService.UpsertVector(ctx, doc); err != nil {
+		return fmt.Errorf("va.vectorDomainService.UpsertVector() %w", err)
+	}
+	return nil
+}
+
+func (va *vectorApp) DelVector(ctx context.Context, docType, docID string) (bool, error) {
+	deleted, err := va.vectorDomainService.DelVector(ctx, docType, docID)
+	if err != nil {
+		return false, fmt.Errorf("va.vectorDomainService.DelVector() %v", err)
+	}
+	return deleted, nil
+}
+
+func (va *vectorApp) QueryVector(ctx context.Context, query *dto.VectorQuery) ([]dto.VectorHit, error) {
+	hits, err := va.vectorDomainService.QueryVector(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("va.vectorDomainService.QueryVector() %w", err)
+	}
+	return hits, nil
+}
@@ -0,0 +1,13 @@
+package app
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+)
+
+type QuestionAppServiceInterface interface {
+	GenerateQuestionTitles(ctx context.Context, content string, questionID uint, language string) (*dto.Question, error)
+	GetAnswer(ctx context.Context, content string, opts dto.AnswerOptions, onChunk func(chunk string) error) (*dto.QuestionAnswer, error)
+	SaveQuestionID(ctx context.Context, key string, questionID uint) error
+	FindSimilarQuestions(ctx context.Context, content string, topK int, excludeID uint) ([]dto.SimilarQuestion, error)
+}
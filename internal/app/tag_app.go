@@ -0,0 +1,16 @@
+package app
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+)
+
+// TagApp 定义标签体系的维护和标签映射接口
+type TagApp interface {
+	CreateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error)
+	UpdateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error)
+	DelTag(ctx context.Context, id uint) error
+	GetTag(id uint) (*dto.Tag, error)
+	ListTags(status string) ([]dto.Tag, error)
+	NormalizeTags(ctx context.Context, tags []string) *dto.TagMapping
+}
@@ -0,0 +1,11 @@
+package app
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+)
+
+// UsageApp 定义大模型用量查询接口
+type UsageApp interface {
+	GetUsage(ctx context.Context, query *dto.UsageQuery) (stats []dto.UsageStat, total dto.UsageStat, err error)
+}
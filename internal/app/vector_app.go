@@ -0,0 +1,13 @@
+package app
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+)
+
+// VectorApp 定义向量存储的增删和检索接口
+type VectorApp interface {
+	UpsertVector(ctx context.Context, doc *dto.VectorDoc) error
+	DelVector(ctx context.Context, docType, docID string) (bool, error)
+	QueryVector(ctx context.Context, query *dto.VectorQuery) ([]dto.VectorHit, error)
+}
@@ -1,19 +1,101 @@
 package dto
 
 type ArticleFirst struct {
-	Key      string   `json:"key"`      // 用于标识文章的状态(是否被修改)
-	Abstract string   `json:"abstract"` // 发布文章时，提取的文章摘要
-	Summary  string   `json:"summary"`  // 发布文章时，提取的文章总结
-	Tags     []string `json:"tags"`     // 标签
+	Key           string     `json:"key"`           // 用于标识文章的状态(是否被修改)
+	Abstract      string     `json:"abstract"`      // 发布文章时，提取的文章摘要
+	Summary       string     `json:"summary"`       // 发布文章时，提取的文章总结
+	Tags          []string   `json:"tags"`          // 标签，已映射为标签体系中的标准标签
+	SuggestedTags []string   `json:"suggestedTags"` // 标签体系中没有匹配的标签，已记录为待审核的建议标签
+	Confidence    float64    `json:"confidence"`    // 模型对摘要和总结的置信度(0~1)，兜底解析时为 0
+	Model         string     `json:"model"`         // 生成摘要和总结的模型
+	PromptVersion string     `json:"promptVersion"` // 生成摘要和总结使用的提示词模板版本
+	Language      string     `json:"language"`      // 摘要和总结使用的语言
+	SEO           ArticleSEO `json:"seo"`           // SEO 信息，没有生成时各字段为空
 }
 
 type ArticleSecond struct {
-	Abstract string `json:"abstract"` // 发布文章时，提取的文章摘要
-	Summary  string `json:"summary"`  // 发布文章时，提取的文章总结
+	Abstract    string     `json:"abstract"`    // 发布文章时，提取的文章摘要
+	Summary     string     `json:"summary"`     // 发布文章时，提取的文章总结
+	Tags        []string   `json:"tags"`        // 标签
+	HumanEdited bool       `json:"humanEdited"` // 摘要、总结或标签中是否有编辑人工修改的内容
+	Language    string     `json:"language"`    // 摘要和总结使用的语言
+	SEO         ArticleSEO `json:"seo"`         // SEO 信息，没有生成时各字段为空
+}
+
+// ArticleOverride 编辑人工修改的文章摘要、总结和标签，为空的字段沿用模型生成的结果
+type ArticleOverride struct {
+	ArticleID uint     // 文章ID
+	Abstract  string   // 人工修改的摘要
+	Summary   string   // 人工修改的总结
+	Tags      []string // 人工修改的标签
+	EditorID  uint     // 修改的用户ID
+}
+
+// Empty 是否没有修改任何字段
+func (o *ArticleOverride) Empty() bool {
+	return o.Abstract == "" && o.Summary == "" && len(o.Tags) == 0
+}
+
+// ArticleUpdate 更新文章信息的结果
+type ArticleUpdate struct {
+	ArticleFirst
+	ChangeRatio float64 // 与上一版内容相比的改动比例(0~1)，上一版没有保存内容时为 1
+	Regenerated bool    // 是否重新生成了摘要和总结
+}
+
+// ArticlesByTag 按标签查询文章的结果
+type ArticlesByTag struct {
+	Tag        string // 实际查询的标签，别名已映射为标准标签
+	ArticleIDs []uint // 带有该标签的文章ID，从小到大排序
 }
 
 type ArticlePrompt struct {
 	Content   string   // 询问AI的内容
 	Tags      []string // 询问AI时提供的标签
 	ArticleID uint     // 文章ID
+	Language  string   // 摘要和总结使用的语言，为空时为默认语言
+}
+
+// ArticleChunkPrompt 长文章分段总结时其中一段的请求参数
+type ArticleChunkPrompt struct {
+	Chunk    string // 这一段的内容
+	Index    int    // 第几段，从 1 开始
+	Total    int    // 总段数
+	Language string // 输出的语言，与文章的请求相同
+}
+
+// ArticleTranslatePrompt 翻译文章时其中一段的请求参数
+type ArticleTranslatePrompt struct {
+	Chunk        string // 这一段的内容，行内代码和链接地址已替换为占位符
+	LanguageName string // 目标语言的名称
+	Index        int    // 第几段，从 1 开始
+	Total        int    // 需要翻译的总段数
+}
+
+// ArticleTranslation 文章的译文
+type ArticleTranslation struct {
+	ArticleID uint   // 文章ID
+	Key       string // 原文内容的 hash 值
+	Language  string // 目标语言
+	Content   string // 完整译文
+	Model     string // 翻译使用的模型
+	Cached    bool   // 是否为已保存的译文
+}
+
+// ArticleEventType 流式获取文章信息时的事件类型
+type ArticleEventType string
+
+const (
+	ArticleEventAbstract ArticleEventType = "abstract" // 摘要的增量内容
+	ArticleEventSummary  ArticleEventType = "summary"  // 总结的增量内容
+	ArticleEventTags     ArticleEventType = "tags"     // 匹配的标签
+	ArticleEventDone     ArticleEventType = "done"     // 生成结束
+)
+
+// ArticleEvent 流式获取文章信息时的一个事件
+type ArticleEvent struct {
+	Type   ArticleEventType
+	Text   string        // 摘要或总结的增量内容
+	Tags   []string      // 匹配的标签
+	Result *ArticleFirst // 生成结束时的最终结果
 }
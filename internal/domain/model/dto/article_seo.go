@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// SEO 信息各字段的最大长度(字符数)
+const (
+	MaxSEOTitleLen        = 60
+	MaxMetaDescriptionLen = 160
+	MaxSlugLen            = 80
+	MaxOGTitleLen         = 90
+	MaxOGDescriptionLen   = 200
+)
+
+// ErrInvalidSEO SEO 信息缺少字段或不符合长度、格式的限制
+var ErrInvalidSEO = errors.New("SEO 信息不符合要求")
+
+// slugRe URL slug 只能由小写字母、数字和单个连字符组成
+var slugRe = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ArticleSEO 发布文章时生成的 SEO 信息
+type ArticleSEO struct {
+	SEOTitle        string `json:"seoTitle"`        // 搜索结果中显示的标题
+	MetaDescription string `json:"metaDescription"` // meta description，不超过 160 个字符
+	Slug            string `json:"slug"`            // 文章的 URL slug
+	OGTitle         string `json:"ogTitle"`         // Open Graph 标题
+	OGDescription   string `json:"ogDescription"`   // Open Graph 描述
+}
+
+// ArticleSEOPrompt 生成 SEO 信息的请求参数
+type ArticleSEOPrompt struct {
+	Content  string   // 文章开头的一部分内容
+	Abstract string   // 已生成的摘要
+	Summary  string   // 已生成的总结
+	Tags     []string // 文章的标签
+	Language string   // SEO 信息使用的语言，与摘要和总结一致
+}
+
+// Empty 是否还没有生成 SEO 信息
+func (s *ArticleSEO) Empty() bool {
+	return s.SEOTitle == "" && s.MetaDescription == "" && s.Slug == ""
+}
+
+// Normalize 去除各字段首尾的空白，slug 转为小写
+func (s *ArticleSEO) Normalize() {
+	s.SEOTitle = strings.TrimSpace(s.SEOTitle)
+	s.MetaDescription = strings.TrimSpace(s.MetaDescription)
+	s.Slug = strings.ToLower(strings.TrimSpace(s.Slug))
+	s.OGTitle = strings.TrimSpace(s.OGTitle)
+	s.OGDescription = strings.TrimSpace(s.OGDescription)
+}
+
+// Validate 校验各字段都不为空且不超过长度限制，slug 符合格式，返回的错误包装 ErrInvalidSEO
+func (s *ArticleSEO) Validate() error {
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"seoTitle", s.SEOTitle, MaxSEOTitleLen},
+		{"metaDescription", s.MetaDescription, MaxMetaDescriptionLen},
+		{"slug", s.Slug, MaxSlugLen},
+		{"ogTitle", s.OGTitle, MaxOGTitleLen},
+		{"ogDescription", s.OGDescription, MaxOGDescriptionLen},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			return fmt.Errorf("%s 不能为空: %w", f.name, ErrInvalidSEO)
+		}
+		if n := utf8.RuneCountInString(f.value); n > f.max {
+			return fmt.Errorf("%s 长度为 %d，不能超过 %d 个字符: %w", f.name, n, f.max, ErrInvalidSEO)
+		}
+	}
+	if !slugRe.MatchString(s.Slug) {
+		return fmt.Errorf("slug %q 只能由小写字母、数字和连字符组成: %w", s.Slug, ErrInvalidSEO)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// ArticleVersion 文章的一个摘要和总结版本
+type ArticleVersion struct {
+	VersionID     uint      `json:"versionID"`     // 版本ID
+	ArticleID     uint      `json:"articleID"`     // 文章ID
+	Key           string    `json:"key"`           // 生成时文章内容的 hash 值
+	Abstract      string    `json:"abstract"`      // 文章摘要
+	Summary       string    `json:"summary"`       // 文章总结
+	Tags          []string  `json:"tags"`          // 匹配的标签
+	Confidence    float64   `json:"confidence"`    // 模型对摘要和总结的置信度
+	Model         string    `json:"model"`         // 生成摘要和总结的模型
+	PromptVersion string    `json:"promptVersion"` // 使用的提示词模板版本
+	CreatedAt     time.Time `json:"createdAt"`     // 生成时间
+	Current       bool      `json:"current"`       // 是否为文章当前使用的版本
+}
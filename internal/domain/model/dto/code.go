@@ -4,6 +4,7 @@ type CodeReq struct {
 	Question string
 	UserId   uint
 	CodeType string
+	Language string // 解释使用的语言，为空时为默认语言；代码的编程语言为 CodeType
 }
 
 type Code struct {
@@ -11,5 +12,8 @@ type Code struct {
 	Key         string
 	Question    string
 	Explanation string
+	Model       string      // 生成解释的模型
+	Language    string      // 解释使用的语言
 	Stream      chan string `json:"-"`
+	Err         chan error  `json:"-"` // Stream 关闭后写入生成结果，nil 表示生成完整
 }
@@ -4,4 +4,58 @@ package dto
 type QuestionPrompt struct {
 	Content    string `json:"content"`     // 问题正文内容
 	QuestionID uint   `json:"question_id"` // 问题ID
+	Sources    string `json:"sources"`     // 参考资料，每条以 [编号] 开头，只在结合站内内容生成答案时使用
+	Language   string `json:"language"`    // 标题和答案使用的语言，为空时为默认语言
+}
+
+// Question 已生成的问题信息
+type Question struct {
+	Key           string   `json:"key"`            // 问题内容的 hash 值
+	QuestionID    uint     `json:"question_id"`    // 问题ID
+	Titles        []string `json:"titles"`         // 生成的标题
+	Tags          []string `json:"tags"`           // 生成的标签，已映射为标准标签
+	SuggestedTags []string `json:"suggested_tags"` // 没有匹配标准标签的建议标签
+	Answer        string   `json:"answer"`         // 生成的答案，未生成时为空
+	TitleModel    string   `json:"title_model"`    // 生成标题和标签的模型
+	AnswerModel   string   `json:"answer_model"`   // 生成答案的模型
+	Language      string   `json:"language"`       // 标题和答案使用的语言
+}
+
+// SimilarQuestion 与新问题内容相近的已有问题
+type SimilarQuestion struct {
+	QuestionID uint     // 问题ID
+	Key        string   // 问题内容的 hash 值
+	Titles     []string // 生成的标题
+	Tags       []string // 生成的标签
+	Score      float32  // 余弦相似度
+}
+
+// QuestionAnswer 问题的答案和生成信息
+type QuestionAnswer struct {
+	Key              string     // 问题内容的 hash 值
+	Answer           string     // 完整答案
+	Model            string     // 生成答案的模型
+	PromptTokens     int        // 提示词的 token 数，命中缓存时为 0
+	CompletionTokens int        // 答案的 token 数，命中缓存时为 0
+	FinishReason     string     // 生成结束的原因
+	Cached           bool       // 是否为已保存的答案
+	Citations        []Citation // 结合站内内容生成答案时使用的参考资料
+	CitedArticleIDs  []uint     // 答案中引用了的文章ID，按编号顺序
+	Language         string     // 答案使用的语言
+}
+
+// AnswerOptions 生成答案的选项
+type AnswerOptions struct {
+	WithSources bool   // 检索站内文章和代码解释作为参考资料
+	TopK        int    // 最多使用的参考资料数量，不大于 0 时使用配置的默认值
+	Language    string // 答案使用的语言，为空时使用默认语言
+}
+
+// Citation 生成答案时提供给模型的一条参考资料
+type Citation struct {
+	Index int     // 在提示词中的编号，从 1 开始
+	Type  string  // 类型: article、code
+	ID    string  // 文章ID或代码解释的ID
+	Score float32 // 与问题的余弦相似度
+	Cited bool    // 答案中是否引用了该资料
 }
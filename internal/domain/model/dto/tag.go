@@ -0,0 +1,24 @@
+package dto
+
+// 标签的状态
+const (
+	TagStatusActive    = "active"    // 标准标签，模型生成的标签会映射到这些标签
+	TagStatusSuggested = "suggested" // 无法映射的模型标签，等待审核后启用或删除
+)
+
+// Tag 标签体系中的一个标签
+type Tag struct {
+	ID           uint     // 标签ID
+	Name         string   // 标准名称
+	Aliases      []string // 别名
+	ParentID     uint     // 父标签ID，0 表示顶级标签
+	Description  string   // 描述
+	Status       string   // 状态: active、suggested
+	SuggestCount uint     // 作为建议标签被模型生成的次数
+}
+
+// TagMapping 模型生成的标签映射到标签体系的结果
+type TagMapping struct {
+	Tags        []string // 映射后的标准标签，标签体系为空时为原样保留的标签
+	Suggestions []string // 无法映射的标签，已记录为建议标签
+}
@@ -2,6 +2,14 @@ package dto
 
 type TokenReq struct {
 	GenerateTokenKey string
+	Client           string // 调用方标识
+	UserID           uint   // 用户ID
+}
+
+// TokenClaims token 中记录的身份，至少包含用户和调用方之一
+type TokenClaims struct {
+	UserID uint
+	Client string
 }
 
 type TokenResp struct {
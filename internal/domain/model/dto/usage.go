@@ -0,0 +1,23 @@
+package dto
+
+// UsageQuery 用量查询条件
+type UsageQuery struct {
+	UserID   *uint    // 为 nil 时统计所有用户
+	AICode   string   // 为空时不过滤
+	Model    string   // 为空时不过滤
+	StartDay string   // 开始日期(含)，为空时不限制
+	EndDay   string   // 结束日期(含)，为空时不限制
+	GroupBy  []string // 分组维度: user、ai_code、model、day
+}
+
+// UsageStat 一个分组的用量
+type UsageStat struct {
+	UserID           uint    `json:"userID" gorm:"column:user_id"`
+	AICode           string  `json:"aiCode" gorm:"column:ai_code"`
+	Model            string  `json:"model" gorm:"column:llm_model"`
+	Day              string  `json:"day" gorm:"column:day"`
+	Calls            int64   `json:"calls" gorm:"column:calls"`
+	PromptTokens     int64   `json:"promptTokens" gorm:"column:prompt_tokens"`
+	CompletionTokens int64   `json:"completionTokens" gorm:"column:completion_tokens"`
+	Cost             float64 `json:"cost" gorm:"-"` // 按配置的模型单价计算
+}
@@ -3,3 +3,47 @@ package dto
 type VectorPrompt struct {
 	Content []string `json:"content"` // 生成向量对应的内容
 }
+
+// 向量存储中的内容类型
+const (
+	VectorTypeArticle  = "article"
+	VectorTypeQuestion = "question"
+	VectorTypeCode     = "code"
+	VectorTypeTag      = "tag" // 标签体系中的标准标签，只在内部使用
+)
+
+// VectorDoc 保存到向量存储中的一条内容
+type VectorDoc struct {
+	Type    string    // 类型: article、question、code
+	ID      string    // 调用方的ID，同类型内唯一
+	Content string    // 内容，未提供向量时根据内容生成
+	Tags    []string  // 标签，用于检索时过滤
+	Vector  []float32 // 向量，为空时根据内容生成
+}
+
+// VectorQuery 相似度检索的条件，Text 和 Vector 二选一
+type VectorQuery struct {
+	Text     string    // 查询文本
+	Vector   []float32 // 查询向量
+	TopK     int       // 返回的结果数量
+	Types    []string  // 只检索这些类型，为空时不限制
+	Tags     []string  // 只检索带有其中任一标签的内容，为空时不限制
+	MinScore float32   // 最低相似度
+}
+
+// VectorHit 一条检索结果
+type VectorHit struct {
+	Type    string
+	ID      string
+	Content string
+	Tags    []string
+	Score   float32 // 余弦相似度
+}
+
+// RelatedArticle 一篇相关文章，按 Score 从高到低排序
+type RelatedArticle struct {
+	ArticleID  uint     `json:"article_id"`
+	Score      float32  `json:"score"`       // 综合得分，由向量相似度和标签重合度加权得到
+	Similarity float32  `json:"similarity"`  // 摘要和总结的余弦相似度
+	CommonTags []string `json:"common_tags"` // 两篇文章共同的标签
+}
@@ -7,11 +7,47 @@ import (
 
 type Article struct {
 	gorm.Model
-	Key        string `gorm:"column:key"`                              // 用于标识文章的状态(是否被修改)
-	ArticleID  uint   `gorm:"column:article_id"`                       // 文章ID
-	Abstract   string `gorm:"column:abstract"`                         // 发布文章时，提取的文章摘要
-	Summary    string `gorm:"column:summary"`                          // 发布文章时，提取的文章总结
-	VisitCount uint64 `gorm:"column:visit_count;type:bigint unsigned"` // 记录该记录被访问的次数
+	Key           string  `gorm:"column:key"`                                  // 用于标识文章的状态(是否被修改)
+	ArticleID     uint    `gorm:"column:article_id"`                           // 文章ID
+	Abstract      string  `gorm:"column:abstract"`                             // 发布文章时，提取的文章摘要
+	Summary       string  `gorm:"column:summary"`                              // 发布文章时，提取的文章总结
+	Confidence    float64 `gorm:"column:confidence"`                           // 模型对摘要和总结的置信度
+	LLMModel      string  `gorm:"column:llm_model"`                            // 生成摘要和总结的模型
+	VisitCount    uint64  `gorm:"column:visit_count;type:bigint unsigned"`     // 记录该记录被访问的次数
+	Content       string  `gorm:"column:content;type:longtext"`                // 生成摘要和总结时的文章内容，更新文章时用于比较改动的大小
+	PromptVersion string  `gorm:"column:prompt_version"`                       // 生成摘要和总结使用的提示词模板版本
+	VersionID     uint    `gorm:"column:version_id"`                           // 当前使用的摘要和总结版本
+	Language      string  `gorm:"column:language;type:varchar(16);default:zh"` // 摘要和总结使用的语言，同一文章的每种语言各有一条记录
+	ArticleSEO
+}
+
+// ArticleSEO 文章的 SEO 信息，与摘要和总结保存在同一条记录中
+type ArticleSEO struct {
+	SEOTitle        string `gorm:"column:seo_title;type:varchar(255)"`        // 搜索结果中显示的标题
+	MetaDescription string `gorm:"column:meta_description;type:varchar(255)"` // meta description
+	Slug            string `gorm:"column:slug;type:varchar(128);index"`       // 文章的 URL slug
+	OGTitle         string `gorm:"column:og_title;type:varchar(255)"`         // Open Graph 标题
+	OGDescription   string `gorm:"column:og_description;type:varchar(512)"`   // Open Graph 描述
+}
+
+func (s ArticleSEO) ConvertArticleSEOEntityToDto() dto.ArticleSEO {
+	return dto.ArticleSEO{
+		SEOTitle:        s.SEOTitle,
+		MetaDescription: s.MetaDescription,
+		Slug:            s.Slug,
+		OGTitle:         s.OGTitle,
+		OGDescription:   s.OGDescription,
+	}
+}
+
+func ConvertArticleSEODtoToEntity(seo *dto.ArticleSEO) ArticleSEO {
+	return ArticleSEO{
+		SEOTitle:        seo.SEOTitle,
+		MetaDescription: seo.MetaDescription,
+		Slug:            seo.Slug,
+		OGTitle:         seo.OGTitle,
+		OGDescription:   seo.OGDescription,
+	}
 }
 
 //func (*ArticleFirst) TableName() string {
@@ -20,8 +56,13 @@ type Article struct {
 
 func (a *Article) ConvertArticleEntityToDtoFirst() *dto.ArticleFirst {
 	return &dto.ArticleFirst{
-		Abstract: a.Abstract,
-		Summary:  a.Summary,
+		Abstract:      a.Abstract,
+		Summary:       a.Summary,
+		Confidence:    a.Confidence,
+		Model:         a.LLMModel,
+		PromptVersion: a.PromptVersion,
+		Language:      a.Language,
+		SEO:           a.ArticleSEO.ConvertArticleSEOEntityToDto(),
 	}
 }
 
@@ -29,13 +70,17 @@ func (a *Article) ConvertArticleEntityToDtoSecond() *dto.ArticleSecond {
 	return &dto.ArticleSecond{
 		Abstract: a.Abstract,
 		Summary:  a.Summary,
+		Language: a.Language,
+		SEO:      a.ArticleSEO.ConvertArticleSEOEntityToDto(),
 	}
 }
 
 func ConvertArticleDtoToEntity(article *dto.ArticleFirst) *Article {
 	return &Article{
-		Key:      article.Key,
-		Abstract: article.Abstract,
-		Summary:  article.Summary,
+		Key:        article.Key,
+		Abstract:   article.Abstract,
+		Summary:    article.Summary,
+		Confidence: article.Confidence,
+		LLMModel:   article.Model,
 	}
 }
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"siwuai/internal/domain/model/dto"
+)
+
+// ArticleOverride 编辑人工修改的文章摘要、总结和标签，与模型生成的结果分开保存，为空的字段沿用模型生成的结果
+type ArticleOverride struct {
+	gorm.Model
+	ArticleID uint     `gorm:"column:article_id;uniqueIndex"`         // 文章ID
+	Abstract  string   `gorm:"column:abstract;type:text"`             // 人工修改的摘要
+	Summary   string   `gorm:"column:summary;type:text"`              // 人工修改的总结
+	Tags      []string `gorm:"column:tags;type:text;serializer:json"` // 人工修改的标签
+	EditorID  uint     `gorm:"column:editor_id"`                      // 修改的用户ID
+}
+
+// Apply 用人工修改的字段替换模型生成的结果，为空的字段保持不变，o 为 nil 时不做修改
+func (o *ArticleOverride) Apply(info *dto.ArticleSecond) {
+	if o == nil {
+		return
+	}
+	if o.Abstract != "" {
+		info.Abstract = o.Abstract
+	}
+	if o.Summary != "" {
+		info.Summary = o.Summary
+	}
+	if len(o.Tags) > 0 {
+		info.Tags = o.Tags
+	}
+	info.HumanEdited = true
+}
+
+func ConvertArticleOverrideDtoToEntity(override *dto.ArticleOverride) *ArticleOverride {
+	return &ArticleOverride{
+		ArticleID: override.ArticleID,
+		Abstract:  override.Abstract,
+		Summary:   override.Summary,
+		Tags:      override.Tags,
+		EditorID:  override.EditorID,
+	}
+}
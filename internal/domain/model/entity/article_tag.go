@@ -0,0 +1,26 @@
+package entity
+
+import "gorm.io/gorm"
+
+// ArticleTag 文章与标签的关联，用于读取文章信息时返回标签和按标签查询文章
+// 模型生成的标签关联到生成它的文章记录，编辑人工修改的标签不属于任何记录，RecordID 为 0
+type ArticleTag struct {
+	gorm.Model
+	ArticleID uint   `gorm:"column:article_id;index"`           // 文章ID，记录保存文章ID前为 0
+	RecordID  uint   `gorm:"column:record_id;index"`            // 生成标签的文章记录ID，人工修改的标签为 0
+	Tag       string `gorm:"column:tag;type:varchar(64);index"` // 标签名称
+}
+
+// NewArticleTags 创建文章记录与标签的关联，重复的标签只保存一次
+func NewArticleTags(articleID, recordID uint, tags []string) []ArticleTag {
+	res := make([]ArticleTag, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, ArticleTag{ArticleID: articleID, RecordID: recordID, Tag: tag})
+	}
+	return res
+}
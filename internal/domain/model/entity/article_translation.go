@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"siwuai/internal/domain/model/dto"
+)
+
+// ArticleTranslation 文章的译文，同一文章的同一版本内容在每种语言下只保存一份
+type ArticleTranslation struct {
+	gorm.Model
+	ArticleID uint   `gorm:"column:article_id;uniqueIndex:idx_article_translation"`                // 文章ID
+	Key       string `gorm:"column:key;type:varchar(64);uniqueIndex:idx_article_translation"`      // 原文内容的 hash 值
+	Language  string `gorm:"column:language;type:varchar(16);uniqueIndex:idx_article_translation"` // 目标语言
+	Content   string `gorm:"column:content;type:longtext"`                                         // 完整译文
+	LLMModel  string `gorm:"column:llm_model"`                                                     // 翻译使用的模型
+}
+
+func (t *ArticleTranslation) ConvertArticleTranslationEntityToDto() *dto.ArticleTranslation {
+	return &dto.ArticleTranslation{
+		ArticleID: t.ArticleID,
+		Key:       t.Key,
+		Language:  t.Language,
+		Content:   t.Content,
+		Model:     t.LLMModel,
+	}
+}
+
+func ConvertArticleTranslationDtoToEntity(translation *dto.ArticleTranslation) *ArticleTranslation {
+	return &ArticleTranslation{
+		ArticleID: translation.ArticleID,
+		Key:       translation.Key,
+		Language:  translation.Language,
+		Content:   translation.Content,
+		LLMModel:  translation.Model,
+	}
+}
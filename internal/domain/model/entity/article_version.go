@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"siwuai/internal/domain/model/dto"
+)
+
+// ArticleVersion 文章每次生成的摘要、总结和标签，按文章ID保留全部版本，不会被覆盖
+type ArticleVersion struct {
+	gorm.Model
+	ArticleID     uint     `gorm:"column:article_id;uniqueIndex:idx_article_record"` // 文章ID
+	RecordID      uint     `gorm:"column:record_id;uniqueIndex:idx_article_record"`  // 生成该版本的文章记录的主键
+	Key           string   `gorm:"column:key;type:char(64)"`                         // 生成时文章内容的 hash 值
+	Abstract      string   `gorm:"column:abstract;type:text"`                        // 文章摘要
+	Summary       string   `gorm:"column:summary;type:text"`                         // 文章总结
+	Tags          []string `gorm:"column:tags;type:text;serializer:json"`            // 匹配的标签
+	Confidence    float64  `gorm:"column:confidence"`                                // 模型对摘要和总结的置信度
+	LLMModel      string   `gorm:"column:llm_model"`                                 // 生成摘要和总结的模型
+	PromptVersion string   `gorm:"column:prompt_version"`                            // 使用的提示词模板版本
+}
+
+func (v *ArticleVersion) ConvertArticleVersionEntityToDto() *dto.ArticleVersion {
+	return &dto.ArticleVersion{
+		VersionID:     v.ID,
+		ArticleID:     v.ArticleID,
+		Key:           v.Key,
+		Abstract:      v.Abstract,
+		Summary:       v.Summary,
+		Tags:          v.Tags,
+		Confidence:    v.Confidence,
+		Model:         v.LLMModel,
+		PromptVersion: v.PromptVersion,
+		CreatedAt:     v.CreatedAt,
+	}
+}
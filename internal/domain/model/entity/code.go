@@ -11,6 +11,8 @@ type Code struct {
 	Key         string
 	Question    string
 	Explanation string
+	LLMModel    string `gorm:"column:llm_model"`                            // 生成解释的模型
+	Language    string `gorm:"column:language;type:varchar(16);default:zh"` // 解释使用的语言，不同语言的 hash 值不同
 	// 一对多关联，一个 Code 可以有多个 History 记录
 	Histories []History `gorm:"foreignKey:CodeID"`
 }
@@ -29,7 +31,9 @@ func (c Code) CodeToDto() *dto.Code {
 		ID:          c.ID,
 		Question:    c.Question,
 		Explanation: c.Explanation,
+		Model:       c.LLMModel,
 		Key:         c.Key,
+		Language:    c.Language,
 	}
 }
 
@@ -41,5 +45,7 @@ func (c Code) DtoToCode(dto *dto.Code) *Code {
 		Key:         dto.Key,
 		Question:    dto.Question,
 		Explanation: dto.Explanation,
+		LLMModel:    dto.Model,
+		Language:    dto.Language,
 	}
 }
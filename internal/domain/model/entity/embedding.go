@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// Embedding 向量存储中的一条向量，按 (类型, ID) 唯一
+type Embedding struct {
+	gorm.Model
+	DocType  string   `gorm:"column:doc_type;type:varchar(16);uniqueIndex:idx_embedding_doc"` // 类型: article、question、code
+	DocID    string   `gorm:"column:doc_id;type:varchar(64);uniqueIndex:idx_embedding_doc"`   // 调用方的ID
+	Content  string   `gorm:"column:content;type:text"`                                       // 向量对应的内容
+	Tags     []string `gorm:"column:tags;type:text;serializer:json"`                          // 标签，用于检索时过滤
+	Vector   Vector   `gorm:"column:vector;type:mediumblob"`                                  // 向量
+	Dim      int      `gorm:"column:dim"`                                                     // 向量维度
+	LLMModel string   `gorm:"column:llm_model"`                                               // 生成向量的模型，调用方直接提供向量时为空
+}
+
+// Vector 以小端 float32 的二进制格式保存在数据库中
+type Vector []float32
+
+// Value 实现 driver.Valuer
+func (v Vector) Value() (driver.Value, error) {
+	buf := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return buf, nil
+}
+
+// Scan 实现 sql.Scanner
+func (v *Vector) Scan(value interface{}) error {
+	buf, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("无法将 %T 转换为向量", value)
+	}
+	if len(buf)%4 != 0 {
+		return fmt.Errorf("向量数据长度 %d 不是 4 的倍数", len(buf))
+	}
+	vec := make(Vector, len(buf)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	*v = vec
+	return nil
+}
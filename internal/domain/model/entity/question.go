@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"siwuai/internal/domain/model/dto"
+)
+
+// Question 问题的标题、标签和答案，按问题内容的 hash 值去重
+type Question struct {
+	gorm.Model
+	Key           string   `gorm:"column:key;type:char(64);uniqueIndex"`            // 问题内容的 hash 值
+	QuestionID    uint     `gorm:"column:question_id;index"`                        // 问题ID
+	Content       string   `gorm:"column:content;type:text"`                        // 问题内容
+	Titles        []string `gorm:"column:titles;type:text;serializer:json"`         // 生成的标题
+	Tags          []string `gorm:"column:tags;type:text;serializer:json"`           // 生成的标签，已映射为标准标签
+	SuggestedTags []string `gorm:"column:suggested_tags;type:text;serializer:json"` // 没有匹配标准标签的建议标签
+	Answer        string   `gorm:"column:answer;type:longtext"`                     // 生成的答案
+	TitleModel    string   `gorm:"column:title_model"`                              // 生成标题和标签的模型
+	AnswerModel   string   `gorm:"column:answer_model"`                             // 生成答案的模型
+	VisitCount    uint64   `gorm:"column:visit_count;type:bigint unsigned"`         // 记录该记录被访问的次数
+	Language      string   `gorm:"column:language;type:varchar(16);default:zh"`     // 标题和答案使用的语言，不同语言的 hash 值不同
+}
+
+func (q *Question) ConvertQuestionEntityToDto() *dto.Question {
+	return &dto.Question{
+		Key:           q.Key,
+		QuestionID:    q.QuestionID,
+		Titles:        q.Titles,
+		Tags:          q.Tags,
+		SuggestedTags: q.SuggestedTags,
+		Answer:        q.Answer,
+		TitleModel:    q.TitleModel,
+		AnswerModel:   q.AnswerModel,
+		Language:      q.Language,
+	}
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"siwuai/internal/domain/model/dto"
+)
+
+// Tag 标签体系中的一个标签，模型生成的标签映射到启用的标准标签，无法映射的作为建议标签等待审核
+type Tag struct {
+	gorm.Model
+	Name         string   `gorm:"column:name;type:varchar(64);uniqueIndex"` // 标准名称
+	Aliases      []string `gorm:"column:aliases;type:text;serializer:json"` // 别名，如 golang 对应 Go
+	ParentID     uint     `gorm:"column:parent_id;index"`                   // 父标签ID，0 表示顶级标签
+	Description  string   `gorm:"column:description;type:varchar(255)"`     // 描述，与名称和别名一起生成向量
+	Status       string   `gorm:"column:status;type:varchar(16);index"`     // active: 标准标签；suggested: 建议标签
+	SuggestCount uint     `gorm:"column:suggest_count"`                     // 作为建议标签被模型生成的次数
+}
+
+func (t *Tag) ConvertTagEntityToDto() *dto.Tag {
+	return &dto.Tag{
+		ID:           t.ID,
+		Name:         t.Name,
+		Aliases:      t.Aliases,
+		ParentID:     t.ParentID,
+		Description:  t.Description,
+		Status:       t.Status,
+		SuggestCount: t.SuggestCount,
+	}
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// Usage 大模型 token 用量，按用户、功能、模型、日期累计
+type Usage struct {
+	gorm.Model
+	UserID           uint   `gorm:"column:user_id;uniqueIndex:idx_usage_key"`                     // 用户ID，0 表示未知用户
+	AICode           string `gorm:"column:ai_code;type:varchar(32);uniqueIndex:idx_usage_key"`    // 功能
+	LLMModel         string `gorm:"column:llm_model;type:varchar(128);uniqueIndex:idx_usage_key"` // 模型
+	Day              string `gorm:"column:day;type:char(10);uniqueIndex:idx_usage_key"`           // 日期，格式 2006-01-02
+	Calls            int64  `gorm:"column:calls"`                                                 // 调用次数
+	PromptTokens     int64  `gorm:"column:prompt_tokens"`                                         // 提示词 token 数
+	CompletionTokens int64  `gorm:"column:completion_tokens"`                                     // 回答 token 数
+}
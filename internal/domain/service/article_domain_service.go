@@ -1,13 +1,26 @@
 package service
 
 import (
+	"context"
 	"siwuai/internal/domain/model/dto"
 )
 
 type ArticleDomainServiceInterface interface {
 	VerifyHash(key string) (*dto.ArticleFirst, error)
-	AskAI(key string, ap *dto.ArticlePrompt) (*dto.ArticleFirst, error)
-	SaveArticleID(key string, articleID uint) error
-	GetArticleInfo(articleID uint) (*dto.ArticleSecond, error)
-	DelArticleInfo(articleID uint) error
+	AskAI(ctx context.Context, key string, ap *dto.ArticlePrompt) (*dto.ArticleFirst, error)
+	AskAIStream(ctx context.Context, key string, ap *dto.ArticlePrompt, onEvent func(event dto.ArticleEvent) error) (*dto.ArticleFirst, error)
+	SaveArticleID(ctx context.Context, key string, articleID uint) error
+	UpdateArticleInfo(ctx context.Context, key string, ap *dto.ArticlePrompt, force bool) (*dto.ArticleUpdate, error)
+	ReuseArticleInfo(ctx context.Context, key string, ap *dto.ArticlePrompt) (*dto.ArticleUpdate, error)
+	GetArticleInfo(articleID uint, language string) (*dto.ArticleSecond, error)
+	DelArticleInfo(ctx context.Context, articleID uint) error
+	GetRelatedArticles(ctx context.Context, articleID uint, k int) ([]dto.RelatedArticle, error)
+	ListArticleVersions(articleID uint) ([]dto.ArticleVersion, error)
+	GetArticleVersion(articleID uint, versionID uint) (*dto.ArticleVersion, error)
+	PinArticleVersion(ctx context.Context, articleID uint, versionID uint) (*dto.ArticleVersion, error)
+	SaveArticleOverride(ctx context.Context, override *dto.ArticleOverride) error
+	ListArticlesByTag(tag string, afterID uint, limit int) (*dto.ArticlesByTag, error)
+	GenerateArticleSEO(ctx context.Context, key string, ap *dto.ArticlePrompt, article *dto.ArticleFirst) (*dto.ArticleSEO, error)
+	TranslateArticle(ctx context.Context, articleID uint, content string, language string, onChunk func(chunk string) error) (*dto.ArticleTranslation, error)
+	TranslationSaved(articleID uint, content string, language string) (bool, error)
 }
@@ -1,11 +1,13 @@
 package service
 
 import (
+	"context"
 	"siwuai/internal/domain/model/dto"
 )
 
 type CodeDomainService interface {
-	ExplainCode(req *dto.CodeReq) (*dto.Code, error)
-	FetchAndSave(req *dto.CodeReq, key string) (*dto.Code, error)
+	ExplainCode(ctx context.Context, req *dto.CodeReq) (*dto.Code, error)
+	Cached(req *dto.CodeReq) (bool, error)
+	FetchAndSave(ctx context.Context, req *dto.CodeReq, key string) (*dto.Code, error)
 	SaveToRedis(key string, code *dto.Code) (err error)
 }
@@ -0,0 +1,254 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"regexp"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/utils"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultMaxPromptTokens  = 6000 // 未配置时文章不分段的最大 token 数
+	defaultChunkTokens      = 3000 // 未配置时每段的最大 token 数
+	defaultChunkConcurrency = 4    // 未配置时同时总结的段数
+)
+
+var (
+	headingRe = regexp.MustCompile(`^#{1,6}\s`)      // markdown 标题
+	fenceRe   = regexp.MustCompile("^\\s*(```|~~~)") // 代码块的开始或结束
+)
+
+// condenseArticle 文章过长时按标题和段落分段，并发提炼各段的要点，返回以各段要点代替原文的请求参数
+// 各段的要点按内容的 hash 值缓存，修改文章的某一段时只需重新总结这一段；文章不长时原样返回
+func (a *articleDomainService) condenseArticle(ctx context.Context, ap *dto.ArticlePrompt) (*dto.ArticlePrompt, error) {
+	maxTokens := a.cfg.Article.MaxPromptTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxPromptTokens
+	}
+	tokens := llm.EstimateTokens(ap.Content)
+	if tokens <= maxTokens {
+		return ap, nil
+	}
+
+	chunkTokens := a.cfg.Article.ChunkTokens
+	if chunkTokens <= 0 {
+		chunkTokens = defaultChunkTokens
+	}
+	chunks := splitArticle(ap.Content, chunkTokens)
+	zap.L().Info("文章过长，分段总结",
+		zap.Uint("articleID", ap.ArticleID),
+		zap.Int("tokens", tokens),
+		zap.Int("chunks", len(chunks)))
+
+	points, err := a.summarizeChunks(ctx, chunks, ap.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	intro, heading := condensedText(ap.Language)
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = fmt.Sprintf(heading, i+1) + "\n" + strings.TrimSpace(p)
+	}
+	condensed := *ap
+	condensed.Content = intro + "\n\n" + strings.Join(parts, "\n\n")
+	return &condensed, nil
+}
+
+// condensedText 以各段要点代替原文时的说明和每段要点的标题，与最终生成摘要的提示词使用相同的语言
+func condensedText(language string) (intro string, heading string) {
+	switch prompt.NormalizeLanguage(language) {
+	case "en":
+		return "(The original article is long. Below are the key points of each part, in the original order.)", "## Key points of part %d"
+	case "ja":
+		return "（原文が長いため、以下は原文の順に並べた各部分の要点です）", "## 第 %d 部分の要点"
+	default:
+		return "（原文较长，以下为按原文顺序排列的各部分要点）", "## 第 %d 部分要点"
+	}
+}
+
+// summarizeChunks 并发提炼各段的要点，同时进行的调用不超过配置的并发数，任一段失败时取消其余调用
+func (a *articleDomainService) summarizeChunks(ctx context.Context, chunks []string, language string) ([]string, error) {
+	concurrency := a.cfg.Article.ChunkConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultChunkConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	points := make([]string, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			point, err := a.summarizeChunk(ctx, &dto.ArticleChunkPrompt{Chunk: chunk, Index: i + 1, Total: len(chunks), Language: language})
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			points[i] = point
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) summarizeChunks -> %w", firstErr)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) summarizeChunks -> %w", err)
+	}
+	return points, nil
+}
+
+// summarizeChunk 提炼一段的要点，优先使用缓存，要点按内容和语言缓存
+func (a *articleDomainService) summarizeChunk(ctx context.Context, cp *dto.ArticleChunkPrompt) (string, error) {
+	hashValue, err := utils.HashLanguage(cp.Chunk, cp.Language)
+	if err != nil {
+		return "", err
+	}
+	key := "article:chunk:" + hashValue
+	if data, err := a.cm.Get(key); err == nil && data != nil {
+		return string(data), nil
+	}
+
+	answer, err := utils.Generate(ctx, a.provider, a.registry, constant.ArticleChunkCode, cp)
+	if err != nil {
+		return "", err
+	}
+	point, _ := answer["text"].(string)
+	if point = strings.TrimSpace(point); point == "" {
+		return "", fmt.Errorf("第 %d 段的要点为空", cp.Index)
+	}
+	a.cm.Set(key, []byte(point), a.jct.GetArticleFlag())
+	return point, nil
+}
+
+// splitArticle 将 markdown 文章分为不超过 maxTokens 的若干段
+// 优先在标题处分段，其次在段落之间，代码块不拆开；单个段落或代码块超过 maxTokens 时按行截断
+func splitArticle(content string, maxTokens int) []string {
+	var chunks []string
+	var current []string
+	currentTokens := 0
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, "\n\n"))
+			current, currentTokens = nil, 0
+		}
+	}
+
+	for _, block := range markdownBlocks(content) {
+		tokens := llm.EstimateTokens(block)
+		switch {
+		case tokens > maxTokens:
+			flush()
+			chunks = append(chunks, splitByTokens(block, maxTokens)...)
+			continue
+		case currentTokens+tokens > maxTokens:
+			flush()
+		case headingRe.MatchString(block) && currentTokens >= maxTokens/2:
+			// 当前段已有一定长度时，新的章节从新的一段开始
+			flush()
+		}
+		current = append(current, block)
+		currentTokens += tokens
+	}
+	flush()
+	return chunks
+}
+
+// markdownBlocks 将 markdown 按空行分为段落，标题单独成为一个段落的开头，代码块内的空行不分段
+func markdownBlocks(content string) []string {
+	var blocks []string
+	var lines []string
+	inFence := false
+	flush := func() {
+		if block := strings.TrimSpace(strings.Join(lines, "\n")); block != "" {
+			blocks = append(blocks, block)
+		}
+		lines = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if fenceRe.MatchString(line) {
+			if !inFence {
+				flush()
+			}
+			inFence = !inFence
+			lines = append(lines, line)
+			if !inFence {
+				flush()
+			}
+			continue
+		}
+		if inFence {
+			lines = append(lines, line)
+			continue
+		}
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case headingRe.MatchString(line):
+			flush()
+			lines = append(lines, line)
+		default:
+			lines = append(lines, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// splitByTokens 将过长的段落截断为不超过 maxTokens 的若干段，尽量在换行处截断
+func splitByTokens(block string, maxTokens int) []string {
+	var pieces []string
+	runes := []rune(block)
+	start, lastNewline := 0, -1
+	var tokens float64
+	for i, r := range runes {
+		tokens += llm.RuneTokens(r)
+		if r == '\n' {
+			lastNewline = i
+		}
+		if tokens <= float64(maxTokens) {
+			continue
+		}
+		end := i
+		if lastNewline > start {
+			end = lastNewline
+		}
+		if piece := strings.TrimSpace(string(runes[start:end])); piece != "" {
+			pieces = append(pieces, piece)
+		}
+		start, lastNewline = end, -1
+		tokens = 0
+		for _, r := range runes[start : i+1] {
+			tokens += llm.RuneTokens(r)
+		}
+	}
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+		pieces = append(pieces, rest)
+	}
+	return pieces
+}
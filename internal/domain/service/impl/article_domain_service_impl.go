@@ -1,6 +1,7 @@
 package impl
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"go.uber.org/zap"
@@ -11,27 +12,58 @@ import (
 	"siwuai/internal/infrastructure/cache"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
 	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
 	"siwuai/internal/infrastructure/utils"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
+)
+
+const (
+	// maxRelatedArticles 缓存的相关文章数量，请求的数量不超过该值时从缓存中截取
+	maxRelatedArticles = 20
+	// maxArticlesByTag 按标签查询文章时每页的最大数量
+	maxArticlesByTag = 100
+	// articleCacheDelDelay 文章信息变化后第二次删除缓存的延迟
+	articleCacheDelDelay = time.Second
+	// defaultDetachedTimeout 未配置超时时间时，不随请求取消的生成过程的超时时间
+	defaultDetachedTimeout = 5 * time.Minute
+)
+
+var (
+	markdownRe = regexp.MustCompile(`(?m)^#+\s*|\*\*`) // markdown 的标题和加粗标记
+	tagSepRe   = regexp.MustCompile(`[、,，]`)           // 标签分隔符
+
+	answerAbstractRe = regexp.MustCompile(`(?s)` + abstractLabel + `\s*(.*?)\s*` + summaryLabel)
+	answerSummaryRe  = regexp.MustCompile(`(?s)` + summaryLabel + `\s*(.*?)\s*` + tagsLabel)
+	answerTagsRe     = regexp.MustCompile(tagsLabel + `\s*([^\n]+)`)
 )
 
 type articleDomainService struct {
-	repo persistence.ArticleRepositoryInterface
-	sign constant.JudgingSignInterface
-	cfg  config.Config
-	cm   cache.CacheManagerInterface
-	jct  constant.JudgingCacheType
+	repo      persistence.ArticleRepositoryInterface
+	sign      constant.JudgingSignInterface
+	cfg       config.Config
+	cm        cache.CacheManagerInterface
+	jct       constant.JudgingCacheType
+	provider  llm.LLMProvider
+	registry  prompt.Registry
+	knowledge service.KnowledgeDomainService
+	tags      service.TagDomainService
 }
 
-func NewArticleDomainService(repo persistence.ArticleRepositoryInterface, sign constant.JudgingSignInterface, cfg config.Config, cm cache.CacheManagerInterface, jct constant.JudgingCacheType) service.ArticleDomainServiceInterface {
+func NewArticleDomainService(repo persistence.ArticleRepositoryInterface, sign constant.JudgingSignInterface, cfg config.Config, cm cache.CacheManagerInterface, jct constant.JudgingCacheType, provider llm.LLMProvider, registry prompt.Registry, knowledge service.KnowledgeDomainService, tags service.TagDomainService) service.ArticleDomainServiceInterface {
 	return &articleDomainService{
-		repo: repo,
-		sign: sign,
-		cfg:  cfg,
-		cm:   cm,
-		jct:  jct,
+		repo:      repo,
+		sign:      sign,
+		cfg:       cfg,
+		cm:        cm,
+		jct:       jct,
+		provider:  provider,
+		registry:  registry,
+		knowledge: knowledge,
+		tags:      tags,
 	}
 }
 
@@ -41,85 +73,393 @@ func (a *articleDomainService) VerifyHash(key string) (*dto.ArticleFirst, error)
 	if err != nil {
 		return nil, err
 	}
-	return articleInfo.ConvertArticleEntityToDtoFirst(), nil
+	tags, err := a.repo.GetArticleTags(articleInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	articleFirst := articleInfo.ConvertArticleEntityToDtoFirst()
+	articleFirst.Tags = tags
+	return articleFirst, nil
 }
 
-func (a *articleDomainService) AskAI(key string, ap *dto.ArticlePrompt) (*dto.ArticleFirst, error) {
-	answer, err := utils.Generate(a.sign.GetArticleFlag(), ap, a.cfg)
-	//answer, stream, err := utils.GenerateStream(globals.ArticleAICode, ap)
+func (a *articleDomainService) AskAI(ctx context.Context, key string, ap *dto.ArticlePrompt) (*dto.ArticleFirst, error) {
+	// 文章过长时先分段总结，再以各段的要点生成摘要、总结和标签
+	condensed, err := a.condenseArticle(ctx, ap)
 	if err != nil {
-		fmt.Println("utils.Generate() err: ", err)
-		return nil, fmt.Errorf("(a *articleDomainService) VerifyHash -> %v", err)
+		return nil, fmt.Errorf("(a *articleDomainService) AskAI -> %w", err)
 	}
 
-	//fmt.Println("stream:", stream)
+	answer, err := utils.Generate(ctx, a.provider, a.registry, a.sign.GetArticleFlag(), condensed)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAI -> %w", err)
+	}
 
 	if answer["text"] == nil {
 		return &dto.ArticleFirst{}, nil
 	}
 
-	// 提取数据
-	articleFirst := a.ParseAnswer(answer["text"].(string))
-	//articleFirst := a.ParseAnswer(answer)
+	// 提取数据：优先使用通过 schema 校验的 JSON，仍不符合时才使用正则兜底解析
+	var articleFirst *dto.ArticleFirst
+	if data, ok := answer["json"].(string); ok {
+		articleFirst, err = a.ParseJSONAnswer(data)
+		if err != nil {
+			zap.L().Error("解析文章分析JSON失败，使用正则兜底解析", zap.Error(err))
+		}
+	}
+	if articleFirst == nil {
+		articleFirst = a.ParseAnswer(answer["text"].(string))
+	}
+
+	model, _ := answer["model"].(string)
+	promptVersion, _ := answer["promptVersion"].(string)
+	articleFirst, err = a.saveArticleFirst(ctx, key, ap, articleFirst, model, promptVersion)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAI -> %w", err)
+	}
+	return articleFirst, nil
+}
+
+// AskAIStream 流式调用大模型提炼文章的摘要、总结、标签，每解析出一段内容调用一次 onEvent，
+// 生成结束后与 AskAI 一样持久化结果，最后以 done 事件返回最终结果
+func (a *articleDomainService) AskAIStream(ctx context.Context, key string, ap *dto.ArticlePrompt, onEvent func(event dto.ArticleEvent) error) (*dto.ArticleFirst, error) {
+	// 文章过长时先分段总结，只有汇总的过程是流式的
+	condensed, err := a.condenseArticle(ctx, ap)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAIStream -> %w", err)
+	}
+
+	// 生成不随请求取消，客户端断开后仍生成完并保存结果，下次请求可以直接使用；由单独的超时时间限制
+	genCtx, cancel := a.detachedContext(ctx, a.sign.GetArticleFlag())
+	defer cancel()
+	streamChan1, streamChan2, doneChan, err := utils.GenerateStream(genCtx, a.provider, a.registry, a.sign.GetArticleFlag(), condensed, a.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAIStream -> %w", err)
+	}
+
+	// 完整回答由解析器累积，第二个通道的内容直接丢弃
+	go func() {
+		for range streamChan2 {
+		}
+	}()
+
+	// 发送失败(如客户端断开)后继续读取通道，生成完成的结果仍会保存
+	parser := &articleStreamParser{}
+	var sendErr error
+	for chunk := range streamChan1 {
+		if sendErr == nil {
+			sendErr = sendEvents(onEvent, parser.Feed(chunk))
+		} else {
+			parser.Feed(chunk)
+		}
+	}
+
+	done := <-doneChan
+	if done.Err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAIStream -> %w", done.Err)
+	}
+	if sendErr == nil {
+		sendErr = sendEvents(onEvent, parser.Flush())
+	}
+
+	// 以完整回答重新解析，结果以 done 事件为准
+	articleFirst := a.parseStreamAnswer(parser.Text())
+	articleFirst, err = a.saveArticleFirst(genCtx, key, ap, articleFirst, done.Model, done.PromptVersion)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAIStream -> %v", err)
+	}
+	if sendErr != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAIStream -> %w", sendErr)
+	}
+
+	if err = onEvent(dto.ArticleEvent{Type: dto.ArticleEventDone, Result: articleFirst}); err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) AskAIStream -> %w", err)
+	}
+	return articleFirst, nil
+}
+
+// detachedContext 返回不随 ctx 取消的 context，超时时间为 code 单次调用的超时时间乘以最多尝试的次数
+func (a *articleDomainService) detachedContext(ctx context.Context, code constant.AICode) (context.Context, context.CancelFunc) {
+	rc := a.cfg.Resilience
+	seconds, ok := rc.Timeouts[string(code)]
+	if !ok {
+		seconds = rc.Timeout
+	}
+	timeout := defaultDetachedTimeout
+	if seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second * time.Duration(max(rc.MaxRetries, 0)+1)
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
+// sendEvents 依次发送事件，遇到错误时停止
+func sendEvents(onEvent func(event dto.ArticleEvent) error, events []dto.ArticleEvent) error {
+	for _, event := range events {
+		if err := onEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseStreamAnswer 解析流式生成的完整回答，流式输出未经 schema 校验，JSON 解析失败时使用正则兜底解析
+func (a *articleDomainService) parseStreamAnswer(text string) *dto.ArticleFirst {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start >= 0 && end > start {
+		articleFirst, err := a.ParseJSONAnswer(text[start : end+1])
+		if err == nil {
+			return articleFirst
+		}
+		zap.L().Error("解析文章分析JSON失败，使用正则兜底解析", zap.Error(err))
+	}
+	return a.ParseAnswer(text)
+}
+
+// saveArticleFirst 持久化提炼出的文章信息，未能提取出摘要和总结时不持久化，避免空结果被写入数据库并缓存
+func (a *articleDomainService) saveArticleFirst(ctx context.Context, key string, ap *dto.ArticlePrompt, articleFirst *dto.ArticleFirst, model string, promptVersion string) (*dto.ArticleFirst, error) {
+	if articleFirst.Abstract == "" && articleFirst.Summary == "" {
+		zap.L().Warn("未能从模型输出中提取文章的摘要和总结", zap.Uint("articleID", ap.ArticleID))
+		return articleFirst, nil
+	}
 	articleFirst.Key = key
+	articleFirst.Model = model
+	articleFirst.PromptVersion = promptVersion
+	articleFirst.Language = prompt.NormalizeLanguage(ap.Language)
 
-	//fmt.Println()
-	//fmt.Println("------------------------------------------------")
-	//fmt.Printf("^^^^^^^^^^^^^^^----------> \n %v \n", articleFirst.Abstract)
-	//fmt.Println("------------------------------------------------")
-	//fmt.Println()
-	//fmt.Println("------------------------------------------------")
-	//fmt.Printf("^^^^^^^^^^^^^^^----------> \n %v \n", articleFirst.Summary)
-	//fmt.Println("------------------------------------------------")
-	//fmt.Println()
-	//fmt.Println("------------------------------------------------")
-	//fmt.Printf("^^^^^^^^^^^^^^^----------> \n %v \n", articleFirst.Tags)
-	//fmt.Println("------------------------------------------------")
-	//fmt.Println()
+	// 将模型输出的标签映射到标签体系中的标准标签
+	mapping := a.tags.NormalizeTags(ctx, articleFirst.Tags)
+	articleFirst.Tags = mapping.Tags
+	articleFirst.SuggestedTags = mapping.Suggestions
 
 	// 持久化数据
 	articleE := &entity.Article{
-		Key:       key,
-		Abstract:  articleFirst.Abstract,
-		Summary:   articleFirst.Summary,
-		ArticleID: ap.ArticleID,
+		Key:           key,
+		Abstract:      articleFirst.Abstract,
+		Summary:       articleFirst.Summary,
+		Confidence:    articleFirst.Confidence,
+		LLMModel:      articleFirst.Model,
+		ArticleID:     ap.ArticleID,
+		Content:       ap.Content,
+		PromptVersion: promptVersion,
+		Language:      articleFirst.Language,
 	}
 
-	err = a.repo.SaveArticleInfo(articleE)
+	err := a.repo.SaveArticleInfo(articleE)
 	if err != nil {
-		return nil, fmt.Errorf("(a *articleDomainService) VerifyHash -> %v", err)
+		return nil, fmt.Errorf("(a *articleDomainService) saveArticleFirst -> %v", err)
 	}
+	if err = a.repo.SaveArticleTags(articleE.ID, articleE.ArticleID, articleFirst.Tags); err != nil {
+		zap.L().Error("保存文章的标签失败", zap.Uint("articleID", articleE.ArticleID), zap.Error(err))
+	}
+	a.recordVersion(articleE, articleFirst.Tags)
+	a.indexArticle(ctx, articleE, articleFirst.Tags)
 
 	return articleFirst, nil
 }
 
-// SaveArticleID 保存文章的ID
-func (a *articleDomainService) SaveArticleID(key string, articleID uint) error {
+// SaveArticleID 保存文章的ID，并将文章的摘要和总结写入向量存储
+func (a *articleDomainService) SaveArticleID(ctx context.Context, key string, articleID uint) error {
 	err := a.repo.SaveArticleID(key, articleID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	articleE, err := a.repo.VerifyHash(key)
+	if err != nil {
+		zap.L().Error("读取文章信息失败，未写入向量存储", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+	tags := a.articleTags(articleE.ID)
+	a.recordVersion(articleE, tags)
+	a.indexArticle(ctx, articleE, tags)
+	a.invalidateArticle(articleID)
+	return nil
+}
+
+// UpdateArticleInfo 文章内容修改后更新文章在 ap.Language 语言下的信息，新版本内容关联到原有的文章ID，该语言旧版本的记录被删除
+// 改动比例低于阈值时沿用原有的摘要和总结，否则或 force 为 true 时重新生成；人工修改的内容只在 force 为 true 时清除
+func (a *articleDomainService) UpdateArticleInfo(ctx context.Context, key string, ap *dto.ArticlePrompt, force bool) (*dto.ArticleUpdate, error) {
+	if !force {
+		update, err := a.ReuseArticleInfo(ctx, key, ap)
+		if err != nil {
+			return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> %w", err)
+		}
+		if update != nil {
+			return update, nil
+		}
+	}
+
+	language := prompt.NormalizeLanguage(ap.Language)
+	current, err := a.repo.GetArticleInfo(ap.ArticleID, language)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> %v", err)
+	}
+	if current.ArticleID == 0 {
+		return nil, persistence.ErrArticleNotFound
+	}
+
+	update := &dto.ArticleUpdate{ChangeRatio: 1}
+	if current.Content != "" {
+		update.ChangeRatio = utils.ChangeRatio(current.Content, ap.Content)
+	}
+
+	// 重新生成，新记录保存时已带有文章ID并写入向量存储
+	articleFirst, err := a.AskAI(ctx, key, ap)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> %w", err)
+	}
+	if articleFirst.Key == "" {
+		return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> 未能生成文章的摘要和总结")
+	}
+	latest, err := a.repo.GetArticleInfo(ap.ArticleID, language)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> %v", err)
+	}
+	if err = a.repo.LinkArticleVersion(latest.ID, ap.ArticleID, language); err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> %v", err)
+	}
+	// 人工修改的内容只作用于默认语言
+	if force && language == prompt.DefaultLanguage {
+		cleared, err := a.clearArticleOverride(ap.ArticleID)
+		if err != nil {
+			return nil, fmt.Errorf("(a *articleDomainService) UpdateArticleInfo -> %v", err)
+		}
+		// 保存新记录时写入向量存储的仍是人工修改的内容，清除后重新写入
+		if cleared {
+			a.indexArticle(ctx, latest, articleFirst.Tags)
+		}
+	}
+	a.invalidateArticle(ap.ArticleID)
+
+	update.ArticleFirst = *articleFirst
+	update.Regenerated = true
+	return update, nil
 }
 
-// GetArticleInfo 非首次获取文章的信息
-func (a *articleDomainService) GetArticleInfo(articleID uint) (*dto.ArticleSecond, error) {
+// ReuseArticleInfo 内容没有变化、新内容已经生成过或改动比例低于阈值时沿用已有的摘要和总结，需要重新生成时返回 nil
+func (a *articleDomainService) ReuseArticleInfo(ctx context.Context, key string, ap *dto.ArticlePrompt) (*dto.ArticleUpdate, error) {
+	language := prompt.NormalizeLanguage(ap.Language)
+	current, err := a.repo.GetArticleInfo(ap.ArticleID, language)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) ReuseArticleInfo -> %v", err)
+	}
+	if current.ArticleID == 0 {
+		return nil, persistence.ErrArticleNotFound
+	}
+
+	update := &dto.ArticleUpdate{ChangeRatio: 1}
+	if current.Content != "" {
+		update.ChangeRatio = utils.ChangeRatio(current.Content, ap.Content)
+	}
+
+	// 内容没有变化
+	if current.Key == key {
+		update.ArticleFirst = *current.ConvertArticleEntityToDtoFirst()
+		update.Key = key
+		update.Tags = a.articleTags(current.ID)
+		update.ChangeRatio = 0
+		return update, nil
+	}
+
+	// 新版本的内容已经生成过摘要和总结(如先调用了 GetArticleInfoFirst)，直接关联
+	if existing, err := a.repo.VerifyHash(key); err == nil && (existing.Abstract != "" || existing.Summary != "") {
+		if err = a.repo.LinkArticleVersion(existing.ID, ap.ArticleID, language); err != nil {
+			return nil, fmt.Errorf("(a *articleDomainService) ReuseArticleInfo -> %v", err)
+		}
+		existing.ArticleID = ap.ArticleID
+		tags := a.articleTags(existing.ID)
+		a.recordVersion(existing, tags)
+		a.indexArticle(ctx, existing, tags)
+		a.invalidateArticle(ap.ArticleID)
+		update.ArticleFirst = *existing.ConvertArticleEntityToDtoFirst()
+		update.Key = key
+		update.Tags = tags
+		return update, nil
+	}
+
+	// 改动较小，沿用原有的摘要和总结
+	if update.ChangeRatio < a.cfg.Article.RegenerateRatio {
+		if err = a.repo.UpdateArticleContent(current.ID, key, ap.Content); err != nil {
+			return nil, fmt.Errorf("(a *articleDomainService) ReuseArticleInfo -> %v", err)
+		}
+		a.invalidateArticle(ap.ArticleID)
+		update.ArticleFirst = *current.ConvertArticleEntityToDtoFirst()
+		update.Key = key
+		update.Tags = a.articleTags(current.ID)
+		return update, nil
+	}
+	return nil, nil
+}
+
+// indexArticle 将已有ID的文章写入向量存储，有人工修改时写入修改后的内容，失败只记录日志，不影响文章信息的保存
+// 向量存储中每篇文章只保存默认语言的内容，其他语言的记录不写入
+func (a *articleDomainService) indexArticle(ctx context.Context, articleE *entity.Article, tags []string) {
+	if articleE.ArticleID == 0 || !isDefaultLanguage(articleE.Language) {
+		return
+	}
+	info := &dto.ArticleSecond{Abstract: articleE.Abstract, Summary: articleE.Summary, Tags: tags}
+	a.articleOverride(articleE.ArticleID).Apply(info)
+	if err := a.knowledge.IndexArticle(ctx, articleE.ArticleID, info.Abstract, info.Summary, info.Tags); err != nil {
+		zap.L().Error("文章写入向量存储失败", zap.Uint("articleID", articleE.ArticleID), zap.Error(err))
+	}
+}
+
+// articleTags 查询文章记录生成的标签，查询失败时记录日志并视为没有标签
+func (a *articleDomainService) articleTags(recordID uint) []string {
+	tags, err := a.repo.GetArticleTags(recordID)
+	if err != nil {
+		zap.L().Error("查询文章的标签失败", zap.Uint("recordID", recordID), zap.Error(err))
+		return nil
+	}
+	return tags
+}
+
+// GetArticleInfo 非首次获取文章在指定语言下的信息
+func (a *articleDomainService) GetArticleInfo(articleID uint, language string) (*dto.ArticleSecond, error) {
+	language = prompt.NormalizeLanguage(language)
+	if !slices.Contains(a.registry.Languages(a.sign.GetArticleFlag()), language) {
+		return nil, fmt.Errorf("(a *articleDomainService) GetArticleInfo -> %w", prompt.ErrLanguageNotSupported)
+	}
+
 	// 从缓存获取数据，优先从本地缓存获取，然后是Redis
 	// strconv.FormatUint(uint64(articleID), 10)
-	data, err := a.cm.Get(fmt.Sprintf("article:%d", articleID))
+	data, err := a.cm.Get(cache.ArticleKey(articleID, language))
 	if data == nil && err == nil {
 		// 查询数据库
-		articleInfo, err := a.repo.GetArticleInfo(articleID)
+		articleInfo, err := a.repo.GetArticleInfo(articleID, language)
 		if err != nil {
 			return nil, err
 		}
 
 		articleDto := articleInfo.ConvertArticleEntityToDtoSecond()
 
+		// 人工修改的内容优先于模型生成的结果，人工修改只作用于默认语言
+		if articleInfo.ArticleID != 0 {
+			articleDto.Tags, err = a.repo.GetArticleTags(articleInfo.ID)
+			if err != nil {
+				return nil, err
+			}
+			if language == prompt.DefaultLanguage {
+				override, err := a.repo.GetArticleOverride(articleID)
+				if err != nil {
+					return nil, err
+				}
+				override.Apply(articleDto)
+			}
+		}
+
+		// 没有查到记录或摘要、总结为空时不缓存
+		if articleInfo.ArticleID == 0 || (articleDto.Abstract == "" && articleDto.Summary == "") {
+			return articleDto, nil
+		}
+
 		jsonData, err := json.Marshal(*articleDto)
 		if err != nil {
 			zap.L().Error("文章信息序列化失败，设置Redis缓存失败", zap.Error(err))
+			return articleDto, nil
 		}
 
 		// 设置缓存，同时设置本地缓存和Redis缓存
-		a.cm.Set(strconv.FormatUint(uint64(articleInfo.ArticleID), 10), jsonData, a.jct.GetArticleFlag())
+		a.cm.Set(cache.ArticleKey(articleInfo.ArticleID, language), jsonData, a.jct.GetArticleFlag())
 
 		// 返回数据
 		return articleDto, nil
@@ -136,31 +476,122 @@ func (a *articleDomainService) GetArticleInfo(articleID uint) (*dto.ArticleSecon
 }
 
 // DelArticleInfo 删除文章信息
-func (a *articleDomainService) DelArticleInfo(articleID uint) error {
+func (a *articleDomainService) DelArticleInfo(ctx context.Context, articleID uint) error {
 	err := a.repo.DelArticleInfo(articleID)
-	return err
+	if err != nil {
+		return err
+	}
+	if err = a.knowledge.DelArticle(ctx, articleID); err != nil {
+		zap.L().Error("从向量存储删除文章失败", zap.Uint("articleID", articleID), zap.Error(err))
+	}
+	a.invalidateArticle(articleID)
+	return nil
 }
 
-// ParseAnswer 解析答案
+// GetRelatedArticles 获取与文章相关的 k 篇文章，k 不超过 maxRelatedArticles
+// 缓存中保存 maxRelatedArticles 篇，不同的 k 共用同一个缓存
+func (a *articleDomainService) GetRelatedArticles(ctx context.Context, articleID uint, k int) ([]dto.RelatedArticle, error) {
+	k = min(k, maxRelatedArticles)
+	key := relatedCacheKey(articleID)
+
+	data, err := a.cm.Get(key)
+	if err == nil && data != nil {
+		var related []dto.RelatedArticle
+		if err = json.Unmarshal(data, &related); err == nil {
+			return related[:min(k, len(related))], nil
+		}
+		zap.L().Error("相关文章反序列化失败", zap.Error(err))
+	}
+
+	related, err := a.knowledge.RelatedArticles(ctx, articleID, maxRelatedArticles)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GetRelatedArticles -> %w", err)
+	}
+
+	// 没有相关文章时不缓存，之后发布的文章可以立即出现
+	if len(related) > 0 {
+		if jsonData, err := json.Marshal(related); err != nil {
+			zap.L().Error("相关文章序列化失败，设置缓存失败", zap.Error(err))
+		} else {
+			a.cm.Set(key, jsonData, a.jct.GetArticleFlag())
+		}
+	}
+	return related[:min(k, len(related))], nil
+}
+
+// ListArticlesByTag 按文章ID从小到大分页查询带有该标签的文章，afterID 为上一页最后一篇文章的ID，每页不超过 maxArticlesByTag 篇
+// 标签的别名按标签体系映射为标准标签后查询，有人工修改标签的文章按修改后的标签匹配
+func (a *articleDomainService) ListArticlesByTag(tag string, afterID uint, limit int) (*dto.ArticlesByTag, error) {
+	res := &dto.ArticlesByTag{Tag: a.tags.CanonicalTag(tag)}
+	articleIDs, err := a.repo.ListArticlesByTag(res.Tag, afterID, min(limit, maxArticlesByTag))
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) ListArticlesByTag -> %v", err)
+	}
+	res.ArticleIDs = articleIDs
+	return res, nil
+}
+
+// invalidateArticle 文章的记录变化或被删除后，删除本地缓存和Redis中各语言的文章信息及其相关文章，其他文章的相关文章缓存过期后更新
+// 布隆过滤器无法删除元素，其中保留的 key 只会让读取穿透到数据库，查到记录后重新写入缓存；
+// 变化前已读到旧记录的并发请求可能在删除后写回旧数据，因此延迟一段时间后再删除一次
+func (a *articleDomainService) invalidateArticle(articleID uint) {
+	a.delArticleCache(articleID)
+	time.AfterFunc(articleCacheDelDelay, func() {
+		a.delArticleCache(articleID)
+	})
+}
+
+func (a *articleDomainService) delArticleCache(articleID uint) {
+	keys := []string{relatedCacheKey(articleID)}
+	for _, language := range a.registry.Languages(a.sign.GetArticleFlag()) {
+		keys = append(keys, cache.ArticleKey(articleID, language))
+	}
+	for _, key := range keys {
+		if err := a.cm.Delete(key); err != nil {
+			zap.L().Error("删除文章缓存失败", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// isDefaultLanguage 是否为默认语言，功能上线前的记录语言为空
+func isDefaultLanguage(language string) bool {
+	return prompt.NormalizeLanguage(language) == prompt.DefaultLanguage
+}
+
+func relatedCacheKey(articleID uint) string {
+	return fmt.Sprintf("article:related:%d", articleID)
+}
+
+// ParseJSONAnswer 解析通过 schema 校验的 JSON 答案
+func (a *articleDomainService) ParseJSONAnswer(answer string) (*dto.ArticleFirst, error) {
+	var meta dto.ArticleFirst
+	if err := json.Unmarshal([]byte(answer), &meta); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal() err: %v", err)
+	}
+	meta.Abstract = strings.TrimSpace(meta.Abstract)
+	meta.Summary = strings.TrimSpace(meta.Summary)
+	return &meta, nil
+}
+
+// ParseAnswer 解析答案，仅在模型未返回有效 JSON 时作为兜底使用
 func (a *articleDomainService) ParseAnswer(answer string) *dto.ArticleFirst {
 	meta := dto.ArticleFirst{}
 
-	summaryRe := regexp.MustCompile(`(?s)摘要:\s*(.*?)\s*总结:`)
-	if matches := summaryRe.FindStringSubmatch(answer); len(matches) > 1 {
+	// 统一中英文冒号，去掉 markdown 的标题和加粗标记
+	answer = strings.ReplaceAll(answer, "：", ":")
+	answer = markdownRe.ReplaceAllString(answer, "")
+
+	if matches := answerAbstractRe.FindStringSubmatch(answer); len(matches) > 1 {
 		meta.Abstract = strings.TrimSpace(matches[1])
 	}
 
-	// 修复点2：处理中文标点
-	conclusionRe := regexp.MustCompile(`(?s)总结:\s*(.*?)\s*匹配的标签:`)
-	if matches := conclusionRe.FindStringSubmatch(answer); len(matches) > 1 {
+	if matches := answerSummaryRe.FindStringSubmatch(answer); len(matches) > 1 {
 		meta.Summary = strings.TrimSpace(matches[1])
 	}
 
-	// 修复点3：支持中文冒号和换行
-	tagRe := regexp.MustCompile(`匹配的标签:\s*([^\n]+)`)
-	if matches := tagRe.FindStringSubmatch(answer); len(matches) > 1 {
+	if matches := answerTagsRe.FindStringSubmatch(answer); len(matches) > 1 {
 		tagStr := strings.ReplaceAll(matches[1], " ", "") // 移除空格
-		tags := strings.Split(tagStr, "、")
+		tags := tagSepRe.Split(tagStr, -1)
 		meta.Tags = tags
 	}
 
@@ -0,0 +1,55 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
+)
+
+// SaveArticleOverride 保存编辑人工修改的默认语言的摘要、总结和标签，所有字段都为空时删除修改，恢复使用模型生成的结果
+// 人工修改的内容优先于模型生成的结果，之后重新生成不会覆盖，只有更新文章时强制重新生成才会清除
+func (a *articleDomainService) SaveArticleOverride(ctx context.Context, override *dto.ArticleOverride) error {
+	current, err := a.repo.GetArticleInfo(override.ArticleID, prompt.DefaultLanguage)
+	if err != nil {
+		return fmt.Errorf("(a *articleDomainService) SaveArticleOverride -> %v", err)
+	}
+	if current.ArticleID == 0 {
+		return fmt.Errorf("(a *articleDomainService) SaveArticleOverride -> %w", persistence.ErrArticleNotFound)
+	}
+
+	if override.Empty() {
+		err = a.repo.DelArticleOverride(override.ArticleID)
+	} else {
+		err = a.repo.SaveArticleOverride(entity.ConvertArticleOverrideDtoToEntity(override))
+	}
+	if err != nil {
+		return fmt.Errorf("(a *articleDomainService) SaveArticleOverride -> %v", err)
+	}
+
+	a.indexArticle(ctx, current, a.articleTags(current.ID))
+	a.invalidateArticle(override.ArticleID)
+	return nil
+}
+
+// clearArticleOverride 强制重新生成后清除人工修改的内容，返回是否有内容被清除
+func (a *articleDomainService) clearArticleOverride(articleID uint) (bool, error) {
+	override, err := a.repo.GetArticleOverride(articleID)
+	if err != nil || override == nil {
+		return false, err
+	}
+	return true, a.repo.DelArticleOverride(articleID)
+}
+
+// articleOverride 查询人工修改的内容，用于写入向量存储，查询失败时记录日志并视为没有修改
+func (a *articleDomainService) articleOverride(articleID uint) *entity.ArticleOverride {
+	override, err := a.repo.GetArticleOverride(articleID)
+	if err != nil {
+		zap.L().Error("查询文章的人工修改失败", zap.Uint("articleID", articleID), zap.Error(err))
+		return nil
+	}
+	return override
+}
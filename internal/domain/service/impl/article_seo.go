@@ -0,0 +1,59 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/utils"
+)
+
+// seoContentChars 生成 SEO 信息时放入提示词的文章开头的最大字符数，其余内容由摘要和总结概括
+const seoContentChars = 2000
+
+// GenerateArticleSEO 根据已生成的摘要和总结为 key 对应的文章记录生成 SEO 信息并保存
+// 模型输出先按提示词模板的 schema 校验，再由服务端校验必填、长度和 slug 格式，不符合时返回包装 dto.ErrInvalidSEO 的错误
+func (a *articleDomainService) GenerateArticleSEO(ctx context.Context, key string, ap *dto.ArticlePrompt, article *dto.ArticleFirst) (*dto.ArticleSEO, error) {
+	articleE, err := a.repo.VerifyHash(key)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GenerateArticleSEO -> %v", err)
+	}
+
+	content := ap.Content
+	if runes := []rune(content); len(runes) > seoContentChars {
+		content = string(runes[:seoContentChars])
+	}
+	answer, err := utils.Generate(ctx, a.provider, a.registry, constant.ArticleSEOCode, &dto.ArticleSEOPrompt{
+		Content:  content,
+		Abstract: article.Abstract,
+		Summary:  article.Summary,
+		Tags:     article.Tags,
+		Language: ap.Language,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GenerateArticleSEO -> %w", err)
+	}
+	data, ok := answer["json"].(string)
+	if !ok {
+		return nil, fmt.Errorf("(a *articleDomainService) GenerateArticleSEO -> 模型输出不是有效的 JSON: %w", dto.ErrInvalidSEO)
+	}
+	var seo dto.ArticleSEO
+	if err = json.Unmarshal([]byte(data), &seo); err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GenerateArticleSEO -> %v: %w", err, dto.ErrInvalidSEO)
+	}
+	seo.Normalize()
+	if err = seo.Validate(); err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GenerateArticleSEO -> %w", err)
+	}
+
+	if err = a.repo.SaveArticleSEO(articleE.ID, entity.ConvertArticleSEODtoToEntity(&seo)); err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GenerateArticleSEO -> %v", err)
+	}
+	// 已发布的文章读取信息时返回 SEO 信息，删除缓存的旧内容
+	if articleE.ArticleID != 0 {
+		a.invalidateArticle(articleE.ArticleID)
+	}
+	return &seo, nil
+}
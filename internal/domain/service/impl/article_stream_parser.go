@@ -0,0 +1,249 @@
+package impl
+
+import (
+	"encoding/json"
+	"regexp"
+	"siwuai/internal/domain/model/dto"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// textHoldback 文本格式下未结束的字段保留末尾的字符暂不输出，避免把不完整的"总结"、"Matched tags"等标记当作内容输出
+// 与最长的标记加上之前的换行一样长
+const textHoldback = 13
+
+// 文本格式的字段标记，中文的标记可以省略冒号；英文、日文的标记只在行首且带冒号时识别，避免与内容中的同一单词混淆
+const (
+	abstractLabel = `(?:摘要:?|(?im:^[ \t]*(?:abstract|要旨|要約)[ \t]*:))`
+	summaryLabel  = `(?:总结:?|(?im:^[ \t]*(?:summary|まとめ)[ \t]*:))`
+	tagsLabel     = `(?:匹配的标签:?|(?im:^[ \t]*(?:matched tags|tags|タグ)[ \t]*:))`
+)
+
+var (
+	jsonAbstractRe = regexp.MustCompile(`"abstract"\s*:\s*"`)
+	jsonSummaryRe  = regexp.MustCompile(`"summary"\s*:\s*"`)
+	jsonTagsRe     = regexp.MustCompile(`"tags"\s*:\s*\[`)
+	textAbstractRe = regexp.MustCompile(abstractLabel)
+	textSummaryRe  = regexp.MustCompile(summaryLabel)
+	textTagsRe     = regexp.MustCompile(tagsLabel)
+)
+
+// articleFields 从未生成完的回答中解析出的字段，done 表示字段已经生成完
+type articleFields struct {
+	abstract, summary         string
+	abstractDone, summaryDone bool
+	tags                      []string
+	tagsDone                  bool
+}
+
+// articleStreamParser 边接收模型输出边解析摘要、总结和标签，转换为增量事件
+// 同时支持 JSON 格式(v3 模板)和"摘要: 总结: 匹配的标签:"文本格式(v1、v2 模板)，文本格式也识别英文、日文的标记
+type articleStreamParser struct {
+	buf      strings.Builder
+	abstract string // 已输出的摘要
+	summary  string // 已输出的总结
+	tagsSent bool
+}
+
+// Feed 追加一段模型输出，返回新产生的事件
+func (p *articleStreamParser) Feed(chunk string) []dto.ArticleEvent {
+	p.buf.WriteString(chunk)
+	return p.events(false)
+}
+
+// Flush 模型输出结束，返回剩余的事件
+func (p *articleStreamParser) Flush() []dto.ArticleEvent {
+	return p.events(true)
+}
+
+// Text 目前收到的完整输出
+func (p *articleStreamParser) Text() string {
+	return p.buf.String()
+}
+
+func (p *articleStreamParser) events(final bool) []dto.ArticleEvent {
+	// 保留末尾的换行，文本格式的标签以换行结束
+	text := strings.TrimLeftFunc(p.buf.String(), unicode.IsSpace)
+	// 末尾被截断的多字节字符等补全后再解析，避免"匹配的标签"等标记被截断后无法识别
+	for !final && text != "" {
+		if r, size := utf8.DecodeLastRuneInString(text); r != utf8.RuneError || size != 1 {
+			break
+		}
+		text = text[:len(text)-1]
+	}
+	if text == "" {
+		return nil
+	}
+
+	var fields articleFields
+	holdback := 0
+	if strings.HasPrefix(text, "{") || strings.HasPrefix(text, "`") {
+		fields = parseJSONFields(text)
+	} else {
+		fields = parseTextFields(text)
+		holdback = textHoldback
+	}
+	if final {
+		fields.abstractDone, fields.summaryDone, fields.tagsDone = true, true, true
+		holdback = 0
+	}
+
+	var events []dto.ArticleEvent
+	if delta, ok := nextDelta(&p.abstract, fields.abstract, fields.abstractDone, holdback); ok {
+		events = append(events, dto.ArticleEvent{Type: dto.ArticleEventAbstract, Text: delta})
+	}
+	if delta, ok := nextDelta(&p.summary, fields.summary, fields.summaryDone, holdback); ok {
+		events = append(events, dto.ArticleEvent{Type: dto.ArticleEventSummary, Text: delta})
+	}
+	if !p.tagsSent && fields.tagsDone && len(fields.tags) > 0 {
+		p.tagsSent = true
+		events = append(events, dto.ArticleEvent{Type: dto.ArticleEventTags, Tags: fields.tags})
+	}
+	return events
+}
+
+// nextDelta 计算相对已输出内容的增量，已输出内容不再是当前内容的前缀时(如 markdown 标记被去掉)暂不输出
+// 与最终解析结果一致去掉首尾空白，未结束的字段末尾的空白等后续内容出现后再输出
+func nextDelta(sent *string, value string, done bool, holdback int) (string, bool) {
+	value = strings.TrimSpace(value)
+	if !done && holdback > 0 {
+		if n := utf8.RuneCountInString(value); n > holdback {
+			value = strings.TrimRightFunc(string([]rune(value)[:n-holdback]), unicode.IsSpace)
+		} else {
+			value = ""
+		}
+	}
+	if len(value) <= len(*sent) || !strings.HasPrefix(value, *sent) {
+		return "", false
+	}
+	delta := value[len(*sent):]
+	*sent = value
+	return delta, true
+}
+
+// parseJSONFields 解析未生成完的 JSON，字符串字段返回目前已生成的部分
+func parseJSONFields(text string) articleFields {
+	var fields articleFields
+	fields.abstract, fields.abstractDone = partialJSONString(text, jsonAbstractRe)
+	fields.summary, fields.summaryDone = partialJSONString(text, jsonSummaryRe)
+
+	loc := jsonTagsRe.FindStringIndex(text)
+	if loc == nil {
+		return fields
+	}
+	if end := closingBracket(text, loc[1]); end >= 0 {
+		if err := json.Unmarshal([]byte(text[loc[1]-1:end+1]), &fields.tags); err == nil {
+			fields.tagsDone = true
+		}
+	}
+	return fields
+}
+
+// partialJSONString 读取 re 匹配位置之后的 JSON 字符串，未生成完时返回已完整生成的部分
+func partialJSONString(text string, re *regexp.Regexp) (string, bool) {
+	loc := re.FindStringIndex(text)
+	if loc == nil {
+		return "", false
+	}
+	start, i, done := loc[1], loc[1], false
+	for i < len(text) {
+		c := text[i]
+		if c == '"' {
+			done = true
+			break
+		}
+		if c != '\\' {
+			i++
+			continue
+		}
+		// 转义序列不完整时停在转义之前
+		if i+1 >= len(text) {
+			break
+		}
+		if text[i+1] != 'u' {
+			i += 2
+			continue
+		}
+		if i+6 > len(text) {
+			break
+		}
+		// 代理对需要两个 \u 转义一起解码
+		if r, err := strconv.ParseUint(text[i+2:i+6], 16, 32); err == nil && r >= 0xD800 && r < 0xDC00 {
+			if i+12 > len(text) {
+				break
+			}
+			i += 12
+			continue
+		}
+		i += 6
+	}
+
+	raw := text[start:i]
+	// 多字节字符可能被截断
+	for !utf8.ValidString(raw) && len(raw) > 0 {
+		raw = raw[:len(raw)-1]
+	}
+	var value string
+	if err := json.Unmarshal([]byte(`"`+raw+`"`), &value); err != nil {
+		return "", false
+	}
+	return value, done
+}
+
+// closingBracket 返回与 start 之前的 '[' 匹配的 ']' 的位置，未生成完时返回 -1
+func closingBracket(text string, start int) int {
+	inString, escaped := false, false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = inString
+		case c == '"':
+			inString = !inString
+		case c == ']' && !inString:
+			return i
+		}
+	}
+	return -1
+}
+
+// parseTextFields 解析"摘要: 总结: 匹配的标签:"格式的回答，规则与 ParseAnswer 一致
+func parseTextFields(text string) articleFields {
+	var fields articleFields
+	text = strings.ReplaceAll(text, "：", ":")
+	text = markdownRe.ReplaceAllString(text, "")
+
+	abstractLoc := textAbstractRe.FindStringIndex(text)
+	if abstractLoc == nil {
+		return fields
+	}
+	rest := text[abstractLoc[1]:]
+	summaryLoc := textSummaryRe.FindStringIndex(rest)
+	if summaryLoc == nil {
+		fields.abstract = strings.TrimSpace(rest)
+		return fields
+	}
+	fields.abstract, fields.abstractDone = strings.TrimSpace(rest[:summaryLoc[0]]), true
+
+	rest = rest[summaryLoc[1]:]
+	tagsLoc := textTagsRe.FindStringIndex(rest)
+	if tagsLoc == nil {
+		fields.summary = strings.TrimSpace(rest)
+		return fields
+	}
+	fields.summary, fields.summaryDone = strings.TrimSpace(rest[:tagsLoc[0]]), true
+
+	// 标签只有一行，换行后视为结束
+	rest = strings.TrimLeft(rest[tagsLoc[1]:], " \t")
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest, fields.tagsDone = rest[:end], true
+	}
+	if tagStr := strings.ReplaceAll(strings.TrimSpace(rest), " ", ""); tagStr != "" {
+		fields.tags = tagSepRe.Split(tagStr, -1)
+	}
+	return fields
+}
@@ -0,0 +1,209 @@
+package impl
+
+import (
+	"reflect"
+	"testing"
+
+	"siwuai/internal/domain/model/dto"
+)
+
+// streamResult 按事件类型拼接的增量输出
+type streamResult struct {
+	abstract, summary string
+	tags              []string
+	tagEvents         int
+}
+
+func (r *streamResult) add(events []dto.ArticleEvent) {
+	for _, event := range events {
+		switch event.Type {
+		case dto.ArticleEventAbstract:
+			r.abstract += event.Text
+		case dto.ArticleEventSummary:
+			r.summary += event.Text
+		case dto.ArticleEventTags:
+			r.tags = event.Tags
+			r.tagEvents++
+		}
+	}
+}
+
+// feedAll 依次输入 chunks 并结束输出，返回拼接后的结果
+func feedAll(chunks []string) (*streamResult, *articleStreamParser) {
+	p := &articleStreamParser{}
+	r := &streamResult{}
+	for _, chunk := range chunks {
+		r.add(p.Feed(chunk))
+	}
+	r.add(p.Flush())
+	return r, p
+}
+
+// 真实模型输出的几种形式，包含转义、\u 转义、代理对、首尾空白和 markdown 标记
+var streamOutputs = []struct {
+	name     string
+	output   string
+	abstract string
+	summary  string
+	tags     []string
+}{
+	{
+		name:     "JSON",
+		output:   `{"abstract": "介绍 \"goroutine\" 与 channel\n的用法 🚀", "summary": "你好，🚀 并发\\模型", "tags": ["Go", "并发"]}`,
+		abstract: "介绍 \"goroutine\" 与 channel\n的用法 🚀",
+		summary:  "你好，🚀 并发\\模型",
+		tags:     []string{"Go", "并发"},
+	},
+	{
+		name:     "代码块中的 JSON",
+		output:   "```json\n{\n  \"abstract\": \"Go 并发入门\",\n  \"summary\": \" 讲解 select 的用法\\n\",\n  \"tags\": [\"Go\", \"select\"]\n}\n```",
+		abstract: "Go 并发入门",
+		summary:  "讲解 select 的用法",
+		tags:     []string{"Go", "select"},
+	},
+	{
+		name:     "文本",
+		output:   "摘要：本文介绍 Go 的并发模型。\n总结：goroutine 轻量，channel 用于通信。\n匹配的标签：Go、并发\n",
+		abstract: "本文介绍 Go 的并发模型。",
+		summary:  "goroutine 轻量，channel 用于通信。",
+		tags:     []string{"Go", "并发"},
+	},
+	{
+		name:     "带 markdown 的文本",
+		output:   "## 摘要\n**本文**介绍 Go 的并发模型。\n\n## 总结\n使用 `sync.WaitGroup` 等待。\n\n**匹配的标签：** Go, 并发\n",
+		abstract: "本文介绍 Go 的并发模型。",
+		summary:  "使用 `sync.WaitGroup` 等待。",
+		tags:     []string{"Go", "并发"},
+	},
+	{
+		name:     "英文文本",
+		output:   "**Abstract:** This article covers tags and summary in Go.\n**Summary:** Use struct tags carefully.\n**Matched tags:** Go, Concurrency\n",
+		abstract: "This article covers tags and summary in Go.",
+		summary:  "Use struct tags carefully.",
+		tags:     []string{"Go", "Concurrency"},
+	},
+	{
+		name:     "日文文本",
+		output:   "## 要旨\n要旨：Go の並行処理を紹介します。\nまとめ：チャネルで通信します。\nタグ：Go、並行処理\n",
+		abstract: "Go の並行処理を紹介します。",
+		summary:  "チャネルで通信します。",
+		tags:     []string{"Go", "並行処理"},
+	},
+}
+
+// TestArticleStreamParserSplit 在每个字节位置切分模型输出，增量事件拼接后应与完整输出的解析结果一致
+func TestArticleStreamParserSplit(t *testing.T) {
+	a := &articleDomainService{}
+	for _, tt := range streamOutputs {
+		final := a.parseStreamAnswer(tt.output)
+		if final.Abstract != tt.abstract || final.Summary != tt.summary || !reflect.DeepEqual(final.Tags, tt.tags) {
+			t.Fatalf("%s: parseStreamAnswer() = %+v", tt.name, final)
+		}
+
+		check := func(t *testing.T, chunks []string) {
+			t.Helper()
+			r, p := feedAll(chunks)
+			if p.Text() != tt.output {
+				t.Fatalf("Text() = %q, want %q", p.Text(), tt.output)
+			}
+			if r.abstract != final.Abstract || r.summary != final.Summary {
+				t.Fatalf("chunks %q: abstract = %q, summary = %q, want %q, %q", chunks, r.abstract, r.summary, final.Abstract, final.Summary)
+			}
+			if r.tagEvents != 1 || !reflect.DeepEqual(r.tags, final.Tags) {
+				t.Fatalf("chunks %q: tags = %v (%d 次), want %v", chunks, r.tags, r.tagEvents, final.Tags)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i <= len(tt.output); i++ {
+				check(t, []string{tt.output[:i], tt.output[i:]})
+			}
+			bytes := make([]string, len(tt.output))
+			for i := 0; i < len(tt.output); i++ {
+				bytes[i] = tt.output[i : i+1]
+			}
+			check(t, bytes)
+		})
+	}
+}
+
+// TestArticleStreamParserPartial 转义和多字节字符被切断时不输出不完整的内容
+func TestArticleStreamParserPartial(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     string // 第一段输出
+		wantFirst string // 第一段之后输出的摘要
+		rest      string
+		want      string
+	}{
+		{name: "转义被切断", first: `{"abstract": "a\`, wantFirst: "a", rest: `"b"}`, want: `a"b`},
+		{name: "\\u 转义被切断", first: `{"abstract": "a\u4f`, wantFirst: "a", rest: `60b"}`, want: "a你b"},
+		{name: "代理对被切断", first: `{"abstract": "x\ud83d`, wantFirst: "x", rest: `\ude80y"}`, want: "x🚀y"},
+		{name: "代理对的第二个转义被切断", first: `{"abstract": "x\ud83d\ude`, wantFirst: "x", rest: `80y"}`, want: "x🚀y"},
+		{name: "UTF-8 字符被切断", first: "{\"abstract\": \"你\xe5\xa5", wantFirst: "你", rest: "\xbd\"}", want: "你好"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &articleStreamParser{}
+			got := &streamResult{}
+			got.add(p.Feed(tt.first))
+			if got.abstract != tt.wantFirst {
+				t.Fatalf("第一段之后 abstract = %q, want %q", got.abstract, tt.wantFirst)
+			}
+			got.add(p.Feed(tt.rest))
+			got.add(p.Flush())
+			if got.abstract != tt.want {
+				t.Fatalf("abstract = %q, want %q", got.abstract, tt.want)
+			}
+		})
+	}
+}
+
+// TestArticleStreamParserFallback 不是 JSON 的输出按文本格式解析，提前结束时输出已生成的部分
+func TestArticleStreamParserFallback(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   streamResult
+	}{
+		{
+			name:   "开头有说明文字时按文本解析",
+			output: "好的，以下是分析结果：\n摘要: 介绍 channel。\n总结: 适合入门。\n匹配的标签: Go\n",
+			want:   streamResult{abstract: "介绍 channel。", summary: "适合入门。", tags: []string{"Go"}, tagEvents: 1},
+		},
+		{
+			name:   "JSON 在总结中途结束",
+			output: `{"abstract": "完整的摘要", "summary": "只生成了一`,
+			want:   streamResult{abstract: "完整的摘要", summary: "只生成了一"},
+		},
+		{
+			name:   "JSON 在标签中途结束",
+			output: `{"abstract": "摘要", "summary": "总结", "tags": ["Go", "并`,
+			want:   streamResult{abstract: "摘要", summary: "总结"},
+		},
+		{
+			name:   "文本在总结中途结束",
+			output: "摘要: 一段摘要\n总结: 写到一半",
+			want:   streamResult{abstract: "一段摘要", summary: "写到一半"},
+		},
+		{
+			name:   "文本在标签行中途结束",
+			output: "摘要: 一段摘要\n总结: 一段总结\n匹配的标签: Go、并",
+			want:   streamResult{abstract: "一段摘要", summary: "一段总结", tags: []string{"Go", "并"}, tagEvents: 1},
+		},
+		{
+			name:   "没有任何字段",
+			output: "抱歉，我无法完成这个任务。",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i <= len(tt.output); i++ {
+				got, _ := feedAll([]string{tt.output[:i], tt.output[i:]})
+				if !reflect.DeepEqual(*got, tt.want) {
+					t.Fatalf("在 %d 处切分: got %+v, want %+v", i, *got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"regexp"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/utils"
+	"strings"
+	"unicode"
+)
+
+// defaultTranslateTokens 未配置时翻译文章每段的最大 token 数，译文与原文长度相近，比分段总结的段落短
+const defaultTranslateTokens = 1500
+
+// translateLanguages 支持翻译的目标语言及写入提示词的名称
+var translateLanguages = map[string]string{
+	"zh": "简体中文",
+	"en": "英语",
+	"ja": "日语",
+	"ko": "韩语",
+	"fr": "法语",
+	"de": "德语",
+	"es": "西班牙语",
+	"ru": "俄语",
+}
+
+var (
+	frontMatterRe = regexp.MustCompile(`\A---\n[\s\S]*?\n(---|\.\.\.)[ \t]*(\n|\z)`) // 文章开头的 front matter
+	// protectRe 翻译时需要原样保留的内容：行内代码、链接定义、链接和图片的地址、HTML 标签和自动链接、裸链接
+	protectRe = regexp.MustCompile("(?m)`[^`\\n]+`|^[ \\t]*\\[[^\\]\\n]+\\]:[ \\t]+\\S.*$|\\]\\([^)\\s]+(?:\\s+\"[^\"]*\")?\\)|<[a-zA-Z/][^>\\n]*>|https?://[^\\s)\\]>]+")
+)
+
+// translateSegment 翻译时文章的一段，translate 为 false 的段落(front matter、代码块)原样输出
+type translateSegment struct {
+	text      string
+	translate bool
+}
+
+// TranslateArticle 将文章翻译为 language 语言，按段落顺序每翻译完一段调用一次 onChunk，各段之间以空行分隔
+// 译文按文章ID、原文的 hash 值和语言保存，重复请求直接返回已保存的译文；翻译被取消或中途出错时不保存
+func (a *articleDomainService) TranslateArticle(ctx context.Context, articleID uint, content string, language string, onChunk func(chunk string) error) (*dto.ArticleTranslation, error) {
+	language = prompt.NormalizeLanguage(language)
+	name, ok := translateLanguages[language]
+	if !ok {
+		return nil, fmt.Errorf("(a *articleDomainService) TranslateArticle -> 不支持翻译为 %s: %w", language, prompt.ErrLanguageNotSupported)
+	}
+	key, err := utils.Hash(content)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) TranslateArticle -> %v", err)
+	}
+
+	translation, err := a.savedTranslation(articleID, key, language)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) TranslateArticle -> %v", err)
+	}
+	if translation != nil {
+		if err = onChunk(translation.Content); err != nil {
+			return nil, fmt.Errorf("(a *articleDomainService) TranslateArticle -> %w", err)
+		}
+		return translation, nil
+	}
+
+	translated, model, err := a.translate(ctx, content, name, onChunk)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) TranslateArticle -> %w", err)
+	}
+	translation = &dto.ArticleTranslation{
+		ArticleID: articleID,
+		Key:       key,
+		Language:  language,
+		Content:   translated,
+		Model:     model,
+	}
+
+	// 译文已经返回给调用方，保存失败只影响下次是否命中
+	if err = a.repo.SaveArticleTranslation(entity.ConvertArticleTranslationDtoToEntity(translation)); err != nil {
+		zap.L().Error("保存文章译文失败", zap.Uint("articleID", articleID), zap.String("language", language), zap.Error(err))
+	} else {
+		a.setTranslationCache(translation)
+	}
+	return translation, nil
+}
+
+// TranslationSaved 查询文章是否已有指定语言的译文，用于判断本次请求是否需要调用大模型，不支持的语言返回错误
+func (a *articleDomainService) TranslationSaved(articleID uint, content string, language string) (bool, error) {
+	language = prompt.NormalizeLanguage(language)
+	if _, ok := translateLanguages[language]; !ok {
+		return false, fmt.Errorf("(a *articleDomainService) TranslationSaved -> 不支持翻译为 %s: %w", language, prompt.ErrLanguageNotSupported)
+	}
+	key, err := utils.Hash(content)
+	if err != nil {
+		return false, fmt.Errorf("(a *articleDomainService) TranslationSaved -> %v", err)
+	}
+	translation, err := a.savedTranslation(articleID, key, language)
+	if err != nil {
+		return false, fmt.Errorf("(a *articleDomainService) TranslationSaved -> %v", err)
+	}
+	return translation != nil, nil
+}
+
+// savedTranslation 查询已保存的译文，优先使用缓存，数据库中查到时写入缓存；没有译文时返回 nil
+func (a *articleDomainService) savedTranslation(articleID uint, key string, language string) (*dto.ArticleTranslation, error) {
+	if data, err := a.cm.Get(translationCacheKey(articleID, key, language)); err == nil && data != nil {
+		var translation dto.ArticleTranslation
+		if err = json.Unmarshal(data, &translation); err == nil {
+			translation.Cached = true
+			return &translation, nil
+		}
+		zap.L().Error("文章译文反序列化失败", zap.Error(err))
+	}
+
+	translationE, err := a.repo.GetArticleTranslation(articleID, key, language)
+	if err != nil || translationE == nil {
+		return nil, err
+	}
+	translation := translationE.ConvertArticleTranslationEntityToDto()
+	a.setTranslationCache(translation)
+	translation.Cached = true
+	return translation, nil
+}
+
+func (a *articleDomainService) setTranslationCache(translation *dto.ArticleTranslation) {
+	data, err := json.Marshal(translation)
+	if err != nil {
+		zap.L().Error("文章译文序列化失败", zap.Error(err))
+		return
+	}
+	a.cm.Set(translationCacheKey(translation.ArticleID, translation.Key, translation.Language), data, a.jct.GetArticleFlag())
+}
+
+// translate 按顺序逐段翻译，front matter 和代码块原样输出，返回完整译文和翻译使用的模型
+func (a *articleDomainService) translate(ctx context.Context, content string, languageName string, onChunk func(chunk string) error) (string, string, error) {
+	maxTokens := a.cfg.Article.TranslateTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultTranslateTokens
+	}
+	segments := splitForTranslation(content, maxTokens)
+	total := 0
+	for _, seg := range segments {
+		if seg.translate {
+			total++
+		}
+	}
+
+	var translated strings.Builder
+	var model string
+	index := 0
+	for i, seg := range segments {
+		part := seg.text
+		if seg.translate {
+			index++
+			var err error
+			part, model, err = a.translateChunk(ctx, &dto.ArticleTranslatePrompt{Chunk: seg.text, LanguageName: languageName, Index: index, Total: total}, model)
+			if err != nil {
+				return "", "", err
+			}
+		}
+		if i > 0 {
+			part = "\n\n" + part
+		}
+		translated.WriteString(part)
+		if err := onChunk(part); err != nil {
+			return "", "", err
+		}
+	}
+	return translated.String(), model, nil
+}
+
+// translateChunk 翻译一段内容，行内代码和链接地址替换为占位符后再交给模型，翻译后还原
+// 译文中缺少占位符时无法保证链接完整，使用原文并记录日志；没有需要翻译的文字时不调用模型
+func (a *articleDomainService) translateChunk(ctx context.Context, tp *dto.ArticleTranslatePrompt, model string) (string, string, error) {
+	chunk := tp.Chunk
+	masked, originals := protectMarkdown(chunk)
+	if strings.IndexFunc(masked, unicode.IsLetter) < 0 {
+		return chunk, model, nil
+	}
+
+	var answer strings.Builder
+	maskedPrompt := *tp
+	maskedPrompt.Chunk = masked
+	res, err := utils.Stream(ctx, a.provider, a.registry, constant.ArticleTranslateCode, &maskedPrompt, a.cfg, func(chunk string) error {
+		answer.WriteString(chunk)
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	restored, ok := restoreMarkdown(strings.TrimSpace(answer.String()), originals)
+	if !ok || restored == "" {
+		zap.L().Warn("译文缺少占位符或为空，该段使用原文", zap.Int("index", tp.Index), zap.Int("total", tp.Total))
+		return chunk, res.Model, nil
+	}
+	return restored, res.Model, nil
+}
+
+// splitForTranslation 将 markdown 文章分为按顺序排列的若干段，front matter 和代码块单独成段且不翻译，
+// 其余内容按标题和段落分为不超过 maxTokens 的段落
+func splitForTranslation(content string, maxTokens int) []translateSegment {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	var segments []translateSegment
+	if frontMatter := frontMatterRe.FindString(content); frontMatter != "" {
+		segments = append(segments, translateSegment{text: strings.TrimRight(frontMatter, "\n")})
+		content = content[len(frontMatter):]
+	}
+
+	var text []string
+	flush := func() {
+		if len(text) == 0 {
+			return
+		}
+		for _, chunk := range splitArticle(strings.Join(text, "\n\n"), maxTokens) {
+			segments = append(segments, translateSegment{text: chunk, translate: true})
+		}
+		text = nil
+	}
+	for _, block := range markdownBlocks(content) {
+		if fenceRe.MatchString(block) {
+			flush()
+			segments = append(segments, translateSegment{text: block})
+			continue
+		}
+		text = append(text, block)
+	}
+	flush()
+	return segments
+}
+
+// protectMarkdown 将需要原样保留的内容替换为 ⟦编号⟧ 占位符，返回替换后的内容和按编号排列的原内容
+func protectMarkdown(text string) (string, []string) {
+	var originals []string
+	masked := protectRe.ReplaceAllStringFunc(text, func(m string) string {
+		prefix := ""
+		// 链接只保留地址部分，链接文字仍然翻译
+		if strings.HasPrefix(m, "](") {
+			prefix, m = "]", m[1:]
+		}
+		originals = append(originals, m)
+		return prefix + placeholder(len(originals)-1)
+	})
+	return masked, originals
+}
+
+// restoreMarkdown 将占位符还原为原内容，有占位符在译文中缺失时 ok 为 false
+func restoreMarkdown(text string, originals []string) (string, bool) {
+	pairs := make([]string, 0, len(originals)*2)
+	for i, original := range originals {
+		p := placeholder(i)
+		if !strings.Contains(text, p) {
+			return "", false
+		}
+		pairs = append(pairs, p, original)
+	}
+	return strings.NewReplacer(pairs...).Replace(text), true
+}
+
+func placeholder(i int) string {
+	return fmt.Sprintf("⟦%d⟧", i)
+}
+
+func translationCacheKey(articleID uint, key string, language string) string {
+	return fmt.Sprintf("article:translation:%d:%s:%s", articleID, language, key)
+}
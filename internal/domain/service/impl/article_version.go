@@ -0,0 +1,125 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
+)
+
+// recordVersion 将已有文章ID的记录生成的摘要、总结和标签保存为一个版本，失败只记录日志，不影响文章信息的保存
+// 版本只记录默认语言的记录，其他语言的记录随文章内容更新
+func (a *articleDomainService) recordVersion(articleE *entity.Article, tags []string) {
+	if articleE.ArticleID == 0 || !isDefaultLanguage(articleE.Language) || (articleE.Abstract == "" && articleE.Summary == "") {
+		return
+	}
+	version := &entity.ArticleVersion{
+		ArticleID:     articleE.ArticleID,
+		RecordID:      articleE.ID,
+		Key:           articleE.Key,
+		Abstract:      articleE.Abstract,
+		Summary:       articleE.Summary,
+		Tags:          tags,
+		Confidence:    articleE.Confidence,
+		LLMModel:      articleE.LLMModel,
+		PromptVersion: articleE.PromptVersion,
+	}
+	if err := a.repo.SaveArticleVersion(version); err != nil {
+		zap.L().Error("保存文章的摘要和总结版本失败", zap.Uint("articleID", articleE.ArticleID), zap.Error(err))
+		return
+	}
+	if articleE.VersionID == 0 {
+		articleE.VersionID = version.ID
+	}
+}
+
+// currentArticle 查询文章当前默认语言的记录，功能上线前生成的记录没有版本时先补充保存，避免被替换后无法找回
+func (a *articleDomainService) currentArticle(articleID uint) (*entity.Article, error) {
+	current, err := a.repo.GetArticleInfo(articleID, prompt.DefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+	if current.ArticleID == 0 {
+		return nil, persistence.ErrArticleNotFound
+	}
+	if current.VersionID == 0 {
+		a.recordVersion(current, a.articleTags(current.ID))
+	}
+	return current, nil
+}
+
+// ListArticleVersions 查询文章的全部摘要和总结版本，按生成时间从新到旧排序
+func (a *articleDomainService) ListArticleVersions(articleID uint) ([]dto.ArticleVersion, error) {
+	current, err := a.currentArticle(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) ListArticleVersions -> %w", err)
+	}
+
+	versions, err := a.repo.ListArticleVersions(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) ListArticleVersions -> %v", err)
+	}
+	res := make([]dto.ArticleVersion, len(versions))
+	for i, v := range versions {
+		res[i] = *v.ConvertArticleVersionEntityToDto()
+		res[i].Current = v.ID == current.VersionID
+	}
+	return res, nil
+}
+
+// GetArticleVersion 查询文章的指定版本
+func (a *articleDomainService) GetArticleVersion(articleID uint, versionID uint) (*dto.ArticleVersion, error) {
+	version, err := a.articleVersion(articleID, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GetArticleVersion -> %w", err)
+	}
+	current, err := a.currentArticle(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) GetArticleVersion -> %w", err)
+	}
+
+	res := version.ConvertArticleVersionEntityToDto()
+	res.Current = version.ID == current.VersionID
+	return res, nil
+}
+
+// PinArticleVersion 将指定版本设为文章当前使用的摘要和总结，之后重新生成时仍会产生新的版本
+func (a *articleDomainService) PinArticleVersion(ctx context.Context, articleID uint, versionID uint) (*dto.ArticleVersion, error) {
+	version, err := a.articleVersion(articleID, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) PinArticleVersion -> %w", err)
+	}
+	current, err := a.currentArticle(articleID)
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleDomainService) PinArticleVersion -> %w", err)
+	}
+
+	if current.VersionID != version.ID {
+		if err = a.repo.PinArticleVersion(current.ID, version); err != nil {
+			return nil, fmt.Errorf("(a *articleDomainService) PinArticleVersion -> %v", err)
+		}
+		current.Abstract = version.Abstract
+		current.Summary = version.Summary
+		a.indexArticle(ctx, current, version.Tags)
+		a.invalidateArticle(articleID)
+	}
+
+	res := version.ConvertArticleVersionEntityToDto()
+	res.Current = true
+	return res, nil
+}
+
+// articleVersion 查询属于该文章的版本，版本属于其他文章时同样视为不存在
+func (a *articleDomainService) articleVersion(articleID uint, versionID uint) (*entity.ArticleVersion, error) {
+	version, err := a.repo.GetArticleVersion(versionID)
+	if err != nil {
+		return nil, err
+	}
+	if version.ArticleID != articleID {
+		return nil, persistence.ErrArticleVersionNotFound
+	}
+	return version, nil
+}
@@ -1,18 +1,22 @@
 package impl
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
 	"strings"
 	"time"
 
 	"github.com/bits-and-blooms/bloom/v3"
+	"go.uber.org/zap"
 	"siwuai/internal/domain/model/dto"
 	"siwuai/internal/domain/model/entity"
 	"siwuai/internal/domain/service"
 	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
 	"siwuai/internal/infrastructure/redis_utils"
 	"siwuai/internal/infrastructure/utils"
 )
@@ -26,29 +30,35 @@ type codeDomainService struct {
 	bf          *bloom.BloomFilter
 	sign        constant.JudgingSignInterface
 	cfg         config.Config
+	provider    llm.LLMProvider
+	registry    prompt.Registry
+	knowledge   service.KnowledgeDomainService
 }
 
-func NewCodeDomainService(repo persistence.CodeRepository, redisClient *redis_utils.RedisClient, bf *bloom.BloomFilter, sign constant.JudgingSignInterface, cfg config.Config) service.CodeDomainService {
+func NewCodeDomainService(repo persistence.CodeRepository, redisClient *redis_utils.RedisClient, bf *bloom.BloomFilter, sign constant.JudgingSignInterface, cfg config.Config, provider llm.LLMProvider, registry prompt.Registry, knowledge service.KnowledgeDomainService) service.CodeDomainService {
 	return &codeDomainService{
 		repo:        repo,
 		redisClient: redisClient,
 		bf:          bf,
 		sign:        sign,
 		cfg:         cfg,
+		provider:    provider,
+		registry:    registry,
+		knowledge:   knowledge,
 	}
 }
 
-func (s *codeDomainService) ExplainCode(req *dto.CodeReq) (code *dto.Code, err error) {
-	key, err := utils.Hash(req.Question)
+func (s *codeDomainService) ExplainCode(ctx context.Context, req *dto.CodeReq) (code *dto.Code, err error) {
+	// 不同语言的解释分别生成和缓存
+	key, err := utils.HashLanguage(req.Question, req.Language)
 	if err != nil {
-		err = fmt.Errorf("utils.Hash() %v", err)
+		err = fmt.Errorf("utils.HashLanguage() %v", err)
 		return
 	}
 
-	code, err = s.GetAnswer(req, key)
+	code, err = s.GetAnswer(ctx, req, key)
 	if err != nil {
-		err = fmt.Errorf("s.GetAnswer() %v", err)
-		s.redisClient.Unlock(key)
+		err = fmt.Errorf("s.GetAnswer() %w", err)
 		return
 	}
 
@@ -63,47 +73,66 @@ func (s *codeDomainService) ExplainCode(req *dto.CodeReq) (code *dto.Code, err e
 	return
 }
 
+// Cached 查询代码解释是否已经生成过，不加锁也不同步缓存，只用于判断本次请求是否需要调用大模型
+func (s *codeDomainService) Cached(req *dto.CodeReq) (bool, error) {
+	key, err := utils.HashLanguage(req.Question, req.Language)
+	if err != nil {
+		return false, fmt.Errorf("utils.HashLanguage() %v", err)
+	}
+	if !s.bf.Test([]byte(key)) {
+		return false, nil
+	}
+	if data, err := s.redisClient.Get(key); err == nil && data != "" {
+		return true, nil
+	}
+	_, ok, err := s.repo.GetCodeByHash(key)
+	if err != nil {
+		return false, fmt.Errorf("repo.GetCodeByHash() %v", err)
+	}
+	return ok, nil
+}
+
 // GetAnswer 用于得到代码解释信息
-func (s *codeDomainService) GetAnswer(req *dto.CodeReq, key string) (code *dto.Code, err error) {
+func (s *codeDomainService) GetAnswer(ctx context.Context, req *dto.CodeReq, key string) (code *dto.Code, err error) {
 	// 尝试设置锁，locked为true表示设置锁成功
 	locked, err := s.redisClient.TryLock(key, lockTTL)
 	if err != nil {
 		err = fmt.Errorf("TryLock() %v", err)
 		return
 	}
-	fmt.Println("Locked: ", locked)
+	zap.L().Debug("尝试获取代码解释的锁", zap.String("key", key), zap.Bool("locked", locked))
 
 	if locked {
 		// 设置了锁，别的进程此时无法访问以下资源
 
 		// 1. 检查布隆过滤器
 		if !s.bf.Test([]byte(key)) {
-			fmt.Println("未命中布隆过滤器", key)
-			return s.FetchAndSave(req, key)
+			zap.L().Debug("未命中布隆过滤器", zap.String("key", key))
+			return s.fetchAndSaveLocked(ctx, req, key)
 		}
-		fmt.Println("成功命中布隆过滤器，开始查询缓存...")
+		zap.L().Debug("命中布隆过滤器，开始查询缓存", zap.String("key", key))
 
 		// 2. 检查 Redis 缓存
 		if code, err = s.checkRedis(key); err == nil && code != nil {
 			s.redisClient.Unlock(key)
 			return code, nil
 		}
-		fmt.Printf("未命中redis缓存: %s\n", key)
+		zap.L().Debug("未命中redis缓存", zap.String("key", key))
 
 		// 3. 检查 MySQL 记录
 		if code, err = s.checkMySQL(key); err == nil && code != nil {
 			s.redisClient.Unlock(key)
 			return code, nil
 		}
-		fmt.Printf("未命中mysql记录: %s\n", key)
+		zap.L().Debug("未命中mysql记录", zap.String("key", key))
 
 		// 4. 若布隆过滤器命中，但 Redis 和 MySQL 中都未查到，则调用 LLM
-		return s.FetchAndSave(req, key)
+		return s.fetchAndSaveLocked(ctx, req, key)
 	} else {
 		// 未获取锁，表示该锁正在被别人占用，等待并查询缓存
 		count := 1
 		for i := 1; i < 120; i++ {
-			fmt.Printf("第%d次循环查询缓存:", count)
+			zap.L().Debug("等待其他请求生成，循环查询缓存", zap.String("key", key), zap.Int("count", count))
 			if code, err = s.checkRedis(key); err == nil && code != nil {
 				return code, nil
 			}
@@ -111,11 +140,16 @@ func (s *codeDomainService) GetAnswer(req *dto.CodeReq, key string) (code *dto.C
 				return code, nil
 			}
 
-			time.Sleep(5 * time.Second)
+			// 客户端断开或请求超时后不再等待
+			select {
+			case <-time.After(5 * time.Second):
+			case <-ctx.Done():
+				return nil, fmt.Errorf("等待其他请求生成代码解释时 ctx 结束: %v", ctx.Err())
+			}
 			count++
 		}
 		err = fmt.Errorf("轮询进行redis、mysql查询时错误：%v", err)
-		fmt.Println("轮询超时")
+		zap.L().Debug("等待其他请求生成超时", zap.String("key", key))
 		return nil, err
 	}
 }
@@ -132,7 +166,7 @@ func (s *codeDomainService) checkRedis(key string) (*dto.Code, error) {
 		err = fmt.Errorf("json.Unmarshal() err: %v", err)
 		return nil, err
 	}
-	fmt.Printf("成功命中redis缓存: %#v\n", code)
+	zap.L().Debug("命中redis缓存", zap.String("key", key), zap.Uint("codeID", code.ID))
 
 	// 保存到 MySQL，确保一致性
 	entityCode := entity.Code{}.DtoToCode(code)
@@ -157,7 +191,7 @@ func (s *codeDomainService) checkMySQL(key string) (*dto.Code, error) {
 	}
 
 	code := entityCode.CodeToDto()
-	fmt.Printf("成功命中mysql记录: %#v\n", code)
+	zap.L().Debug("命中mysql记录", zap.String("key", key), zap.Uint("codeID", code.ID))
 
 	// 同步到 Redis，保证数据一致性
 	if err = s.SaveToRedis(key, code); err != nil {
@@ -168,15 +202,26 @@ func (s *codeDomainService) checkMySQL(key string) (*dto.Code, error) {
 	return code, nil
 }
 
+// fetchAndSaveLocked 在持有锁时调用 FetchAndSave，调用失败时释放锁
+func (s *codeDomainService) fetchAndSaveLocked(ctx context.Context, req *dto.CodeReq, key string) (*dto.Code, error) {
+	code, err := s.FetchAndSave(ctx, req, key)
+	if err != nil {
+		s.redisClient.Unlock(key)
+		return nil, err
+	}
+	return code, nil
+}
+
 // FetchAndSave 从 LLM 获取数据并保存到 MySQL、Redis、布隆过滤器
-func (s *codeDomainService) FetchAndSave(req *dto.CodeReq, key string) (*dto.Code, error) {
-	streamChan1, streamChan2, err := utils.GenerateStream(s.sign.GetCodeFlag(), req, s.cfg)
+// 生成被取消（客户端断开、请求超时、服务关闭）或中途出错时，不完整的解释不会被持久化和缓存，下次请求会重新生成
+func (s *codeDomainService) FetchAndSave(ctx context.Context, req *dto.CodeReq, key string) (*dto.Code, error) {
+	streamChan1, streamChan2, doneChan, err := utils.GenerateStream(ctx, s.provider, s.registry, s.sign.GetCodeFlag(), req, s.cfg)
 	if err != nil {
-		err = fmt.Errorf("utils.GenerateStream() %v", err)
+		err = fmt.Errorf("utils.GenerateStream() %w", err)
 		return nil, err
 	}
 
-	dtoCode := &dto.Code{Stream: streamChan1}
+	dtoCode := &dto.Code{Stream: streamChan1, Err: make(chan error, 1)}
 
 	go func() {
 		var completeResponse strings.Builder
@@ -184,16 +229,35 @@ func (s *codeDomainService) FetchAndSave(req *dto.CodeReq, key string) (*dto.Cod
 			completeResponse.WriteString(chunk)
 		}
 
+		// 将生成结果告知调用方，生成不完整时不保存
+		done := <-doneChan
+		genErr := done.Err
+		dtoCode.Model = done.Model
+		dtoCode.Err <- genErr
+		close(dtoCode.Err)
+		if genErr != nil {
+			zap.L().Warn("代码解释生成未完成，丢弃不完整的结果",
+				zap.String("key", key),
+				zap.Int("length", completeResponse.Len()),
+				zap.Error(genErr))
+			if err := s.redisClient.Unlock(key); err != nil {
+				zap.L().Error("s.redisClient.Unlock()", zap.Error(err))
+			}
+			return
+		}
+
 		totalStr := completeResponse.String()
 		code := &entity.Code{
 			Key:         key,
 			Explanation: totalStr,
 			Question:    req.Question,
+			LLMModel:    done.Model,
+			Language:    prompt.NormalizeLanguage(req.Language),
 		}
 
 		// 先添加到布隆过滤器
 		s.bf.Add([]byte(key))
-		fmt.Println("成功将记录缓存到布隆过滤器:", []byte(key))
+		zap.L().Debug("成功将记录缓存到布隆过滤器", zap.String("key", key))
 
 		code.ID, err = s.repo.SaveCode(code)
 		if err != nil {
@@ -201,8 +265,15 @@ func (s *codeDomainService) FetchAndSave(req *dto.CodeReq, key string) (*dto.Cod
 			return
 		}
 
-		dtoCode = code.CodeToDto()
-		err = s.SaveToRedis(key, dtoCode)
+		// 请求结束后 ctx 会被取消，写入向量存储不受其影响；失败只记录日志
+		// 只有默认语言的解释作为站内参考资料
+		if code.Language == prompt.DefaultLanguage {
+			if err := s.knowledge.IndexCode(context.WithoutCancel(ctx), code.ID, totalStr); err != nil {
+				zap.L().Error("代码解释写入向量存储失败", zap.Uint("codeID", code.ID), zap.Error(err))
+			}
+		}
+
+		err = s.SaveToRedis(key, code.CodeToDto())
 		if err != nil {
 			err = fmt.Errorf("s.SaveToRedis() %v", err)
 			return
@@ -237,6 +308,6 @@ func (s *codeDomainService) SaveToRedis(key string, code *dto.Code) (err error)
 		return
 	}
 
-	fmt.Printf("成功将记录缓存到redis: %s —— %#v\n", key, code)
+	zap.L().Debug("成功将记录缓存到redis", zap.String("key", key), zap.Uint("codeID", code.ID))
 	return
 }
@@ -0,0 +1,317 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/persistence"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	backfillBatchSize     = 100  // 补充写入时每批读取的记录数
+	defaultRAGTopK        = 4    // 未配置时最多使用的参考资料数量
+	defaultRAGMinScore    = 0.5  // 未配置时参考资料的最低相似度
+	defaultMaxSourceChars = 1500 // 未配置时每条参考资料的最大字符数
+	defaultTagWeight      = 0.3  // 未配置时相关文章排序中标签重合度的权重
+	relatedCandidates     = 3    // 相关文章的候选数量为 k 的倍数，候选按加权得分重新排序
+)
+
+type knowledgeDomainService struct {
+	vectors  service.VectorDomainService
+	articles persistence.ArticleRepositoryInterface
+	codes    persistence.CodeRepository
+	cfg      config.Config
+}
+
+// NewKnowledgeDomainService 构造函数
+func NewKnowledgeDomainService(vectors service.VectorDomainService, articles persistence.ArticleRepositoryInterface, codes persistence.CodeRepository, cfg config.Config) service.KnowledgeDomainService {
+	return &knowledgeDomainService{
+		vectors:  vectors,
+		articles: articles,
+		codes:    codes,
+		cfg:      cfg,
+	}
+}
+
+// IndexArticle 将文章的摘要和总结写入向量存储，文章ID相同时覆盖
+// tags 为空时保留已保存的标签，如保存文章ID时不知道生成摘要时匹配的标签
+func (k *knowledgeDomainService) IndexArticle(ctx context.Context, articleID uint, abstract, summary string, tags []string) error {
+	content := articleContent(abstract, summary)
+	if articleID == 0 || content == "" {
+		return nil
+	}
+	id := strconv.FormatUint(uint64(articleID), 10)
+	if len(tags) == 0 {
+		if doc, err := k.vectors.GetVector(dto.VectorTypeArticle, id); err == nil {
+			tags = doc.Tags
+		}
+	}
+	err := k.vectors.UpsertVector(ctx, &dto.VectorDoc{
+		Type:    dto.VectorTypeArticle,
+		ID:      id,
+		Content: k.truncate(content),
+		Tags:    tags,
+	})
+	if err != nil {
+		return fmt.Errorf("(k *knowledgeDomainService) IndexArticle -> %w", err)
+	}
+	return nil
+}
+
+// DelArticle 从向量存储中删除文章
+func (k *knowledgeDomainService) DelArticle(ctx context.Context, articleID uint) error {
+	if _, err := k.vectors.DelVector(ctx, dto.VectorTypeArticle, strconv.FormatUint(uint64(articleID), 10)); err != nil {
+		return fmt.Errorf("(k *knowledgeDomainService) DelArticle -> %v", err)
+	}
+	return nil
+}
+
+// IndexCode 将代码解释写入向量存储
+func (k *knowledgeDomainService) IndexCode(ctx context.Context, codeID uint, explanation string) error {
+	explanation = strings.TrimSpace(explanation)
+	if codeID == 0 || explanation == "" {
+		return nil
+	}
+	err := k.vectors.UpsertVector(ctx, &dto.VectorDoc{
+		Type:    dto.VectorTypeCode,
+		ID:      strconv.FormatUint(uint64(codeID), 10),
+		Content: k.truncate(explanation),
+	})
+	if err != nil {
+		return fmt.Errorf("(k *knowledgeDomainService) IndexCode -> %w", err)
+	}
+	return nil
+}
+
+// Backfill 将尚未写入向量存储的文章和代码解释补充写入，用于上线前已保存的内容
+// 单条写入失败只记录日志，ctx 取消时停止
+func (k *knowledgeDomainService) Backfill(ctx context.Context) error {
+	start := time.Now()
+	articles, err := k.backfillArticles(ctx)
+	if err != nil {
+		return fmt.Errorf("(k *knowledgeDomainService) Backfill -> %v", err)
+	}
+	codes, err := k.backfillCodes(ctx)
+	if err != nil {
+		return fmt.Errorf("(k *knowledgeDomainService) Backfill -> %v", err)
+	}
+	zap.L().Info("站内内容写入向量存储完成",
+		zap.Int("articles", articles),
+		zap.Int("codes", codes),
+		zap.Duration("elapsed", time.Since(start)))
+	return nil
+}
+
+func (k *knowledgeDomainService) backfillArticles(ctx context.Context) (int, error) {
+	var afterID uint
+	count := 0
+	for ctx.Err() == nil {
+		batch, err := k.articles.ListArticles(afterID, backfillBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		// 文章修改过时同一文章ID有多条记录，使用最新的一条
+		latest := make(map[string]int, len(batch))
+		ids := make([]string, 0, len(batch))
+		for i, a := range batch {
+			id := strconv.FormatUint(uint64(a.ArticleID), 10)
+			if _, ok := latest[id]; !ok {
+				ids = append(ids, id)
+			}
+			latest[id] = i
+		}
+		missing, err := k.vectors.MissingVectors(dto.VectorTypeArticle, ids)
+		if err != nil {
+			return count, err
+		}
+		for _, id := range missing {
+			a := batch[latest[id]]
+			if err = k.IndexArticle(ctx, a.ArticleID, a.Abstract, a.Summary, nil); err != nil {
+				zap.L().Error("文章写入向量存储失败", zap.Uint("articleID", a.ArticleID), zap.Error(err))
+				continue
+			}
+			count++
+		}
+	}
+	return count, ctx.Err()
+}
+
+func (k *knowledgeDomainService) backfillCodes(ctx context.Context) (int, error) {
+	var afterID uint
+	count := 0
+	for ctx.Err() == nil {
+		batch, err := k.codes.ListCodes(afterID, backfillBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		ids := make([]string, len(batch))
+		byID := make(map[string]int, len(batch))
+		for i, c := range batch {
+			ids[i] = strconv.FormatUint(uint64(c.ID), 10)
+			byID[ids[i]] = i
+		}
+		missing, err := k.vectors.MissingVectors(dto.VectorTypeCode, ids)
+		if err != nil {
+			return count, err
+		}
+		for _, id := range missing {
+			c := batch[byID[id]]
+			if err = k.IndexCode(ctx, c.ID, c.Explanation); err != nil {
+				zap.L().Error("代码解释写入向量存储失败", zap.Uint("codeID", c.ID), zap.Error(err))
+				continue
+			}
+			count++
+		}
+	}
+	return count, ctx.Err()
+}
+
+// Retrieve 检索与问题最相关的文章和代码解释，按相似度从高到低排序
+// 使用精确的相似度阈值和稳定的排序，相同的数据和问题总是得到相同的参考资料
+func (k *knowledgeDomainService) Retrieve(ctx context.Context, content string, topK int) ([]dto.VectorHit, error) {
+	if topK <= 0 {
+		topK = k.cfg.Rag.TopK
+	}
+	if topK <= 0 {
+		topK = defaultRAGTopK
+	}
+	minScore := k.cfg.Rag.MinScore
+	if minScore <= 0 {
+		minScore = defaultRAGMinScore
+	}
+
+	hits, err := k.vectors.QueryVector(ctx, &dto.VectorQuery{
+		Text:     content,
+		TopK:     topK,
+		Types:    []string{dto.VectorTypeArticle, dto.VectorTypeCode},
+		MinScore: minScore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("(k *knowledgeDomainService) Retrieve -> %w", err)
+	}
+	return hits, nil
+}
+
+// RelatedArticles 查找与文章相关的 k 篇文章，文章未写入向量存储时返回 service.ErrVectorNotFound
+// 候选为向量最相近的文章和带有相同标签的文章中向量最相近的，按向量相似度和标签的 Jaccard 系数加权排序
+func (k *knowledgeDomainService) RelatedArticles(ctx context.Context, articleID uint, n int) ([]dto.RelatedArticle, error) {
+	id := strconv.FormatUint(uint64(articleID), 10)
+	source, err := k.vectors.GetVector(dto.VectorTypeArticle, id)
+	if err != nil {
+		return nil, fmt.Errorf("(k *knowledgeDomainService) RelatedArticles -> %w", err)
+	}
+
+	queries := []*dto.VectorQuery{{Vector: source.Vector, TopK: n*relatedCandidates + 1, Types: []string{dto.VectorTypeArticle}}}
+	if len(source.Tags) > 0 {
+		queries = append(queries, &dto.VectorQuery{Vector: source.Vector, TopK: n*relatedCandidates + 1, Types: []string{dto.VectorTypeArticle}, Tags: source.Tags})
+	}
+	candidates := make(map[string]dto.VectorHit)
+	for _, query := range queries {
+		hits, err := k.vectors.QueryVector(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("(k *knowledgeDomainService) RelatedArticles -> %w", err)
+		}
+		for _, hit := range hits {
+			if hit.ID != id {
+				candidates[hit.ID] = hit
+			}
+		}
+	}
+
+	weight := k.cfg.Article.RelatedTagWeight
+	if weight <= 0 || weight > 1 {
+		weight = defaultTagWeight
+	}
+	related := make([]dto.RelatedArticle, 0, len(candidates))
+	for _, hit := range candidates {
+		relatedID, err := strconv.ParseUint(hit.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		common, jaccard := tagOverlap(source.Tags, hit.Tags)
+		related = append(related, dto.RelatedArticle{
+			ArticleID:  uint(relatedID),
+			Score:      (1-weight)*hit.Score + weight*jaccard,
+			Similarity: hit.Score,
+			CommonTags: common,
+		})
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].ArticleID < related[j].ArticleID
+	})
+	if len(related) > n {
+		related = related[:n]
+	}
+	return related, nil
+}
+
+// tagOverlap 返回两组标签共同的标签和 Jaccard 系数，标签不区分大小写
+func tagOverlap(a, b []string) ([]string, float32) {
+	set := make(map[string]bool, len(a))
+	for _, tag := range a {
+		set[strings.ToLower(tag)] = true
+	}
+	var common []string
+	union := len(set)
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		lower := strings.ToLower(tag)
+		if seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		if set[lower] {
+			common = append(common, tag)
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return nil, 0
+	}
+	return common, float32(len(common)) / float32(union)
+}
+
+// truncate 截取内容开头的部分，参考资料放入提示词时只使用这么多
+func (k *knowledgeDomainService) truncate(content string) string {
+	maxChars := k.cfg.Rag.MaxSourceChars
+	if maxChars <= 0 {
+		maxChars = defaultMaxSourceChars
+	}
+	if runes := []rune(content); len(runes) > maxChars {
+		return string(runes[:maxChars])
+	}
+	return content
+}
+
+// articleContent 文章写入向量存储的内容
+func articleContent(abstract, summary string) string {
+	var parts []string
+	if abstract = strings.TrimSpace(abstract); abstract != "" {
+		parts = append(parts, "摘要: "+abstract)
+	}
+	if summary = strings.TrimSpace(summary); summary != "" {
+		parts = append(parts, "总结: "+summary)
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,199 @@
+package impl
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/vectorstore"
+)
+
+// memEmbeddings 保存在内存中的向量存储
+type memEmbeddings struct {
+	docs map[[2]string]entity.Embedding
+}
+
+func (m *memEmbeddings) SaveEmbedding(e *entity.Embedding) error {
+	m.docs[[2]string{e.DocType, e.DocID}] = *e
+	return nil
+}
+
+func (m *memEmbeddings) DelEmbedding(docType, docID string) (bool, error) {
+	_, ok := m.docs[[2]string{docType, docID}]
+	delete(m.docs, [2]string{docType, docID})
+	return ok, nil
+}
+
+func (m *memEmbeddings) GetEmbeddings(docs [][2]string) ([]entity.Embedding, error) {
+	var res []entity.Embedding
+	for _, doc := range docs {
+		if e, ok := m.docs[doc]; ok {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (m *memEmbeddings) ListEmbeddings(uint, int) ([]entity.Embedding, error) {
+	return nil, nil
+}
+
+const ragQuestion = "goroutine 和 channel 怎么配合使用"
+
+// newTestKnowledge 使用假的向量模型和暴力检索的索引创建站内知识服务，并写入两篇文章和一条代码解释
+// 与 ragQuestion 的相似度: 文章 1 约 0.80，代码解释 3 约 0.72，文章 2 约 0.30
+func newTestKnowledge(t *testing.T, cfg config.Config) service.KnowledgeDomainService {
+	t.Helper()
+	cfg.Vector.Index = vectorstore.FlatIndex
+	index, err := vectorstore.NewIndex(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectors := NewVectorDomainService(&memEmbeddings{docs: make(map[[2]string]entity.Embedding)}, index, llm.NewFakeProvider(), cfg)
+	k := NewKnowledgeDomainService(vectors, nil, nil, cfg)
+
+	ctx := context.Background()
+	for _, err = range []error{
+		k.IndexArticle(ctx, 1, "Go 语言的 goroutine 和 channel 并发编程", "介绍 goroutine 的调度和 channel 的通信", nil),
+		k.IndexArticle(ctx, 2, "Python 装饰器的用法", "装饰器用于在不修改函数的情况下增加功能", nil),
+		k.IndexCode(ctx, 3, "这段代码启动多个 goroutine，通过 channel 汇总结果"),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return k
+}
+
+func TestRetrieve(t *testing.T) {
+	tests := []struct {
+		name     string
+		topK     int     // 请求的数量
+		cfgTopK  int     // 配置的数量
+		minScore float32 // 配置的最低相似度，0 使用默认值 0.5
+		want     []string
+	}{
+		{name: "默认阈值过滤无关文章", want: []string{"article:1", "code:3"}},
+		{name: "请求的数量优先", topK: 1, cfgTopK: 3, want: []string{"article:1"}},
+		{name: "使用配置的数量", cfgTopK: 1, want: []string{"article:1"}},
+		{name: "阈值之下的不返回", minScore: 0.75, want: []string{"article:1"}},
+		{name: "全部低于阈值", minScore: 0.9, want: nil},
+		{name: "阈值足够低时返回全部", minScore: 0.1, want: []string{"article:1", "code:3", "article:2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg config.Config
+			cfg.Rag.TopK = tt.cfgTopK
+			cfg.Rag.MinScore = tt.minScore
+			hits, err := newTestKnowledge(t, cfg).Retrieve(context.Background(), ragQuestion, tt.topK)
+			if err != nil {
+				t.Fatalf("Retrieve() err: %v", err)
+			}
+
+			var got []string
+			for i, hit := range hits {
+				got = append(got, hit.Type+":"+hit.ID)
+				if i > 0 && hit.Score > hits[i-1].Score {
+					t.Fatalf("结果未按相似度从高到低排序: %+v", hits)
+				}
+				if hit.Content == "" {
+					t.Fatalf("%s 缺少内容", got[i])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Retrieve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkCited(t *testing.T) {
+	tests := []struct {
+		name        string
+		answer      string
+		wantCited   []bool
+		wantArticle []uint
+	}{
+		{name: "单个引用", answer: "使用 channel 通信 [1]。", wantCited: []bool{true, false, false}, wantArticle: []uint{1}},
+		{name: "多个编号", answer: "见 [1, 3] 和 [2]", wantCited: []bool{true, true, true}, wantArticle: []uint{1, 2}},
+		{name: "中文分隔符", answer: "见 [2、3]", wantCited: []bool{false, true, true}, wantArticle: []uint{2}},
+		{name: "代码解释不计入文章", answer: "示例见 [2]", wantCited: []bool{false, true, false}},
+		{name: "超出范围的编号", answer: "见 [0] 和 [4]", wantCited: []bool{false, false, false}},
+		{name: "代码中的下标不是引用", answer: "```go\nx := arr[1]\n```\n行内 `a[3]` 不算", wantCited: []bool{false, false, false}},
+		{name: "重复引用", answer: "[1] 以及 [1]", wantCited: []bool{true, false, false}, wantArticle: []uint{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			citations := []dto.Citation{
+				{Index: 1, Type: dto.VectorTypeArticle, ID: "1"},
+				{Index: 2, Type: dto.VectorTypeCode, ID: "3"},
+				{Index: 3, Type: dto.VectorTypeArticle, ID: "2"},
+			}
+			articleIDs := markCited(tt.answer, citations)
+
+			cited := make([]bool, len(citations))
+			for i, c := range citations {
+				cited[i] = c.Cited
+			}
+			if !reflect.DeepEqual(cited, tt.wantCited) {
+				t.Errorf("Cited = %v, want %v", cited, tt.wantCited)
+			}
+			if !reflect.DeepEqual(articleIDs, tt.wantArticle) {
+				t.Errorf("markCited() = %v, want %v", articleIDs, tt.wantArticle)
+			}
+		})
+	}
+}
+
+func TestAskAnswerWithSources(t *testing.T) {
+	var cfg config.Config
+	cfg.Prompt.Dir = "../../../../prompts"
+	registry, err := prompt.NewRegistry(cfg, constant.QuestionRAGCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer := "通过 channel 在 goroutine 之间传递数据 [1]，完整示例见 [2]。"
+	q := &questionDomainService{
+		cfg:       cfg,
+		provider:  llm.NewFakeProvider(answer),
+		registry:  registry,
+		knowledge: newTestKnowledge(t, cfg),
+	}
+
+	var streamed strings.Builder
+	res, err := q.AskAnswerWithSources(context.Background(), "key", &dto.QuestionPrompt{Content: ragQuestion}, 0, func(chunk string) error {
+		streamed.WriteString(chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("AskAnswerWithSources() err: %v", err)
+	}
+	if res.Answer != answer || streamed.String() != answer {
+		t.Fatalf("Answer = %q, streamed = %q, want %q", res.Answer, streamed.String(), answer)
+	}
+
+	want := []dto.Citation{
+		{Index: 1, Type: dto.VectorTypeArticle, ID: "1", Cited: true},
+		{Index: 2, Type: dto.VectorTypeCode, ID: "3", Cited: true},
+	}
+	if len(res.Citations) != len(want) {
+		t.Fatalf("Citations = %+v, want %+v", res.Citations, want)
+	}
+	for i, c := range res.Citations {
+		c.Score = 0
+		if c != want[i] {
+			t.Fatalf("Citations[%d] = %+v, want %+v", i, c, want[i])
+		}
+	}
+	if !reflect.DeepEqual(res.CitedArticleIDs, []uint{1}) {
+		t.Fatalf("CitedArticleIDs = %v, want [1]", res.CitedArticleIDs)
+	}
+}
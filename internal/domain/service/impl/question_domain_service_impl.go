@@ -0,0 +1,371 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"regexp"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/cache"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/utils"
+	"strconv"
+	"strings"
+)
+
+var (
+	citationRe = regexp.MustCompile(`\[(\d+(?:\s*[,，、]\s*\d+)*)\]`) // 答案中的引用编号，如 [1]、[1, 2]
+	codeRe     = regexp.MustCompile("(?s)```.*?(```|$)|`[^`\n]*`")  // 代码块和行内代码，其中的 [1] 不是引用
+)
+
+const (
+	defaultSimilarThreshold = 0.85 // 未配置时相似问题的最低余弦相似度
+	defaultSimilarTopK      = 5    // 未配置时最多返回的相似问题数量
+)
+
+type questionDomainService struct {
+	repo      persistence.QuestionRepositoryInterface
+	cfg       config.Config
+	cm        cache.CacheManagerInterface
+	jct       constant.JudgingCacheType
+	provider  llm.LLMProvider
+	registry  prompt.Registry
+	vectors   service.VectorDomainService
+	knowledge service.KnowledgeDomainService
+	tags      service.TagDomainService
+}
+
+func NewQuestionDomainService(repo persistence.QuestionRepositoryInterface, cfg config.Config, cm cache.CacheManagerInterface, jct constant.JudgingCacheType, provider llm.LLMProvider, registry prompt.Registry, vectors service.VectorDomainService, knowledge service.KnowledgeDomainService, tags service.TagDomainService) service.QuestionDomainServiceInterface {
+	return &questionDomainService{
+		repo:      repo,
+		cfg:       cfg,
+		cm:        cm,
+		jct:       jct,
+		provider:  provider,
+		registry:  registry,
+		vectors:   vectors,
+		knowledge: knowledge,
+		tags:      tags,
+	}
+}
+
+// VerifyHash 验证hash值，优先从缓存获取，然后是数据库，没有记录时返回 persistence.ErrQuestionNotFound
+func (q *questionDomainService) VerifyHash(key string) (*dto.Question, error) {
+	data, err := q.cm.Get(questionCacheKey(key))
+	if err == nil && data != nil {
+		var question dto.Question
+		if err = json.Unmarshal(data, &question); err == nil {
+			return &question, nil
+		}
+		zap.L().Error("问题信息反序列化失败", zap.Error(err))
+	}
+
+	questionInfo, err := q.repo.VerifyHash(key)
+	if err != nil {
+		return nil, err
+	}
+	if err = q.repo.IncrVisitCount(key); err != nil {
+		zap.L().Error("更新问题访问次数失败", zap.Error(err))
+	}
+
+	question := questionInfo.ConvertQuestionEntityToDto()
+	q.setCache(question)
+	return question, nil
+}
+
+// AskTitles 调用大模型生成问题的标题和标签并持久化
+func (q *questionDomainService) AskTitles(ctx context.Context, key string, qp *dto.QuestionPrompt) (*dto.Question, error) {
+	answer, err := utils.Generate(ctx, q.provider, q.registry, constant.QuestionAICode, qp)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) AskTitles -> %w", err)
+	}
+
+	titles, _ := answer["titles"].([]string)
+	tags, _ := answer["tags"].([]string)
+	model, _ := answer["model"].(string)
+	// 将模型输出的标签映射到标签体系中的标准标签
+	mapping := q.tags.NormalizeTags(ctx, tags)
+
+	questionE := &entity.Question{
+		Key:           key,
+		QuestionID:    qp.QuestionID,
+		Content:       qp.Content,
+		Titles:        titles,
+		Tags:          mapping.Tags,
+		SuggestedTags: mapping.Suggestions,
+		TitleModel:    model,
+		Language:      prompt.NormalizeLanguage(qp.Language),
+	}
+	columns := []string{"titles", "tags", "suggested_tags", "title_model"}
+	if qp.QuestionID != 0 {
+		columns = append(columns, "question_id")
+	}
+	if err = q.repo.SaveQuestionInfo(questionE, columns...); err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) AskTitles -> %v", err)
+	}
+	if qp.QuestionID != 0 {
+		q.indexQuestion(ctx, questionE)
+	}
+
+	// 答案可能已经生成过，重新读取完整记录后更新缓存
+	return q.refresh(key, questionE), nil
+}
+
+// AskAnswer 流式调用大模型生成问题的答案并持久化，答案为 markdown 文本
+// 生成被取消或中途出错时不保存不完整的答案
+func (q *questionDomainService) AskAnswer(ctx context.Context, key string, qp *dto.QuestionPrompt, onChunk func(chunk string) error) (*dto.QuestionAnswer, error) {
+	var answer strings.Builder
+	res, err := utils.Stream(ctx, q.provider, q.registry, constant.QuestionAnswerCode, qp, q.cfg, func(chunk string) error {
+		answer.WriteString(chunk)
+		return onChunk(chunk)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) AskAnswer -> %w", err)
+	}
+
+	questionE := &entity.Question{
+		Key:         key,
+		QuestionID:  qp.QuestionID,
+		Content:     qp.Content,
+		Answer:      answer.String(),
+		AnswerModel: res.Model,
+		Language:    prompt.NormalizeLanguage(qp.Language),
+	}
+	if err = q.repo.SaveQuestionInfo(questionE, "answer", "answer_model"); err != nil {
+		// 答案已经返回给调用方，保存失败只影响下次是否命中
+		zap.L().Error("保存问题答案失败", zap.String("key", key), zap.Error(err))
+	} else {
+		q.refresh(key, questionE)
+	}
+
+	return &dto.QuestionAnswer{
+		Key:              key,
+		Answer:           answer.String(),
+		Model:            res.Model,
+		PromptTokens:     res.PromptTokens,
+		CompletionTokens: res.CompletionTokens,
+		FinishReason:     res.FinishReason,
+		Language:         questionE.Language,
+	}, nil
+}
+
+// AskAnswerWithSources 检索站内文章和代码解释作为参考资料，流式生成以 [编号] 标注引用的答案
+// 答案依赖检索时的站内内容，不保存；检索不到参考资料时与 AskAnswer 相同
+func (q *questionDomainService) AskAnswerWithSources(ctx context.Context, key string, qp *dto.QuestionPrompt, topK int, onChunk func(chunk string) error) (*dto.QuestionAnswer, error) {
+	hits, err := q.knowledge.Retrieve(ctx, qp.Content, topK)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) AskAnswerWithSources -> %w", err)
+	}
+	if len(hits) == 0 {
+		return q.AskAnswer(ctx, key, qp, onChunk)
+	}
+
+	citations := make([]dto.Citation, len(hits))
+	sources := make([]string, len(hits))
+	for i, hit := range hits {
+		citations[i] = dto.Citation{Index: i + 1, Type: hit.Type, ID: hit.ID, Score: hit.Score}
+		sources[i] = fmt.Sprintf("[%d] %s\n%s", i+1, sourceLabel(hit.Type, qp.Language), hit.Content)
+	}
+	ragPrompt := *qp
+	ragPrompt.Sources = strings.Join(sources, "\n\n")
+
+	var answer strings.Builder
+	res, err := utils.Stream(ctx, q.provider, q.registry, constant.QuestionRAGCode, &ragPrompt, q.cfg, func(chunk string) error {
+		answer.WriteString(chunk)
+		return onChunk(chunk)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) AskAnswerWithSources -> %w", err)
+	}
+
+	return &dto.QuestionAnswer{
+		Key:              key,
+		Answer:           answer.String(),
+		Model:            res.Model,
+		PromptTokens:     res.PromptTokens,
+		CompletionTokens: res.CompletionTokens,
+		FinishReason:     res.FinishReason,
+		Citations:        citations,
+		CitedArticleIDs:  markCited(answer.String(), citations),
+		Language:         prompt.NormalizeLanguage(qp.Language),
+	}, nil
+}
+
+// SaveQuestionID 保存问题的ID，并将问题写入向量存储供相似问题检索
+func (q *questionDomainService) SaveQuestionID(ctx context.Context, key string, questionID uint) error {
+	if err := q.repo.SaveQuestionID(key, questionID); err != nil {
+		return err
+	}
+	if err := q.cm.Delete(questionCacheKey(key)); err != nil {
+		zap.L().Error("删除问题缓存失败", zap.String("key", key), zap.Error(err))
+	}
+
+	questionE, err := q.repo.VerifyHash(key)
+	if err != nil {
+		zap.L().Error("读取问题信息失败，未写入向量存储", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+	q.indexQuestion(ctx, questionE)
+	return nil
+}
+
+// FindSimilarQuestions 检索内容相近的已有问题，相似度低于配置的阈值的不返回
+// excludeID 不为 0 时排除该问题本身，用于编辑已发布的问题
+func (q *questionDomainService) FindSimilarQuestions(ctx context.Context, content string, topK int, excludeID uint) ([]dto.SimilarQuestion, error) {
+	if topK <= 0 {
+		topK = q.cfg.Question.SimilarTopK
+	}
+	if topK <= 0 {
+		topK = defaultSimilarTopK
+	}
+	threshold := q.cfg.Question.SimilarThreshold
+	if threshold <= 0 {
+		threshold = defaultSimilarThreshold
+	}
+
+	hits, err := q.vectors.QueryVector(ctx, &dto.VectorQuery{
+		Text:     content,
+		TopK:     topK + 1, // 多取一条，排除问题本身后仍有 topK 条
+		Types:    []string{dto.VectorTypeQuestion},
+		MinScore: threshold,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) FindSimilarQuestions -> %w", err)
+	}
+
+	ids := make([]uint, 0, len(hits))
+	scores := make(map[uint]float32, len(hits))
+	for _, hit := range hits {
+		id, err := strconv.ParseUint(hit.ID, 10, 64)
+		if err != nil || uint(id) == excludeID {
+			continue
+		}
+		ids = append(ids, uint(id))
+		scores[uint(id)] = hit.Score
+	}
+	if len(ids) > topK {
+		ids = ids[:topK]
+	}
+
+	questions, err := q.repo.GetByQuestionIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionDomainService) FindSimilarQuestions -> %v", err)
+	}
+	// 同一ID有多条记录时使用最近更新的
+	latest := make(map[uint]*entity.Question, len(questions))
+	for i := range questions {
+		if _, ok := latest[questions[i].QuestionID]; !ok {
+			latest[questions[i].QuestionID] = &questions[i]
+		}
+	}
+
+	result := make([]dto.SimilarQuestion, 0, len(ids))
+	for _, id := range ids {
+		similar := dto.SimilarQuestion{QuestionID: id, Score: scores[id]}
+		if questionE, ok := latest[id]; ok {
+			similar.Key = questionE.Key
+			similar.Titles = questionE.Titles
+			similar.Tags = questionE.Tags
+		}
+		result = append(result, similar)
+	}
+	return result, nil
+}
+
+// indexQuestion 将已有ID的问题写入向量存储，失败只记录日志，不影响问题信息的保存
+// 同一问题的其他语言的记录内容相同，只写入默认语言的记录
+func (q *questionDomainService) indexQuestion(ctx context.Context, questionE *entity.Question) {
+	if questionE.QuestionID == 0 || strings.TrimSpace(questionE.Content) == "" || prompt.NormalizeLanguage(questionE.Language) != prompt.DefaultLanguage {
+		return
+	}
+	err := q.vectors.UpsertVector(ctx, &dto.VectorDoc{
+		Type:    dto.VectorTypeQuestion,
+		ID:      strconv.FormatUint(uint64(questionE.QuestionID), 10),
+		Content: questionE.Content,
+		Tags:    questionE.Tags,
+	})
+	if err != nil {
+		zap.L().Error("问题写入向量存储失败", zap.Uint("questionID", questionE.QuestionID), zap.Error(err))
+	}
+}
+
+// refresh 从数据库读取完整记录并更新缓存，读取失败时使用刚保存的内容
+func (q *questionDomainService) refresh(key string, saved *entity.Question) *dto.Question {
+	questionInfo, err := q.repo.VerifyHash(key)
+	if err != nil {
+		if !errors.Is(err, persistence.ErrQuestionNotFound) {
+			zap.L().Error("读取问题信息失败", zap.String("key", key), zap.Error(err))
+		}
+		return saved.ConvertQuestionEntityToDto()
+	}
+	question := questionInfo.ConvertQuestionEntityToDto()
+	q.setCache(question)
+	return question
+}
+
+// setCache 设置缓存，同时设置本地缓存和Redis缓存
+func (q *questionDomainService) setCache(question *dto.Question) {
+	jsonData, err := json.Marshal(question)
+	if err != nil {
+		zap.L().Error("问题信息序列化失败，设置缓存失败", zap.Error(err))
+		return
+	}
+	q.cm.Set(questionCacheKey(question.Key), jsonData, q.jct.GetQuestionFlag())
+}
+
+func questionCacheKey(key string) string {
+	return "question:" + key
+}
+
+// sourceLabel 参考资料的类型在提示词中的名称，使用与模板相同的语言
+func sourceLabel(docType string, language string) string {
+	code := docType == dto.VectorTypeCode
+	switch prompt.NormalizeLanguage(language) {
+	case "en":
+		if code {
+			return "Code explanation"
+		}
+		return "Article"
+	case "ja":
+		if code {
+			return "コード解説"
+		}
+		return "記事"
+	default:
+		if code {
+			return "代码解释"
+		}
+		return "文章"
+	}
+}
+
+// markCited 根据答案中的 [编号] 标记被引用的参考资料，返回被引用的文章ID，按编号顺序
+func markCited(answer string, citations []dto.Citation) []uint {
+	answer = codeRe.ReplaceAllString(answer, "")
+	for _, m := range citationRe.FindAllStringSubmatch(answer, -1) {
+		for _, n := range strings.FieldsFunc(m[1], func(r rune) bool { return r < '0' || r > '9' }) {
+			if i, err := strconv.Atoi(n); err == nil && i >= 1 && i <= len(citations) {
+				citations[i-1].Cited = true
+			}
+		}
+	}
+
+	var articleIDs []uint
+	for _, c := range citations {
+		if !c.Cited || c.Type != dto.VectorTypeArticle {
+			continue
+		}
+		if id, err := strconv.ParseUint(c.ID, 10, 64); err == nil {
+			articleIDs = append(articleIDs, uint(id))
+		}
+	}
+	return articleIDs
+}
@@ -0,0 +1,435 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"regexp"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/persistence"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxTagNameLen        = 64          // 标签名称的最大字符数，与数据库列的长度一致
+	tagReloadInterval    = time.Minute // 超过该时间重新从数据库加载标签体系，其他实例的修改在该时间内生效
+	defaultTagMatchScore = 0.88        // 未配置时按向量映射到标准标签的最低余弦相似度
+)
+
+var (
+	tagSpaceRe    = regexp.MustCompile(`\s+`)      // 连续的空白
+	tagKeyStripRe = regexp.MustCompile(`[\s\-_.]`) // 比较标签时忽略的字符，如 Node.js 与 nodejs、Vue 3 与 Vue3
+)
+
+// taxonomy 内存中的标准标签，key 为规范化后的名称和别名
+type taxonomy struct {
+	byID  map[uint]*entity.Tag
+	byKey map[string]*entity.Tag
+}
+
+type tagDomainService struct {
+	repo     persistence.TagRepository
+	vectors  service.VectorDomainService
+	cfg      config.Config
+	mu       sync.RWMutex
+	tax      *taxonomy
+	loadedAt time.Time
+}
+
+// NewTagDomainService 创建标签领域服务
+func NewTagDomainService(repo persistence.TagRepository, vectors service.VectorDomainService, cfg config.Config) service.TagDomainService {
+	return &tagDomainService{
+		repo:    repo,
+		vectors: vectors,
+		cfg:     cfg,
+	}
+}
+
+// LoadTaxonomy 从数据库加载标准标签
+func (t *tagDomainService) LoadTaxonomy() error {
+	tags, err := t.repo.ListTags(dto.TagStatusActive)
+	if err != nil {
+		return fmt.Errorf("(t *tagDomainService) LoadTaxonomy -> %v", err)
+	}
+	tax := newTaxonomy(tags)
+
+	t.mu.Lock()
+	t.tax = tax
+	t.loadedAt = time.Now()
+	t.mu.Unlock()
+	return nil
+}
+
+// current 返回当前的标签体系，超过 tagReloadInterval 时重新加载，加载失败时继续使用已加载的
+func (t *tagDomainService) current() *taxonomy {
+	t.mu.RLock()
+	tax, loadedAt := t.tax, t.loadedAt
+	t.mu.RUnlock()
+	if tax != nil && time.Since(loadedAt) < tagReloadInterval {
+		return tax
+	}
+
+	if err := t.LoadTaxonomy(); err != nil {
+		zap.L().Error("加载标签体系失败", zap.Error(err))
+		if tax == nil {
+			return newTaxonomy(nil)
+		}
+		return tax
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tax
+}
+
+func newTaxonomy(tags []entity.Tag) *taxonomy {
+	tax := &taxonomy{
+		byID:  make(map[uint]*entity.Tag, len(tags)),
+		byKey: make(map[string]*entity.Tag, len(tags)),
+	}
+	for i := range tags {
+		tag := &tags[i]
+		tax.byID[tag.ID] = tag
+		for _, key := range tagKeys(tag.Name, tag.Aliases) {
+			tax.byKey[key] = tag
+		}
+	}
+	return tax
+}
+
+// SyncVectors 为尚未写入向量存储的标准标签生成向量
+func (t *tagDomainService) SyncVectors(ctx context.Context) error {
+	tax := t.current()
+	ids := make([]string, 0, len(tax.byID))
+	for id := range tax.byID {
+		ids = append(ids, tagVectorID(id))
+	}
+	missing, err := t.vectors.MissingVectors(dto.VectorTypeTag, ids)
+	if err != nil {
+		return fmt.Errorf("(t *tagDomainService) SyncVectors -> %v", err)
+	}
+	for _, id := range missing {
+		tagID, _ := strconv.ParseUint(id, 10, 64)
+		if tag := tax.byID[uint(tagID)]; tag != nil {
+			t.indexTag(ctx, tag)
+		}
+	}
+	zap.L().Info("标签向量同步完成", zap.Int("count", len(missing)))
+	return nil
+}
+
+// CreateTag 创建标准标签，同名的建议标签直接启用
+func (t *tagDomainService) CreateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error) {
+	tagE, err := t.prepare(tag, 0)
+	if err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) CreateTag -> %w", err)
+	}
+
+	existing, err := t.repo.GetTagByName(tagE.Name)
+	switch {
+	case err == nil && existing.Status == dto.TagStatusActive:
+		return nil, fmt.Errorf("(t *tagDomainService) CreateTag -> %w", service.ErrTagConflict)
+	case err == nil:
+		tagE.Model = existing.Model
+		tagE.SuggestCount = existing.SuggestCount
+		err = t.repo.UpdateTag(tagE)
+	case errors.Is(err, persistence.ErrTagNotFound):
+		err = t.repo.CreateTag(tagE)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) CreateTag -> %v", err)
+	}
+
+	t.afterSave(ctx, tagE)
+	return tagE.ConvertTagEntityToDto(), nil
+}
+
+// UpdateTag 更新标签，Status 为空时保持原有状态，建议标签改为 active 即为审核通过
+func (t *tagDomainService) UpdateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error) {
+	existing, err := t.repo.GetTag(tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) UpdateTag -> %w", err)
+	}
+	if tag.Status == "" {
+		tag.Status = existing.Status
+	}
+
+	tagE, err := t.prepare(tag, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) UpdateTag -> %w", err)
+	}
+	tagE.Model = existing.Model
+	tagE.SuggestCount = existing.SuggestCount
+
+	// 改名后与建议标签同名时，建议标签已由该标签覆盖，先删除以免名称冲突
+	if same, err := t.repo.GetTagByName(tagE.Name); err == nil && same.ID != tagE.ID {
+		if same.Status == dto.TagStatusActive {
+			return nil, fmt.Errorf("(t *tagDomainService) UpdateTag -> %w", service.ErrTagConflict)
+		}
+		if err = t.repo.DelTag(same.ID); err != nil {
+			return nil, fmt.Errorf("(t *tagDomainService) UpdateTag -> %v", err)
+		}
+	}
+	if err = t.repo.UpdateTag(tagE); err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) UpdateTag -> %v", err)
+	}
+
+	if tagE.Status == dto.TagStatusActive {
+		t.afterSave(ctx, tagE)
+	} else {
+		t.afterDelete(ctx, tagE.ID)
+	}
+	return tagE.ConvertTagEntityToDto(), nil
+}
+
+// DelTag 删除标签，子标签改为挂在其父标签下
+func (t *tagDomainService) DelTag(ctx context.Context, id uint) error {
+	if err := t.repo.DelTag(id); err != nil {
+		return fmt.Errorf("(t *tagDomainService) DelTag -> %w", err)
+	}
+	t.afterDelete(ctx, id)
+	return nil
+}
+
+// GetTag 查询标签
+func (t *tagDomainService) GetTag(id uint) (*dto.Tag, error) {
+	tag, err := t.repo.GetTag(id)
+	if err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) GetTag -> %w", err)
+	}
+	return tag.ConvertTagEntityToDto(), nil
+}
+
+// ListTags 查询指定状态的标签，status 为空时查询全部
+func (t *tagDomainService) ListTags(status string) ([]dto.Tag, error) {
+	if status != "" && status != dto.TagStatusActive && status != dto.TagStatusSuggested {
+		return nil, fmt.Errorf("(t *tagDomainService) ListTags -> %w", service.ErrInvalidTag)
+	}
+	tags, err := t.repo.ListTags(status)
+	if err != nil {
+		return nil, fmt.Errorf("(t *tagDomainService) ListTags -> %v", err)
+	}
+	res := make([]dto.Tag, len(tags))
+	for i := range tags {
+		res[i] = *tags[i].ConvertTagEntityToDto()
+	}
+	return res, nil
+}
+
+// NormalizeTags 将模型生成的标签映射到标准标签：先按名称和别名匹配，再按向量相似度匹配，
+// 仍无法映射的记录为建议标签；标签体系为空时还没有可以映射的标准标签，原样保留模型生成的标签
+func (t *tagDomainService) NormalizeTags(ctx context.Context, tags []string) *dto.TagMapping {
+	tax := t.current()
+	mapping := &dto.TagMapping{}
+	seen := make(map[string]bool, len(tags))
+	for _, raw := range tags {
+		name := cleanTag(raw)
+		key := tagKey(name)
+		if key == "" || utf8.RuneCountInString(name) > maxTagNameLen {
+			continue
+		}
+
+		tag := tax.byKey[key]
+		if tag == nil && len(tax.byID) > 0 {
+			tag = t.matchByVector(ctx, tax, name)
+		}
+		if tag != nil {
+			if !seen[tagKey(tag.Name)] {
+				seen[tagKey(tag.Name)] = true
+				mapping.Tags = append(mapping.Tags, tag.Name)
+			}
+			continue
+		}
+
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		mapping.Suggestions = append(mapping.Suggestions, name)
+		if err := t.repo.AddSuggestion(name); err != nil {
+			zap.L().Error("记录建议标签失败", zap.String("tag", name), zap.Error(err))
+		}
+	}
+
+	if len(tax.byID) == 0 {
+		mapping.Tags = mapping.Suggestions
+	}
+	return mapping
+}
+
+// CanonicalTag 按名称和别名查找对应的标准标签，没有时返回整理后的原标签，不记录建议标签
+func (t *tagDomainService) CanonicalTag(tag string) string {
+	name := cleanTag(tag)
+	if canonical := t.current().byKey[tagKey(name)]; canonical != nil {
+		return canonical.Name
+	}
+	return name
+}
+
+// matchByVector 按向量相似度查找最接近的标准标签，失败时只记录日志
+func (t *tagDomainService) matchByVector(ctx context.Context, tax *taxonomy, name string) *entity.Tag {
+	threshold := t.cfg.Tag.MatchThreshold
+	if threshold <= 0 {
+		threshold = defaultTagMatchScore
+	}
+	hits, err := t.vectors.QueryVector(ctx, &dto.VectorQuery{
+		Text:     name,
+		TopK:     1,
+		Types:    []string{dto.VectorTypeTag},
+		MinScore: threshold,
+	})
+	if err != nil {
+		zap.L().Error("按向量映射标签失败", zap.String("tag", name), zap.Error(err))
+		return nil
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	id, _ := strconv.ParseUint(hits[0].ID, 10, 64)
+	return tax.byID[uint(id)]
+}
+
+// prepare 校验并规范化标签，id 为被更新标签的ID，创建时为 0
+// 标准标签的名称和别名不能与其他标准标签重复，父标签必须是标准标签且不能形成环
+func (t *tagDomainService) prepare(tag *dto.Tag, id uint) (*entity.Tag, error) {
+	name := cleanTag(tag.Name)
+	if tagKey(name) == "" || utf8.RuneCountInString(name) > maxTagNameLen {
+		return nil, service.ErrInvalidTag
+	}
+	status := tag.Status
+	if status == "" {
+		status = dto.TagStatusActive
+	}
+	if status != dto.TagStatusActive && status != dto.TagStatusSuggested {
+		return nil, service.ErrInvalidTag
+	}
+
+	// 别名去重，去掉与名称相同的别名
+	aliases := make([]string, 0, len(tag.Aliases))
+	seen := map[string]bool{tagKey(name): true}
+	for _, alias := range tag.Aliases {
+		alias = cleanTag(alias)
+		key := tagKey(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		if utf8.RuneCountInString(alias) > maxTagNameLen {
+			return nil, service.ErrInvalidTag
+		}
+		seen[key] = true
+		aliases = append(aliases, alias)
+	}
+
+	tagE := &entity.Tag{
+		Name:        name,
+		Aliases:     aliases,
+		ParentID:    tag.ParentID,
+		Description: strings.TrimSpace(tag.Description),
+		Status:      status,
+	}
+	tagE.ID = id
+	if status != dto.TagStatusActive {
+		return tagE, nil
+	}
+
+	// 校验使用最新的标签体系
+	if err := t.LoadTaxonomy(); err != nil {
+		return nil, err
+	}
+	tax := t.current()
+	for _, key := range tagKeys(name, aliases) {
+		if owner := tax.byKey[key]; owner != nil && owner.ID != id {
+			return nil, service.ErrTagConflict
+		}
+	}
+	for parentID := tag.ParentID; parentID != 0; {
+		parent := tax.byID[parentID]
+		if parent == nil || parent.ID == id {
+			return nil, service.ErrTagCycle
+		}
+		parentID = parent.ParentID
+	}
+	return tagE, nil
+}
+
+// afterSave 标准标签保存后写入向量存储，删除已被其名称或别名覆盖的建议标签，并重新加载标签体系
+func (t *tagDomainService) afterSave(ctx context.Context, tagE *entity.Tag) {
+	t.indexTag(ctx, tagE)
+
+	suggestions, err := t.repo.ListTags(dto.TagStatusSuggested)
+	if err != nil {
+		zap.L().Error("查询建议标签失败", zap.Error(err))
+	}
+	keys := make(map[string]bool)
+	for _, key := range tagKeys(tagE.Name, tagE.Aliases) {
+		keys[key] = true
+	}
+	for _, suggestion := range suggestions {
+		if suggestion.ID != tagE.ID && keys[tagKey(suggestion.Name)] {
+			if err = t.repo.DelTag(suggestion.ID); err != nil {
+				zap.L().Error("删除建议标签失败", zap.String("tag", suggestion.Name), zap.Error(err))
+			}
+		}
+	}
+
+	if err = t.LoadTaxonomy(); err != nil {
+		zap.L().Error("加载标签体系失败", zap.Error(err))
+	}
+}
+
+// afterDelete 标签删除或不再是标准标签后从向量存储删除，并重新加载标签体系
+func (t *tagDomainService) afterDelete(ctx context.Context, id uint) {
+	if _, err := t.vectors.DelVector(ctx, dto.VectorTypeTag, tagVectorID(id)); err != nil {
+		zap.L().Error("从向量存储删除标签失败", zap.Uint("tagID", id), zap.Error(err))
+	}
+	if err := t.LoadTaxonomy(); err != nil {
+		zap.L().Error("加载标签体系失败", zap.Error(err))
+	}
+}
+
+// indexTag 将标准标签的名称、别名和描述写入向量存储，失败只记录日志
+func (t *tagDomainService) indexTag(ctx context.Context, tagE *entity.Tag) {
+	content := strings.Join(append([]string{tagE.Name}, tagE.Aliases...), "、")
+	if tagE.Description != "" {
+		content += "：" + tagE.Description
+	}
+	err := t.vectors.UpsertVector(ctx, &dto.VectorDoc{
+		Type:    dto.VectorTypeTag,
+		ID:      tagVectorID(tagE.ID),
+		Content: content,
+	})
+	if err != nil {
+		zap.L().Error("标签写入向量存储失败", zap.Uint("tagID", tagE.ID), zap.Error(err))
+	}
+}
+
+func tagVectorID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// cleanTag 去掉首尾空白和开头的 #，连续的空白合并为一个空格
+func cleanTag(tag string) string {
+	tag = strings.TrimLeft(strings.TrimSpace(tag), "#＃")
+	return tagSpaceRe.ReplaceAllString(strings.TrimSpace(tag), " ")
+}
+
+// tagKey 比较标签时使用的 key，忽略大小写、空白和 -_. 符号
+func tagKey(tag string) string {
+	return strings.ToLower(tagKeyStripRe.ReplaceAllString(tag, ""))
+}
+
+// tagKeys 名称和别名的 key
+func tagKeys(name string, aliases []string) []string {
+	keys := []string{tagKey(name)}
+	for _, alias := range aliases {
+		keys = append(keys, tagKey(alias))
+	}
+	return keys
+}
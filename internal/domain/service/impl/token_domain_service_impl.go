@@ -16,6 +16,12 @@ var (
 	ErrValidationFailed  = errors.New("token validation failed")
 )
 
+// clientClaim token 中记录调用方标识的 claim
+const clientClaim = "client"
+
+// userClaim token 中记录用户ID的 claim
+const userClaim = "user"
+
 // TokenDomainService 定义 token 领域服务
 type tokenDomainService struct {
 	// 可以添加其他依赖，例如配置或存储
@@ -26,10 +32,10 @@ func NewTokenDomainService() service.TokenDomainService {
 	return &tokenDomainService{}
 }
 
-// ValidateToken 验证 JWT token 的合法性，不提取 claims
-func (s *tokenDomainService) ValidateToken(tokenString string, secretKey string) error {
-	// 解析 token，不绑定具体 claims
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+// ValidateToken 验证 JWT token 的合法性，返回 token 中记录的用户和调用方
+func (s *tokenDomainService) ValidateToken(tokenString string, secretKey string) (*dto.TokenClaims, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		// 验证签名方法
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("%w: %v", ErrUnsupportedMethod, token.Header["alg"])
@@ -42,23 +48,28 @@ func (s *tokenDomainService) ValidateToken(tokenString string, secretKey string)
 		var ve *jwt.ValidationError
 		if errors.As(err, &ve) {
 			if ve.Errors&jwt.ValidationErrorMalformed != 0 {
-				return fmt.Errorf("%w: malformed token", ErrInvalidToken)
+				return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
 			}
 			if ve.Errors&(jwt.ValidationErrorExpired|jwt.ValidationErrorNotValidYet) != 0 {
-				return fmt.Errorf("%w: token is expired or not yet valid", ErrValidationFailed)
+				return nil, fmt.Errorf("%w: token is expired or not yet valid", ErrValidationFailed)
 			}
-			return fmt.Errorf("%w: %v", ErrValidationFailed, err)
+			return nil, fmt.Errorf("%w: %v", ErrValidationFailed, err)
 		}
-		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
 	// 检查 token 是否有效
 	if !token.Valid {
-		return ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
-	// token 验证通过，无需提取 claims
-	return nil
+	// 数字 claim 解析为 float64；早期签发的 token 两者都没有，由调用方拒绝
+	tokenClaims := &dto.TokenClaims{}
+	tokenClaims.Client, _ = claims[clientClaim].(string)
+	if user, ok := claims[userClaim].(float64); ok && user > 0 {
+		tokenClaims.UserID = uint(user)
+	}
+	return tokenClaims, nil
 }
 
 // GenerateToken 生成token
@@ -67,11 +78,17 @@ func (s *tokenDomainService) GenerateToken(req *dto.TokenReq, secretKey string,
 	if req.GenerateTokenKey != generateTokenKey {
 		return "", fmt.Errorf("驳回，生成token密钥错误")
 	}
+	// 配额和用量按 token 中的身份计算，不签发没有身份的 token
+	if req.Client == "" && req.UserID == 0 {
+		return "", fmt.Errorf("驳回，token 需要包含用户ID或调用方标识")
+	}
 
 	// 假设验证通过，生成 JWT
 	claims := jwt.MapClaims{
-		"exp": time.Now().Add(time.Hour * 72).Unix(), // 72 小时有效期
-		"iat": time.Now().Unix(),
+		"exp":       time.Now().Add(time.Hour * 72).Unix(), // 72 小时有效期
+		"iat":       time.Now().Unix(),
+		clientClaim: req.Client,
+		userClaim:   req.UserID,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err = token.SignedString([]byte(secretKey))
@@ -0,0 +1,109 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/persistence"
+	"slices"
+	"strings"
+	"time"
+)
+
+type usageDomainService struct {
+	repo   persistence.UsageRepository
+	prices map[string]config.ModelPrice
+}
+
+// NewUsageDomainService 创建用量领域服务
+func NewUsageDomainService(repo persistence.UsageRepository, cfg config.Config) service.UsageDomainService {
+	prices := make(map[string]config.ModelPrice, len(cfg.Usage.Prices))
+	for model, price := range cfg.Usage.Prices {
+		prices[strings.ToLower(model)] = price
+	}
+	return &usageDomainService{
+		repo:   repo,
+		prices: prices,
+	}
+}
+
+// RecordUsage 将一次调用的用量累加到当天的统计中
+func (s *usageDomainService) RecordUsage(_ context.Context, usage llm.Usage) error {
+	err := s.repo.AddUsage(&entity.Usage{
+		UserID:           usage.UserID,
+		AICode:           string(usage.Code),
+		LLMModel:         usage.Model,
+		Day:              time.Now().Format(time.DateOnly),
+		Calls:            1,
+		PromptTokens:     int64(usage.PromptTokens),
+		CompletionTokens: int64(usage.CompletionTokens),
+	})
+	if err != nil {
+		return fmt.Errorf("(s *usageDomainService) RecordUsage -> %v", err)
+	}
+	return nil
+}
+
+// GetUsage 按条件汇总用量并计算费用
+// 费用与模型相关，因此总是额外按模型查询，计算费用后再合并为调用方要求的分组
+func (s *usageDomainService) GetUsage(_ context.Context, query *dto.UsageQuery) (stats []dto.UsageStat, total dto.UsageStat, err error) {
+	groupBy := query.GroupBy
+	byModel := *query
+	if !slices.Contains(groupBy, "model") {
+		byModel.GroupBy = append(slices.Clone(groupBy), "model")
+	}
+
+	rows, err := s.repo.ListUsage(&byModel)
+	if err != nil {
+		return nil, total, fmt.Errorf("(s *usageDomainService) GetUsage -> %v", err)
+	}
+
+	index := make(map[dto.UsageStat]int)
+	for _, row := range rows {
+		row.Cost = s.cost(row)
+		total = addUsageStat(total, row)
+
+		key := dto.UsageStat{}
+		for _, group := range groupBy {
+			switch group {
+			case "user":
+				key.UserID = row.UserID
+			case "ai_code":
+				key.AICode = row.AICode
+			case "model":
+				key.Model = row.Model
+			case "day":
+				key.Day = row.Day
+			}
+		}
+		if i, ok := index[key]; ok {
+			stats[i] = addUsageStat(stats[i], row)
+			continue
+		}
+		index[key] = len(stats)
+		stats = append(stats, addUsageStat(key, row))
+	}
+	return stats, total, nil
+}
+
+// cost 按模型单价计算费用
+func (s *usageDomainService) cost(stat dto.UsageStat) float64 {
+	price, ok := s.prices[strings.ToLower(stat.Model)]
+	if !ok {
+		return 0
+	}
+	return float64(stat.PromptTokens)/1000*price.Prompt + float64(stat.CompletionTokens)/1000*price.Completion
+}
+
+// addUsageStat 将 row 的用量累加到 stat 上，保留 stat 的分组字段
+func addUsageStat(stat, row dto.UsageStat) dto.UsageStat {
+	stat.Calls += row.Calls
+	stat.PromptTokens += row.PromptTokens
+	stat.CompletionTokens += row.CompletionTokens
+	stat.Cost += row.Cost
+	return stat
+}
@@ -0,0 +1,269 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/utils"
+	"siwuai/internal/infrastructure/vectorstore"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	loadBatchSize        = 500  // 启动时每批从数据库加载的向量数
+	defaultTopK          = 10   // 未指定时返回的结果数量
+	maxTopK              = 100  // 最多返回的结果数量
+	defaultMaxEmbedChars = 2000 // 未配置时生成向量截取的最大字符数
+)
+
+type vectorDomainService struct {
+	repo     persistence.EmbeddingRepository
+	index    vectorstore.Index
+	provider llm.LLMProvider
+	cfg      config.Config
+}
+
+// NewVectorDomainService 构造函数，index 在进程内共享，应只创建一个实例
+func NewVectorDomainService(repo persistence.EmbeddingRepository, index vectorstore.Index, provider llm.LLMProvider, cfg config.Config) service.VectorDomainService {
+	return &vectorDomainService{
+		repo:     repo,
+		index:    index,
+		provider: provider,
+		cfg:      cfg,
+	}
+}
+
+// LoadIndex 从数据库分批加载全部向量到内存索引，维度不一致的向量(如更换了向量模型)跳过
+func (s *vectorDomainService) LoadIndex() error {
+	start := time.Now()
+	var afterID uint
+	skipped := 0
+	for {
+		embeddings, err := s.repo.ListEmbeddings(afterID, loadBatchSize)
+		if err != nil {
+			return fmt.Errorf("s.repo.ListEmbeddings() %v", err)
+		}
+		for _, e := range embeddings {
+			if err = s.index.Upsert(toItem(&e)); err != nil {
+				skipped++
+				zap.L().Warn("加载向量失败，跳过", zap.String("type", e.DocType), zap.String("id", e.DocID), zap.Error(err))
+			}
+		}
+		if len(embeddings) < loadBatchSize {
+			break
+		}
+		afterID = embeddings[len(embeddings)-1].ID
+	}
+
+	zap.L().Info("向量索引加载完成",
+		zap.Int("count", s.index.Len()),
+		zap.Int("skipped", skipped),
+		zap.Duration("elapsed", time.Since(start)))
+	return nil
+}
+
+// UpsertVector 保存向量并更新内存索引，未提供向量时根据内容生成
+func (s *vectorDomainService) UpsertVector(ctx context.Context, doc *dto.VectorDoc) error {
+	embedding := &entity.Embedding{
+		DocType: doc.Type,
+		DocID:   doc.ID,
+		Content: doc.Content,
+		Tags:    doc.Tags,
+		Vector:  doc.Vector,
+	}
+	if len(embedding.Vector) == 0 {
+		vector, err := s.embed(ctx, doc.Content)
+		if err != nil {
+			return fmt.Errorf("s.embed() %w", err)
+		}
+		embedding.Vector = vector
+		embedding.LLMModel = s.cfg.Embedding.Model
+	}
+	embedding.Dim = len(embedding.Vector)
+	if err := s.checkDim(embedding.Dim); err != nil {
+		return err
+	}
+
+	if err := s.repo.SaveEmbedding(embedding); err != nil {
+		return fmt.Errorf("s.repo.SaveEmbedding() %v", err)
+	}
+	if err := s.index.Upsert(toItem(embedding)); err != nil {
+		return fmt.Errorf("s.index.Upsert() %w: %v", service.ErrInvalidVector, err)
+	}
+	return nil
+}
+
+// DelVector 删除向量，记录不存在时返回 false
+func (s *vectorDomainService) DelVector(ctx context.Context, docType, docID string) (bool, error) {
+	deleted, err := s.repo.DelEmbedding(docType, docID)
+	if err != nil {
+		return false, fmt.Errorf("s.repo.DelEmbedding() %v", err)
+	}
+	s.index.Delete(vectorKey(docType, docID))
+	return deleted, nil
+}
+
+// QueryVector 按文本或向量检索最相似的内容
+func (s *vectorDomainService) QueryVector(ctx context.Context, query *dto.VectorQuery) ([]dto.VectorHit, error) {
+	vector := query.Vector
+	if len(vector) == 0 {
+		var err error
+		if vector, err = s.embed(ctx, query.Text); err != nil {
+			return nil, fmt.Errorf("s.embed() %w", err)
+		}
+	}
+	if s.index.Len() == 0 {
+		return nil, nil
+	}
+	if err := s.checkDim(len(vector)); err != nil {
+		return nil, err
+	}
+
+	topK := query.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+	topK = min(topK, maxTopK)
+
+	hits := s.index.Search(vector, topK, func(item *vectorstore.Item) bool {
+		if len(query.Types) > 0 && !slices.Contains(query.Types, item.Type) {
+			return false
+		}
+		if len(query.Tags) > 0 && !slices.ContainsFunc(item.Tags, func(tag string) bool {
+			return slices.Contains(query.Tags, tag)
+		}) {
+			return false
+		}
+		return true
+	})
+
+	// 索引中只有向量，内容和标签从数据库读取
+	docs := make([][2]string, 0, len(hits))
+	for _, hit := range hits {
+		if hit.Score < query.MinScore {
+			break
+		}
+		docType, docID, _ := strings.Cut(hit.Key, ":")
+		docs = append(docs, [2]string{docType, docID})
+	}
+	embeddings, err := s.repo.GetEmbeddings(docs)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetEmbeddings() %v", err)
+	}
+	byKey := make(map[string]*entity.Embedding, len(embeddings))
+	for i := range embeddings {
+		byKey[vectorKey(embeddings[i].DocType, embeddings[i].DocID)] = &embeddings[i]
+	}
+
+	result := make([]dto.VectorHit, 0, len(docs))
+	for _, hit := range hits[:len(docs)] {
+		e, ok := byKey[hit.Key]
+		if !ok {
+			continue
+		}
+		result = append(result, dto.VectorHit{
+			Type:    e.DocType,
+			ID:      e.DocID,
+			Content: e.Content,
+			Tags:    e.Tags,
+			Score:   hit.Score,
+		})
+	}
+	return result, nil
+}
+
+// GetVector 读取一条已保存的向量，不存在时返回 service.ErrVectorNotFound
+func (s *vectorDomainService) GetVector(docType, docID string) (*dto.VectorDoc, error) {
+	embeddings, err := s.repo.GetEmbeddings([][2]string{{docType, docID}})
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetEmbeddings() %v", err)
+	}
+	if len(embeddings) == 0 {
+		return nil, service.ErrVectorNotFound
+	}
+	e := embeddings[0]
+	return &dto.VectorDoc{
+		Type:    e.DocType,
+		ID:      e.DocID,
+		Content: e.Content,
+		Tags:    e.Tags,
+		Vector:  e.Vector,
+	}, nil
+}
+
+// MissingVectors 返回 docIDs 中尚未保存向量的ID
+func (s *vectorDomainService) MissingVectors(docType string, docIDs []string) ([]string, error) {
+	docs := make([][2]string, len(docIDs))
+	for i, id := range docIDs {
+		docs[i] = [2]string{docType, id}
+	}
+	embeddings, err := s.repo.GetEmbeddings(docs)
+	if err != nil {
+		return nil, fmt.Errorf("s.repo.GetEmbeddings() %v", err)
+	}
+	saved := make(map[string]bool, len(embeddings))
+	for _, e := range embeddings {
+		saved[e.DocID] = true
+	}
+
+	missing := make([]string, 0, len(docIDs)-len(saved))
+	for _, id := range docIDs {
+		if !saved[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// embed 为内容生成向量，内容过长时截取开头部分
+func (s *vectorDomainService) embed(ctx context.Context, content string) ([]float32, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("%w: 内容和向量不能同时为空", service.ErrInvalidVector)
+	}
+	maxChars := s.cfg.Vector.MaxEmbedChars
+	if maxChars <= 0 {
+		maxChars = defaultMaxEmbedChars
+	}
+	if runes := []rune(content); len(runes) > maxChars {
+		content = string(runes[:maxChars])
+	}
+
+	vectors, err := utils.GenerateVector(ctx, s.provider, constant.DocumentVectorCode, &dto.VectorPrompt{Content: []string{content}})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("向量模型返回了 %d 个向量", len(vectors))
+	}
+	return vectors[0], nil
+}
+
+// checkDim 检查向量维度与索引是否一致，索引为空时任意维度都可以
+func (s *vectorDomainService) checkDim(dim int) error {
+	if indexDim := s.index.Dim(); indexDim != 0 && dim != indexDim {
+		return fmt.Errorf("%w: 向量维度 %d 与索引的维度 %d 不一致", service.ErrInvalidVector, dim, indexDim)
+	}
+	return nil
+}
+
+func toItem(e *entity.Embedding) vectorstore.Item {
+	return vectorstore.Item{
+		Key:    vectorKey(e.DocType, e.DocID),
+		Type:   e.DocType,
+		Tags:   e.Tags,
+		Vector: e.Vector,
+	}
+}
+
+func vectorKey(docType, docID string) string {
+	return docType + ":" + docID
+}
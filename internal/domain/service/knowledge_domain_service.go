@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+)
+
+// KnowledgeDomainService 站内知识：文章的摘要和总结、代码解释写入向量存储，回答问题时检索作为参考资料
+type KnowledgeDomainService interface {
+	IndexArticle(ctx context.Context, articleID uint, abstract, summary string, tags []string) error
+	DelArticle(ctx context.Context, articleID uint) error
+	IndexCode(ctx context.Context, codeID uint, explanation string) error
+	Backfill(ctx context.Context) error
+	Retrieve(ctx context.Context, content string, topK int) ([]dto.VectorHit, error)
+	RelatedArticles(ctx context.Context, articleID uint, k int) ([]dto.RelatedArticle, error)
+}
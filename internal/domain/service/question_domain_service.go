@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+)
+
+type QuestionDomainServiceInterface interface {
+	VerifyHash(key string) (*dto.Question, error)
+	AskTitles(ctx context.Context, key string, qp *dto.QuestionPrompt) (*dto.Question, error)
+	AskAnswer(ctx context.Context, key string, qp *dto.QuestionPrompt, onChunk func(chunk string) error) (*dto.QuestionAnswer, error)
+	AskAnswerWithSources(ctx context.Context, key string, qp *dto.QuestionPrompt, topK int, onChunk func(chunk string) error) (*dto.QuestionAnswer, error)
+	SaveQuestionID(ctx context.Context, key string, questionID uint) error
+	FindSimilarQuestions(ctx context.Context, content string, topK int, excludeID uint) ([]dto.SimilarQuestion, error)
+}
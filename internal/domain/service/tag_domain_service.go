@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"siwuai/internal/domain/model/dto"
+)
+
+// ErrInvalidTag 标签名称为空或过长
+var ErrInvalidTag = errors.New("标签无效")
+
+// ErrTagConflict 名称或别名已被其他标准标签使用
+var ErrTagConflict = errors.New("标签名称或别名已存在")
+
+// ErrTagCycle 父标签不存在，或设置父标签后形成环
+var ErrTagCycle = errors.New("父标签无效")
+
+// TagDomainService 标签体系：标准标签的名称、别名、层级和向量，以及模型生成的标签到标准标签的映射
+type TagDomainService interface {
+	LoadTaxonomy() error
+	SyncVectors(ctx context.Context) error
+	CreateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error)
+	UpdateTag(ctx context.Context, tag *dto.Tag) (*dto.Tag, error)
+	DelTag(ctx context.Context, id uint) error
+	GetTag(id uint) (*dto.Tag, error)
+	ListTags(status string) ([]dto.Tag, error)
+	NormalizeTags(ctx context.Context, tags []string) *dto.TagMapping
+	CanonicalTag(tag string) string
+}
@@ -4,5 +4,5 @@ import "siwuai/internal/domain/model/dto"
 
 type TokenDomainService interface {
 	GenerateToken(req *dto.TokenReq, secretKey string, generateTokenKey string) (string, error)
-	ValidateToken(tokenString string, secretKey string) error
+	ValidateToken(tokenString string, secretKey string) (*dto.TokenClaims, error)
 }
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/infrastructure/llm"
+)
+
+// UsageDomainService 大模型用量的记录与统计，同时作为 llm.UsageRecorder 注入到大模型提供方
+type UsageDomainService interface {
+	RecordUsage(ctx context.Context, usage llm.Usage) error
+	GetUsage(ctx context.Context, query *dto.UsageQuery) (stats []dto.UsageStat, total dto.UsageStat, err error)
+}
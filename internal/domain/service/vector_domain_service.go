@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"siwuai/internal/domain/model/dto"
+)
+
+// ErrInvalidVector 内容和向量都为空，或向量维度与索引不一致
+var ErrInvalidVector = errors.New("向量无效")
+
+// ErrVectorNotFound 向量存储中没有该内容
+var ErrVectorNotFound = errors.New("向量不存在")
+
+// VectorDomainService 向量存储：向量保存在 MySQL 中，检索使用进程内的内存索引
+type VectorDomainService interface {
+	LoadIndex() error
+	UpsertVector(ctx context.Context, doc *dto.VectorDoc) error
+	DelVector(ctx context.Context, docType, docID string) (bool, error)
+	QueryVector(ctx context.Context, query *dto.VectorQuery) ([]dto.VectorHit, error)
+	MissingVectors(docType string, docIDs []string) ([]string, error)
+	GetVector(docType, docID string) (*dto.VectorDoc, error)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/prompt"
 	"siwuai/internal/infrastructure/utils"
 	"sync"
 	"time"
@@ -17,10 +18,11 @@ import (
 
 // 不同类型数据的缓存过期时间
 const (
-	DefaultExpiration = 24 * time.Hour     // 默认过期时间
-	CodeExpiration    = 48 * time.Hour     // 代码解释缓存时间
-	ArticleExpiration = 72 * time.Hour     // 文章缓存时间
-	HotDataExpiration = 7 * 24 * time.Hour // 热点数据缓存时间
+	DefaultExpiration  = 24 * time.Hour     // 默认过期时间
+	CodeExpiration     = 48 * time.Hour     // 代码解释缓存时间
+	ArticleExpiration  = 72 * time.Hour     // 文章缓存时间
+	QuestionExpiration = 72 * time.Hour     // 问题缓存时间
+	HotDataExpiration  = 7 * 24 * time.Hour // 热点数据缓存时间
 )
 
 // CacheType 缓存类型
@@ -212,32 +214,81 @@ func (cm *CacheManager) warmUpCodes(cacheType constant.CacheType) {
 	zap.L().Info("代码缓存预热完成", zap.Int("count", len(codes)))
 }
 
+// ArticleKey 文章信息的缓存键，默认语言沿用 article:<id>，其他语言为 article:<id>:<language>
+func ArticleKey(articleID uint, language string) string {
+	language = prompt.NormalizeLanguage(language)
+	if language == prompt.DefaultLanguage {
+		return fmt.Sprintf("article:%d", articleID)
+	}
+	return fmt.Sprintf("article:%d:%s", articleID, language)
+}
+
 // warmUpArticles 预热文章缓存
 func (cm *CacheManager) warmUpArticles(cacheType constant.CacheType) {
-	// 查询访问量最高的前50篇文章
+	// 同一篇文章在每种语言下可能有多条记录，与读取时一样只取最新的一条，再按访问量取前50条
+	latest := cm.db.Model(&entity.Article{}).Select("MAX(id)").Where("article_id <> 0").Group("article_id, language")
 	var articles []entity.Article
-	if err := cm.db.Model(&entity.Article{}).Order("visit_count DESC").Limit(50).Find(&articles).Error; err != nil {
+	if err := cm.db.Where("id IN (?)", latest).Order("visit_count DESC").Limit(50).Find(&articles).Error; err != nil {
 		zap.L().Error("加载热门文章记录失败", zap.Error(err))
 		return
 	}
 
+	// 与读取文章信息时一样，返回记录生成的标签，人工修改的内容优先于模型生成的结果
+	articleIDs := make([]uint, 0, len(articles))
+	recordIDs := make([]uint, 0, len(articles))
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ArticleID)
+		recordIDs = append(recordIDs, article.ID)
+	}
+	var articleTags []entity.ArticleTag
+	if err := cm.db.Where("record_id IN ?", recordIDs).Order("id").Find(&articleTags).Error; err != nil {
+		zap.L().Error("加载文章的标签失败", zap.Error(err))
+		return
+	}
+	tagMap := make(map[uint][]string, len(articles))
+	for _, tag := range articleTags {
+		tagMap[tag.RecordID] = append(tagMap[tag.RecordID], tag.Tag)
+	}
+	var overrides []entity.ArticleOverride
+	if err := cm.db.Where("article_id IN ?", articleIDs).Find(&overrides).Error; err != nil {
+		zap.L().Error("加载文章的人工修改失败", zap.Error(err))
+		return
+	}
+	overrideMap := make(map[uint]*entity.ArticleOverride, len(overrides))
+	for i := range overrides {
+		overrideMap[overrides[i].ArticleID] = &overrides[i]
+	}
+
+	count := 0
 	for _, article := range articles {
+		articleDto := article.ConvertArticleEntityToDtoSecond()
+		articleDto.Tags = tagMap[article.ID]
+		// 人工修改只作用于默认语言
+		if prompt.NormalizeLanguage(article.Language) == prompt.DefaultLanguage {
+			overrideMap[article.ArticleID].Apply(articleDto)
+		}
+		// 摘要、总结为空时读取时也不缓存
+		if articleDto.Abstract == "" && articleDto.Summary == "" {
+			continue
+		}
+
 		// 序列化数据
-		data, err := json.Marshal(article)
+		data, err := json.Marshal(articleDto)
 		if err != nil {
 			zap.L().Error("序列化文章记录失败", zap.Error(err), zap.Uint("id", article.ID))
 			continue
 		}
 
 		// 设置到缓存
-		cm.Set(fmt.Sprintf("article:%d", article.ArticleID), data, cacheType)
+		cm.Set(ArticleKey(article.ArticleID, article.Language), data, cacheType)
+		count++
 
 		//err != nil {
 		//	zap.L().Error("预热文章缓存失败", zap.Error(err), zap.Uint("id", article.ID))
 		//}
 	}
 
-	zap.L().Info("文章缓存预热完成", zap.Int("count", len(articles)))
+	zap.L().Info("文章缓存预热完成", zap.Int("count", count))
 }
 
 // getExpirationByType 根据缓存类型获取过期时间
@@ -247,6 +298,8 @@ func (cm *CacheManager) getExpirationByType(cacheType constant.CacheType) time.D
 		return CodeExpiration
 	case cm.jct.GetArticleFlag():
 		return ArticleExpiration
+	case cm.jct.GetQuestionFlag():
+		return QuestionExpiration
 	default:
 		return DefaultExpiration
 	}
@@ -9,7 +9,8 @@ import (
 // Config 定义了应用的配置结构体
 type Config struct {
 	Server struct {
-		Port string `mapstructure:"port"`
+		Port        string `mapstructure:"port"`
+		MetricsPort string `mapstructure:"metricsPort"` // 指标（expvar）HTTP 端口，为空时不启动
 	} `mapstructure:"server"`
 	MySQL struct {
 		Host      string `mapstructure:"host"`
@@ -41,17 +42,106 @@ type Config struct {
 		GenerateTokenKey string `mapstructure:"generateTokenKey"` // token生成密钥
 	} `mapstructure:"token"`
 	Llm struct {
-		ApiKey             string  `mapstructure:"apiKey"`
-		Model              string  `mapstructure:"model"`
-		BaseURL            string  `mapstructure:"baseURL"`
-		TemperatureCode    float64 `mapstructure:"temperatureCode"`
-		TemperatureArticle float64 `mapstructure:"temperatureArticle"`
+		Provider           string              `mapstructure:"provider"` // 大模型提供方: openai(默认)、ollama、fake
+		ApiKey             string              `mapstructure:"apiKey"`
+		Model              string              `mapstructure:"model"`
+		BaseURL            string              `mapstructure:"baseURL"`
+		TemperatureCode    float64             `mapstructure:"temperatureCode"`
+		TemperatureArticle float64             `mapstructure:"temperatureArticle"`
+		FakeResponses      []string            `mapstructure:"fakeResponses"` // provider 为 fake 时按顺序循环返回的回答
+		Models             []LlmEndpoint       `mapstructure:"models"`        // 备用模型端点，上面的配置为名为 default 的主模型
+		Chains             map[string][]string `mapstructure:"chains"`        // 各 AICode 依次尝试的模型端点，未配置时只使用 default
 	} `mapstructure:"llm"`
 	Embedding struct {
 		ApiKey  string `mapstructure:"apiKey"`
 		Model   string `mapstructure:"model"`
 		BaseURL string `mapstructure:"baseURL"`
 	} `mapstructure:"embedding"`
+	Prompt struct {
+		Dir         string                    `mapstructure:"dir"`         // 提示词模板目录
+		Versions    map[string]string         `mapstructure:"versions"`    // 各 AICode 默认使用的模板版本，未配置时为 v1
+		Experiments map[string]map[string]int `mapstructure:"experiments"` // A/B 实验：各 AICode 下各版本的流量权重
+	} `mapstructure:"prompt"`
+	Resilience struct {
+		Timeout          int            `mapstructure:"timeout"`          // 单次调用大模型的超时时间（秒）
+		Timeouts         map[string]int `mapstructure:"timeouts"`         // 按 AICode 覆盖单次调用的超时时间（秒）
+		MaxRetries       int            `mapstructure:"maxRetries"`       // 临时性错误的最大重试次数
+		BaseBackoff      int            `mapstructure:"baseBackoff"`      // 首次重试的退避时间（毫秒），之后指数增长
+		MaxBackoff       int            `mapstructure:"maxBackoff"`       // 退避时间上限（毫秒）
+		FailureThreshold int            `mapstructure:"failureThreshold"` // 连续失败多少次后熔断
+		OpenTimeout      int            `mapstructure:"openTimeout"`      // 熔断持续时间（秒），之后放行一次探测请求
+	} `mapstructure:"resilience"`
+	Usage struct {
+		Prices map[string]ModelPrice `mapstructure:"prices"` // 各模型的单价，key 为模型名（不区分大小写），未配置的模型费用记为 0
+	} `mapstructure:"usage"`
+	Quota struct {
+		User    QuotaLimit            `mapstructure:"user"`    // 每个用户的默认配额
+		Client  QuotaLimit            `mapstructure:"client"`  // 每个调用方的默认配额
+		Users   map[string]QuotaLimit `mapstructure:"users"`   // 按用户ID覆盖默认配额
+		Clients map[string]QuotaLimit `mapstructure:"clients"` // 按调用方覆盖默认配额，key 不区分大小写
+	} `mapstructure:"quota"`
+	RateLimit struct {
+		Enabled bool            `mapstructure:"enabled"`
+		Default RateLimitRule   `mapstructure:"default"` // 未单独配置的方法使用的限流规则
+		Methods []RateLimitRule `mapstructure:"methods"` // 按方法配置的限流规则
+	} `mapstructure:"rateLimit"`
+	Vector struct {
+		Index          string `mapstructure:"index"`          // 内存索引类型: ivf(默认)、flat(暴力检索)
+		NList          int    `mapstructure:"nlist"`          // ivf 的聚类数，0 表示按向量数量的平方根自动确定
+		NProbe         int    `mapstructure:"nprobe"`         // ivf 检索时查找的聚类数
+		TrainThreshold int    `mapstructure:"trainThreshold"` // 向量数量达到该值后才训练 ivf 聚类，之前使用暴力检索
+		MaxEmbedChars  int    `mapstructure:"maxEmbedChars"`  // 生成向量时截取内容的最大字符数
+	} `mapstructure:"vector"`
+	Question struct {
+		SimilarThreshold float32 `mapstructure:"similarThreshold"` // 相似问题的最低余弦相似度
+		SimilarTopK      int     `mapstructure:"similarTopK"`      // 最多返回的相似问题数量
+	} `mapstructure:"question"`
+	Article struct {
+		RelatedTagWeight float32 `mapstructure:"relatedTagWeight"` // 相关文章排序时标签重合度的权重(0~1)，其余为向量相似度的权重
+		MaxPromptTokens  int     `mapstructure:"maxPromptTokens"`  // 文章估算的 token 数超过该值时先分段总结再汇总
+		ChunkTokens      int     `mapstructure:"chunkTokens"`      // 分段总结时每段的最大 token 数
+		ChunkConcurrency int     `mapstructure:"chunkConcurrency"` // 同时总结的段数
+		RegenerateRatio  float64 `mapstructure:"regenerateRatio"`  // 更新文章时内容的改动比例达到该值才重新生成摘要和总结
+		TranslateTokens  int     `mapstructure:"translateTokens"`  // 翻译文章时每段的最大 token 数
+	} `mapstructure:"article"`
+	Tag struct {
+		MatchThreshold float32 `mapstructure:"matchThreshold"` // 模型生成的标签按向量映射到标准标签时的最低余弦相似度
+	} `mapstructure:"tag"`
+	Rag struct {
+		TopK           int     `mapstructure:"topK"`           // 生成答案时最多使用的参考资料数量
+		MinScore       float32 `mapstructure:"minScore"`       // 参考资料的最低余弦相似度
+		MaxSourceChars int     `mapstructure:"maxSourceChars"` // 每条参考资料放入提示词的最大字符数
+		Backfill       bool    `mapstructure:"backfill"`       // 启动时将尚未写入向量存储的文章和代码解释补充写入
+	} `mapstructure:"rag"`
+}
+
+// RateLimitRule 令牌桶限流规则，每个方法的每个调用方各有一个令牌桶
+type RateLimitRule struct {
+	Method        string  `mapstructure:"method"`        // gRPC 方法全名，如 /vector.vectorService/GetVector
+	Rate          float64 `mapstructure:"rate"`          // 每秒补充的令牌数，不大于 0 时不限流
+	Burst         int64   `mapstructure:"burst"`         // 令牌桶容量
+	BytesPerToken int     `mapstructure:"bytesPerToken"` // 请求每多少字节额外消耗一个令牌，0 表示每个请求固定消耗一个
+}
+
+// QuotaLimit 调用大模型生成内容的次数上限，0 表示不限制
+type QuotaLimit struct {
+	Daily   int64 `mapstructure:"daily"`
+	Monthly int64 `mapstructure:"monthly"`
+}
+
+// ModelPrice 模型每 1000 个 token 的单价
+type ModelPrice struct {
+	Prompt     float64 `mapstructure:"prompt"`     // 提示词单价
+	Completion float64 `mapstructure:"completion"` // 回答单价
+}
+
+// LlmEndpoint 一个可用的大模型端点
+type LlmEndpoint struct {
+	Name     string `mapstructure:"name"`     // 端点名称，在 llm.chains 中引用
+	Provider string `mapstructure:"provider"` // openai、ollama、fake
+	ApiKey   string `mapstructure:"apiKey"`
+	Model    string `mapstructure:"model"`
+	BaseURL  string `mapstructure:"baseURL"`
 }
 
 // LoadConfig 加载并解析配置文件
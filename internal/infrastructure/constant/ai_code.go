@@ -3,11 +3,17 @@ package constant
 type AICode string
 
 const (
-	ArticleAICode      AICode = "article"
-	CodeAICode         AICode = "code"
-	QuestionAICode     AICode = "question"
-	QuestionAnswerCode AICode = "question_answer"
-	QuestionVectorCode AICode = "question_vector"
+	ArticleAICode        AICode = "article"
+	ArticleChunkCode     AICode = "article_chunk"     // 长文章分段总结
+	ArticleTranslateCode AICode = "article_translate" // 分段翻译文章
+	ArticleSEOCode       AICode = "article_seo"       // 生成文章的 SEO 信息
+	CodeAICode           AICode = "code"
+	QuestionAICode       AICode = "question"
+	QuestionAnswerCode   AICode = "question_answer"
+	QuestionRAGCode      AICode = "question_rag" // 结合站内文章和代码解释生成答案
+	QuestionVectorCode   AICode = "question_vector"
+	DocumentVectorCode   AICode = "document_vector" // 向量存储中的内容
+	RepairAICode         AICode = "repair"          // 修复不符合 schema 的模型输出
 )
 
 type JudgingSignInterface interface {
@@ -4,13 +4,15 @@ package constant
 type CacheType string
 
 const (
-	CodeCache    CacheType = "code"    // 代码缓存
-	ArticleCache CacheType = "article" // 文章缓存
+	CodeCache     CacheType = "code"     // 代码缓存
+	ArticleCache  CacheType = "article"  // 文章缓存
+	QuestionCache CacheType = "question" // 问题缓存
 )
 
 type JudgingCacheType interface {
 	GetArticleFlag() CacheType
 	GetCodeFlag() CacheType
+	GetQuestionFlag() CacheType
 }
 
 type judgingCache struct{}
@@ -24,3 +26,6 @@ func (j *judgingCache) GetArticleFlag() CacheType {
 func (j *judgingCache) GetCodeFlag() CacheType {
 	return CodeCache
 }
+func (j *judgingCache) GetQuestionFlag() CacheType {
+	return QuestionCache
+}
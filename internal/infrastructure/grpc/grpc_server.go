@@ -1,27 +1,38 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"github.com/bits-and-blooms/bloom/v3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"gorm.io/gorm"
 	"net"
+	"siwuai/internal/domain/service"
 	serviceimpl "siwuai/internal/domain/service/impl" // 导入服务实现
 	"siwuai/internal/infrastructure/cache"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
 	"siwuai/internal/infrastructure/redis_utils"
 	server "siwuai/internal/server/grpc"
 	pb "siwuai/proto/article"
 	pbcode "siwuai/proto/code"
 	pbquestion "siwuai/proto/question"
+	pbtag "siwuai/proto/tag"
 	pbtoken "siwuai/proto/token"
+	pbusage "siwuai/proto/usage"
 	pbvector "siwuai/proto/vector"
+	"time"
 )
 
-// RunGRPCServer 启动 gRPC 服务器，并启用 token 验证
-func RunGRPCServer(port string, db *gorm.DB, rdb *redis_utils.RedisClient, bf *bloom.BloomFilter, cfg config.Config, cacheManager *cache.CacheManager, jc constant.JudgingCacheType) error {
+// shutdownTimeout 优雅关闭时等待进行中请求结束的最长时间，超时后强制关闭并取消剩余请求的 ctx
+const shutdownTimeout = 5 * time.Second
+
+// RunGRPCServer 启动 gRPC 服务器，并启用 token 验证，ctx 取消时关闭服务器
+func RunGRPCServer(ctx context.Context, port string, db *gorm.DB, rdb *redis_utils.RedisClient, bf *bloom.BloomFilter, cfg config.Config, cacheManager *cache.CacheManager, jc constant.JudgingCacheType, provider llm.LLMProvider, registry prompt.Registry, vectors service.VectorDomainService, knowledge service.KnowledgeDomainService, tags service.TagDomainService, limiter quota.Limiter) error {
 	lis, err := net.Listen("tcp", "0.0.0.0:"+port)
 	if err != nil {
 		return err
@@ -31,29 +42,58 @@ func RunGRPCServer(port string, db *gorm.DB, rdb *redis_utils.RedisClient, bf *b
 	tokenSvc := serviceimpl.NewTokenDomainService()
 
 	// 创建 gRPC 服务器并注册拦截器
+	// 限流依赖 token 中的调用方标识，放在 token 验证之后
+	unaryInterceptors := []grpc.UnaryServerInterceptor{TokenValidationInterceptor(tokenSvc, cfg.Token.SecretKey)}
+	streamInterceptors := []grpc.StreamServerInterceptor{StreamTokenValidationInterceptor(tokenSvc, cfg.Token.SecretKey)}
+	if cfg.RateLimit.Enabled {
+		unaryInterceptors = append(unaryInterceptors, RateLimitInterceptor(rdb, cfg))
+		streamInterceptors = append(streamInterceptors, StreamRateLimitInterceptor(rdb, cfg))
+	}
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(TokenValidationInterceptor(tokenSvc, cfg.Token.SecretKey)),
-		grpc.StreamInterceptor(StreamTokenValidationInterceptor(tokenSvc, cfg.Token.SecretKey)),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
 
 	// 注册 CodeService
-	pbcode.RegisterCodeServiceServer(grpcServer, server.NewCodeGRPCHandler(db, rdb, bf, cfg))
+	pbcode.RegisterCodeServiceServer(grpcServer, server.NewCodeGRPCHandler(db, rdb, bf, cfg, provider, registry, knowledge, limiter))
 
 	// 注册 ArticleService
-	pb.RegisterArticleServiceServer(grpcServer, server.NewArticleGRPCHandler(db, cfg, cacheManager, jc))
+	pb.RegisterArticleServiceServer(grpcServer, server.NewArticleGRPCHandler(db, cfg, cacheManager, jc, provider, registry, knowledge, tags, limiter))
 
 	// 注册 TokenService
 	pbtoken.RegisterTokenServiceServer(grpcServer, server.NewTokenGRPCHandler(cfg))
 
 	// 注册 QuestionService
-	pbquestion.RegisterQuestionServiceServer(grpcServer, server.NewQuestionGRPCHandler(db, cfg, cacheManager, jc))
+	pbquestion.RegisterQuestionServiceServer(grpcServer, server.NewQuestionGRPCHandler(db, cfg, cacheManager, jc, provider, registry, vectors, knowledge, tags, limiter))
 
 	// 注册 VectorService
-	pbvector.RegisterVectorServiceServer(grpcServer, server.NewVectorGrpcHandler(cfg))
+	pbvector.RegisterVectorServiceServer(grpcServer, server.NewVectorGrpcHandler(cfg, provider, vectors))
+
+	// 注册 TagService
+	pbtag.RegisterTagServiceServer(grpcServer, server.NewTagGRPCHandler(tags))
+
+	// 注册 UsageService
+	pbusage.RegisterUsageServiceServer(grpcServer, server.NewUsageGRPCHandler(db, cfg))
 
 	msg := fmt.Sprintf("gRPC 服务器成功启动在端口 %s...", port)
 	fmt.Println(msg)
 	zap.L().Info(msg)
 
+	go func() {
+		<-ctx.Done()
+		zap.L().Info("开始关闭 gRPC 服务器...")
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			zap.L().Warn("gRPC 服务器优雅关闭超时，强制关闭")
+			grpcServer.Stop()
+		}
+	}()
+
 	return grpcServer.Serve(lis)
 }
@@ -7,7 +7,10 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"siwuai/internal/domain/model/dto"
 	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/quota"
 	"strings"
 )
 
@@ -44,13 +47,16 @@ func TokenValidationInterceptor(tokenSvc service.TokenDomainService, secretKey s
 		}
 
 		// 验证 token
-		err = tokenSvc.ValidateToken(tokenString, secretKey)
+		claims, err := tokenSvc.ValidateToken(tokenString, secretKey)
 		if err != nil {
 			return nil, status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
 		}
+		if claims.UserID == 0 && claims.Client == "" {
+			return nil, status.Error(codes.Unauthenticated, "token 中缺少用户ID和调用方标识")
+		}
 
-		// token 有效，继续处理
-		return handler(ctx, req)
+		// token 有效，记录其中的身份用于配额限制和用量统计，继续处理
+		return handler(withIdentity(ctx, claims), req)
 	}
 }
 
@@ -84,11 +90,29 @@ func StreamTokenValidationInterceptor(tokenSvc service.TokenDomainService, secre
 			return status.Error(codes.Unauthenticated, "无效的 Authorization 头格式，缺少 Bearer 前缀")
 		}
 
-		err := tokenSvc.ValidateToken(tokenString, secretKey)
+		claims, err := tokenSvc.ValidateToken(tokenString, secretKey)
 		if err != nil {
 			return status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
 		}
+		if claims.UserID == 0 && claims.Client == "" {
+			return status.Error(codes.Unauthenticated, "token 中缺少用户ID和调用方标识")
+		}
 
-		return handler(srv, ss)
+		return handler(srv, &clientStream{ServerStream: ss, ctx: withIdentity(ss.Context(), claims)})
 	}
 }
+
+// withIdentity 记录 token 中的用户和调用方，客户端在请求中传递的用户ID不作为配额和用量的依据
+func withIdentity(ctx context.Context, claims *dto.TokenClaims) context.Context {
+	return quota.WithIdentity(llm.WithUser(ctx, claims.UserID), claims.UserID, claims.Client)
+}
+
+// clientStream 替换流的 ctx，使处理函数能取到调用方
+type clientStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,191 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"net"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/quota"
+	"siwuai/internal/infrastructure/redis_utils"
+	"strconv"
+)
+
+// tokenBucketScript Redis 令牌桶，使用 Redis 的时间，多个实例共享同一个令牌桶
+// ARGV: 每秒补充的令牌数、桶容量、本次消耗的令牌数
+// 返回: 是否放行(1/0)、剩余令牌数、距离桶满的毫秒数、令牌不足时需等待的毫秒数
+const tokenBucketScript = `
+redis.replicate_commands()
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+local wait = 0
+if tokens >= cost then
+  tokens = tokens - cost
+  allowed = 1
+else
+  wait = math.ceil((cost - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, math.floor(tokens), math.ceil((burst - tokens) * 1000 / rate), wait}
+`
+
+// rateLimiter 按方法和调用方限流，调用方为 token 中的调用方标识，没有时使用客户端地址
+type rateLimiter struct {
+	rdb     *redis_utils.RedisClient
+	rule    config.RateLimitRule
+	methods map[string]config.RateLimitRule
+}
+
+func newRateLimiter(rdb *redis_utils.RedisClient, cfg config.Config) *rateLimiter {
+	methods := make(map[string]config.RateLimitRule, len(cfg.RateLimit.Methods))
+	for _, rule := range cfg.RateLimit.Methods {
+		methods[rule.Method] = rule
+	}
+	return &rateLimiter{
+		rdb:     rdb,
+		rule:    cfg.RateLimit.Default,
+		methods: methods,
+	}
+}
+
+// RateLimitInterceptor 创建一个 gRPC 一元拦截器，用于限流，需在 token 验证之后执行
+func RateLimitInterceptor(rdb *redis_utils.RedisClient, cfg config.Config) grpc.UnaryServerInterceptor {
+	limiter := newRateLimiter(rdb, cfg)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		md, err := limiter.take(ctx, info.FullMethod, req)
+		// 限流信息通过 trailer 返回
+		if md != nil {
+			_ = grpc.SetTrailer(ctx, md)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor 创建一个 gRPC 流式拦截器，用于限流，需在 token 验证之后执行
+// 请求大小要收到第一条消息才知道，因此在第一次 RecvMsg 时扣除令牌
+func StreamRateLimitInterceptor(rdb *redis_utils.RedisClient, cfg config.Config) grpc.StreamServerInterceptor {
+	limiter := newRateLimiter(rdb, cfg)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &rateLimitStream{ServerStream: ss, limiter: limiter, method: info.FullMethod})
+	}
+}
+
+// rateLimitStream 在收到第一条消息时限流
+type rateLimitStream struct {
+	grpc.ServerStream
+	limiter *rateLimiter
+	method  string
+	checked bool
+}
+
+func (s *rateLimitStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.checked {
+		return nil
+	}
+	s.checked = true
+
+	md, err := s.limiter.take(s.Context(), s.method, m)
+	if md != nil {
+		s.SetTrailer(md)
+	}
+	return err
+}
+
+// take 从方法和调用方对应的令牌桶中扣除本次请求消耗的令牌，返回限流信息
+// Redis 不可用时放行，避免限流影响正常服务
+func (l *rateLimiter) take(ctx context.Context, method string, req interface{}) (metadata.MD, error) {
+	rule, ok := l.methods[method]
+	if !ok {
+		rule = l.rule
+	}
+	if rule.Rate <= 0 || rule.Burst <= 0 {
+		return nil, nil
+	}
+
+	cost := requestCost(rule, req)
+	if cost > rule.Burst {
+		return nil, status.Errorf(codes.ResourceExhausted, "请求过大，需要 %d 个令牌，超过上限 %d", cost, rule.Burst)
+	}
+
+	key := fmt.Sprintf("ratelimit:%s:%s", method, caller(ctx))
+	val, err := l.rdb.Eval(tokenBucketScript, []string{key}, rule.Rate, rule.Burst, cost)
+	if err != nil {
+		zap.L().Error("限流检查失败，放行本次请求", zap.String("method", method), zap.Error(err))
+		return nil, nil
+	}
+	result, ok := val.([]interface{})
+	if !ok || len(result) != 4 {
+		zap.L().Error("限流脚本返回值格式错误，放行本次请求", zap.Any("result", val))
+		return nil, nil
+	}
+	allowed, _ := result[0].(int64)
+	remaining, _ := result[1].(int64)
+	resetMs, _ := result[2].(int64)
+	waitMs, _ := result[3].(int64)
+
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.FormatInt(rule.Burst, 10),
+		"ratelimit-remaining", strconv.FormatInt(remaining, 10),
+		"ratelimit-reset", strconv.FormatInt(ceilSeconds(resetMs), 10),
+	)
+	if allowed == 1 {
+		return md, nil
+	}
+
+	md.Set("retry-after", strconv.FormatInt(ceilSeconds(waitMs), 10))
+	zap.L().Warn("请求被限流", zap.String("method", method), zap.String("caller", caller(ctx)), zap.Int64("cost", cost))
+	return md, status.Errorf(codes.ResourceExhausted, "请求过于频繁，请 %d 秒后重试", ceilSeconds(waitMs))
+}
+
+// requestCost 计算请求消耗的令牌数，配置了 bytesPerToken 时按请求大小加权
+func requestCost(rule config.RateLimitRule, req interface{}) int64 {
+	if rule.BytesPerToken <= 0 {
+		return 1
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return 1
+	}
+	return 1 + int64(proto.Size(msg)/rule.BytesPerToken)
+}
+
+// caller 调用方标识，token 中没有时使用客户端的 IP
+// 地址中的端口随连接变化，只使用主机部分，避免客户端通过重新连接绕过限流
+func caller(ctx context.Context) string {
+	if client := quota.ClientFromContext(ctx); client != "" {
+		return "client:" + client
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "addr:" + addr
+	}
+	return "unknown"
+}
+
+func ceilSeconds(ms int64) int64 {
+	return (ms + 999) / 1000
+}
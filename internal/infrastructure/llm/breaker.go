@@ -0,0 +1,121 @@
+package llm
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// breakerState 熔断器状态
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // 正常放行
+	breakerOpen                         // 熔断中，直接失败
+	breakerHalfOpen                     // 熔断到期，放行一次探测请求
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker 连续失败达到阈值后熔断，openTimeout 之后放行一次探测请求，成功则恢复
+type circuitBreaker struct {
+	mu          sync.Mutex
+	name        string
+	state       breakerState
+	failures    int
+	threshold   int
+	openTimeout time.Duration
+	openedAt    time.Time
+	probing     bool // 半开状态下是否已有探测请求在进行
+}
+
+func newCircuitBreaker(name string, threshold int, openTimeout time.Duration) *circuitBreaker {
+	b := &circuitBreaker{
+		name:        name,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+	}
+	breakerStates.Set(name, breakerStateVar(breakerClosed))
+	return b
+}
+
+// allow 判断是否可以发起请求，返回 false 时应直接失败
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.setState(breakerHalfOpen)
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success 记录一次成功的请求
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != breakerClosed {
+		b.setState(breakerClosed)
+	}
+}
+
+// failure 记录一次上游故障
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == breakerHalfOpen || (b.state == breakerClosed && b.threshold > 0 && b.failures >= b.threshold) {
+		b.openedAt = time.Now()
+		b.setState(breakerOpen)
+	}
+}
+
+// release 请求既未成功也不是上游故障（例如调用方取消）时释放探测名额
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// setState 切换状态并记录日志和指标，调用方需持有锁
+func (b *circuitBreaker) setState(state breakerState) {
+	zap.L().Warn("大模型熔断器状态变化",
+		zap.String("breaker", b.name),
+		zap.String("from", b.state.String()),
+		zap.String("to", state.String()),
+		zap.Int("failures", b.failures))
+	b.state = state
+	breakerStates.Set(b.name, breakerStateVar(state))
+	if state == breakerOpen {
+		breakerOpens.Add(b.name, 1)
+	}
+}
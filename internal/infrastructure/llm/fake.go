@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+const (
+	fakeModel          = "fake"
+	fakeFinishReason   = "stop"
+	fakeEmbeddingDim   = 64 // 假向量的维度
+	fakeStreamChunkLen = 8  // 流式输出时每段的字符数
+)
+
+// fakeProvider 确定性的假实现，不访问网络
+// 对话按顺序循环返回预设的回答，向量由文本的字符二元组哈希得到，相同文本总是得到相同向量
+type fakeProvider struct {
+	mu        sync.Mutex
+	responses []string
+	index     int
+}
+
+// NewFakeProvider 创建假的大模型提供方，responses 为按顺序循环返回的回答
+func NewFakeProvider(responses ...string) LLMProvider {
+	return &fakeProvider{responses: responses}
+}
+
+// Generate 返回下一条预设回答
+func (f *fakeProvider) Generate(_ context.Context, prompt string, _ ...llms.CallOption) (*Result, error) {
+	content := f.next(prompt)
+	return &Result{Content: content, Model: fakeModel, FinishReason: fakeFinishReason, PromptTokens: EstimateTokens(prompt), CompletionTokens: EstimateTokens(content)}, nil
+}
+
+// GenerateStream 将下一条预设回答按固定长度分段回调
+func (f *fakeProvider) GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error, _ ...llms.CallOption) (*Result, error) {
+	content := f.next(prompt)
+	runes := []rune(content)
+	for start := 0; start < len(runes); start += fakeStreamChunkLen {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+fakeStreamChunkLen, len(runes))
+		if err := onChunk(string(runes[start:end])); err != nil {
+			return nil, err
+		}
+	}
+	return &Result{Content: content, Model: fakeModel, FinishReason: fakeFinishReason, PromptTokens: EstimateTokens(prompt), CompletionTokens: EstimateTokens(content)}, nil
+}
+
+// CreateEmbedding 为每段文本生成确定性的归一化向量
+func (f *fakeProvider) CreateEmbedding(_ context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = fakeEmbedding(text)
+	}
+	return embeddings, nil
+}
+
+// next 循环取出预设回答，未预设时原样返回提示词
+func (f *fakeProvider) next(prompt string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.responses) == 0 {
+		return prompt
+	}
+	response := f.responses[f.index%len(f.responses)]
+	f.index++
+	return response
+}
+
+// fakeEmbedding 将文本的字符二元组哈希到固定维度并归一化，字面相近的文本得到相近的向量
+func fakeEmbedding(text string) []float32 {
+	vec := make([]float32, fakeEmbeddingDim)
+	runes := []rune(text)
+	for i := range runes {
+		h := fnv.New32a()
+		h.Write([]byte(string(runes[i:min(i+2, len(runes))])))
+		vec[h.Sum32()%fakeEmbeddingDim]++
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vec
+	}
+	norm = math.Sqrt(norm)
+	for i := range vec {
+		vec[i] = float32(float64(vec[i]) / norm)
+	}
+	return vec
+}
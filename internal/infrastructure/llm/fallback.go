@@ -0,0 +1,124 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"siwuai/internal/infrastructure/constant"
+
+	"github.com/tmc/langchaingo/llms"
+	"go.uber.org/zap"
+)
+
+// ErrNoEndpoint 备用模型链中的端点都已被排除
+var ErrNoEndpoint = errors.New("没有可用的模型端点")
+
+type excludeKey struct{}
+
+// WithExclude 在 ctx 中记录本次调用不再使用的模型端点，例如输出无法解析的端点
+func WithExclude(ctx context.Context, endpoints ...string) context.Context {
+	if len(endpoints) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, excludeKey{}, endpoints)
+}
+
+func isExcluded(ctx context.Context, endpoint string) bool {
+	excluded, _ := ctx.Value(excludeKey{}).([]string)
+	for _, name := range excluded {
+		if name == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// fallbackProvider 按 AICode 对应的模型链依次尝试各端点，前一个端点出错时使用下一个
+// 向量固定使用 default 端点，不同模型生成的向量不可混用
+type fallbackProvider struct {
+	endpoints map[string]LLMProvider
+	chains    map[constant.AICode][]string
+}
+
+// newFallbackProvider 校验模型链中引用的端点并创建 fallbackProvider
+func newFallbackProvider(endpoints map[string]LLMProvider, chains map[string][]string) (LLMProvider, error) {
+	p := &fallbackProvider{
+		endpoints: endpoints,
+		chains:    make(map[constant.AICode][]string, len(chains)),
+	}
+	for code, names := range chains {
+		if len(names) == 0 {
+			return nil, fmt.Errorf("AICode %s 的模型链为空", code)
+		}
+		for _, name := range names {
+			if _, ok := endpoints[name]; !ok {
+				return nil, fmt.Errorf("AICode %s 的模型链引用了不存在的端点 %s", code, name)
+			}
+		}
+		p.chains[constant.AICode(code)] = names
+	}
+	return p, nil
+}
+
+// Generate 根据提示词生成完整回答
+func (p *fallbackProvider) Generate(ctx context.Context, prompt string, opts ...llms.CallOption) (*Result, error) {
+	return p.each(ctx, nil, func(provider LLMProvider) (*Result, error) {
+		return provider.Generate(ctx, prompt, opts...)
+	})
+}
+
+// GenerateStream 流式生成回答，已经向调用方输出内容后不再切换端点，避免内容重复
+func (p *fallbackProvider) GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error, opts ...llms.CallOption) (*Result, error) {
+	started := false
+	chunkFunc := func(chunk string) error {
+		started = true
+		return onChunk(chunk)
+	}
+
+	return p.each(ctx, func() bool { return !started }, func(provider LLMProvider) (*Result, error) {
+		return provider.GenerateStream(ctx, prompt, chunkFunc, opts...)
+	})
+}
+
+// CreateEmbedding 使用 default 端点为每段文本生成向量
+func (p *fallbackProvider) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	return p.endpoints[DefaultEndpoint].CreateEmbedding(ctx, texts)
+}
+
+// each 依次尝试模型链中未被排除的端点，canFallback 不为空时还需其返回 true 才会切换
+func (p *fallbackProvider) each(ctx context.Context, canFallback func() bool, fn func(provider LLMProvider) (*Result, error)) (*Result, error) {
+	code := CodeFromContext(ctx)
+	names, ok := p.chains[code]
+	if !ok {
+		names = []string{DefaultEndpoint}
+	}
+
+	var lastErr error
+	for _, name := range names {
+		if isExcluded(ctx, name) {
+			continue
+		}
+		if lastErr != nil {
+			if ctx.Err() != nil || (canFallback != nil && !canFallback()) {
+				return nil, lastErr
+			}
+			zap.L().Warn("模型端点调用失败，切换到备用端点",
+				zap.String("code", string(code)),
+				zap.String("endpoint", name),
+				zap.Error(lastErr))
+			fallbacksTotal.Add(string(code), 1)
+		}
+
+		res, err := fn(p.endpoints[name])
+		if err == nil {
+			res.Endpoint = name
+			return res, nil
+		}
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		return nil, ErrNoEndpoint
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,24 @@
+package llm
+
+import (
+	"expvar"
+)
+
+// 大模型调用指标，通过 expvar 在 /debug/vars 暴露，key 为 AICode 或熔断器名称
+var (
+	callsTotal     = expvar.NewMap("llm_calls_total")     // 调用次数（不含重试）
+	failuresTotal  = expvar.NewMap("llm_failures_total")  // 重试后仍失败的调用次数
+	retriesTotal   = expvar.NewMap("llm_retries_total")   // 重试次数
+	rejectsTotal   = expvar.NewMap("llm_rejects_total")   // 被熔断器直接拒绝的次数
+	fallbacksTotal = expvar.NewMap("llm_fallbacks_total") // 切换到备用模型端点的次数
+	latencyMs      = expvar.NewMap("llm_latency_ms")      // 调用累计耗时（毫秒），除以调用次数得到平均耗时
+	breakerStates  = expvar.NewMap("llm_breaker_state")   // 熔断器当前状态
+	breakerOpens   = expvar.NewMap("llm_breaker_opens")   // 熔断次数
+)
+
+// breakerStateVar 以字符串形式输出熔断器状态
+type breakerStateVar breakerState
+
+func (s breakerStateVar) String() string {
+	return `"` + breakerState(s).String() + `"`
+}
@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"fmt"
+	"siwuai/internal/infrastructure/config"
+
+	"github.com/tmc/langchaingo/llms/ollama"
+)
+
+// NewOllamaProvider 创建 Ollama 本地模型提供方
+// 对话使用 endpoint，向量使用 cfg.Embedding.Model，未配置 cfg.Embedding.BaseURL 时与对话共用同一服务地址
+func NewOllamaProvider(endpoint config.LlmEndpoint, cfg config.Config) (LLMProvider, error) {
+	chat, err := ollama.New(
+		ollama.WithModel(endpoint.Model),
+		ollama.WithServerURL(endpoint.BaseURL),
+		ollama.WithHTTPClient(newHTTPClient()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ollama.New() err: %v", err)
+	}
+
+	embedURL := cfg.Embedding.BaseURL
+	if embedURL == "" {
+		embedURL = endpoint.BaseURL
+	}
+	embed, err := ollama.New(
+		ollama.WithModel(cfg.Embedding.Model),
+		ollama.WithServerURL(embedURL),
+		ollama.WithHTTPClient(newHTTPClient()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ollama.New() err: %v", err)
+	}
+
+	return &modelProvider{
+		chat:     chat,
+		embedder: embed,
+		model:    endpoint.Model,
+	}, nil
+}
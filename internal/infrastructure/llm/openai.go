@@ -0,0 +1,37 @@
+package llm
+
+import (
+	"fmt"
+	"siwuai/internal/infrastructure/config"
+
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// NewOpenAIProvider 创建 OpenAI 兼容接口的提供方，对话使用 endpoint，向量使用 cfg.Embedding
+func NewOpenAIProvider(endpoint config.LlmEndpoint, cfg config.Config) (LLMProvider, error) {
+	chat, err := openai.New(
+		openai.WithToken(endpoint.ApiKey),
+		openai.WithModel(endpoint.Model),
+		openai.WithBaseURL(endpoint.BaseURL),
+		openai.WithHTTPClient(newHTTPClient()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("openai.New() err: %v", err)
+	}
+
+	embed, err := openai.New(
+		openai.WithToken(cfg.Embedding.ApiKey),
+		openai.WithEmbeddingModel(cfg.Embedding.Model),
+		openai.WithBaseURL(cfg.Embedding.BaseURL),
+		openai.WithHTTPClient(newHTTPClient()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("openai.New() err: %v", err)
+	}
+
+	return &modelProvider{
+		chat:     chat,
+		embedder: embed,
+		model:    endpoint.Model,
+	}, nil
+}
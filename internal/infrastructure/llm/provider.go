@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"siwuai/internal/infrastructure/config"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// 可选的大模型提供方
+const (
+	OpenAIProvider = "openai" // OpenAI 兼容接口（默认）
+	OllamaProvider = "ollama" // Ollama 本地模型
+	FakeProvider   = "fake"   // 确定性的假实现，用于本地调试和单元测试
+)
+
+// DefaultEndpoint cfg.Llm 本身配置的主模型端点的名称
+const DefaultEndpoint = "default"
+
+// Result 一次大模型调用的结果
+type Result struct {
+	Content  string // 模型返回的完整内容
+	Model    string // 实际生成内容的模型
+	Endpoint string // 实际生成内容的模型端点名称
+
+	FinishReason string // 生成结束的原因，例如 stop、length
+
+	PromptTokens     int // 提示词的 token 数
+	CompletionTokens int // 回答的 token 数
+}
+
+// LLMProvider 大模型提供方接口，统一对话、流式对话和向量生成
+type LLMProvider interface {
+	// Generate 根据提示词生成完整回答
+	Generate(ctx context.Context, prompt string, opts ...llms.CallOption) (*Result, error)
+	// GenerateStream 流式生成回答，每收到一段内容调用一次 onChunk，结束后返回完整回答
+	GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error, opts ...llms.CallOption) (*Result, error)
+	// CreateEmbedding 为每段文本生成向量
+	CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// NewProvider 根据配置文件创建大模型提供方
+// cfg.Llm 为主模型端点，cfg.Llm.Models 为备用端点，每个端点按 cfg.Resilience 包装超时、重试和熔断，
+// 再按 cfg.Llm.Chains 组成各 AICode 的备用模型链
+func NewProvider(cfg config.Config) (LLMProvider, error) {
+	primary := config.LlmEndpoint{
+		Name:     DefaultEndpoint,
+		Provider: cfg.Llm.Provider,
+		ApiKey:   cfg.Llm.ApiKey,
+		Model:    cfg.Llm.Model,
+		BaseURL:  cfg.Llm.BaseURL,
+	}
+
+	endpoints := make(map[string]LLMProvider, len(cfg.Llm.Models)+1)
+	for _, endpoint := range append([]config.LlmEndpoint{primary}, cfg.Llm.Models...) {
+		if endpoint.Name == "" {
+			return nil, fmt.Errorf("模型端点未配置 name: %s", endpoint.Model)
+		}
+		if _, ok := endpoints[endpoint.Name]; ok {
+			return nil, fmt.Errorf("模型端点 %s 重复", endpoint.Name)
+		}
+		provider, err := newEndpointProvider(endpoint, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("模型端点 %s: %v", endpoint.Name, err)
+		}
+		endpoints[endpoint.Name] = NewResilientProvider(endpoint.Name, provider, cfg)
+	}
+
+	return newFallbackProvider(endpoints, cfg.Llm.Chains)
+}
+
+// newEndpointProvider 根据端点的 provider 创建对应的实现
+func newEndpointProvider(endpoint config.LlmEndpoint, cfg config.Config) (LLMProvider, error) {
+	switch endpoint.Provider {
+	case "", OpenAIProvider:
+		return NewOpenAIProvider(endpoint, cfg)
+	case OllamaProvider:
+		return NewOllamaProvider(endpoint, cfg)
+	case FakeProvider:
+		return NewFakeProvider(cfg.Llm.FakeResponses...), nil
+	default:
+		return nil, fmt.Errorf("不支持的大模型提供方: %s", endpoint.Provider)
+	}
+}
+
+// embedder 生成向量的能力，openai 和 ollama 的客户端均已实现
+type embedder interface {
+	CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float32, error)
+}
+
+// modelProvider 基于 langchaingo llms.Model 的通用实现
+type modelProvider struct {
+	chat     llms.Model
+	embedder embedder
+	model    string
+}
+
+// Generate 根据提示词生成完整回答
+func (p *modelProvider) Generate(ctx context.Context, prompt string, opts ...llms.CallOption) (*Result, error) {
+	return p.call(ctx, prompt, opts...)
+}
+
+// GenerateStream 流式生成回答
+func (p *modelProvider) GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error, opts ...llms.CallOption) (*Result, error) {
+	streamingFunc := func(ctx context.Context, chunk []byte) error {
+		return onChunk(string(chunk))
+	}
+	return p.call(ctx, prompt, append(opts, llms.WithStreamingFunc(streamingFunc))...)
+}
+
+// CreateEmbedding 为每段文本生成向量
+func (p *modelProvider) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	embedding, err := p.embedder.CreateEmbedding(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("CreateEmbedding() err: %w", err)
+	}
+	return embedding, nil
+}
+
+func (p *modelProvider) call(ctx context.Context, prompt string, opts ...llms.CallOption) (*Result, error) {
+	msg := llms.MessageContent{
+		Role:  llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{llms.TextContent{Text: prompt}},
+	}
+
+	resp, err := p.chat.GenerateContent(ctx, []llms.MessageContent{msg}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateContent() err: %w", err)
+	}
+	if len(resp.Choices) < 1 {
+		return nil, fmt.Errorf("模型返回内容为空")
+	}
+
+	// 优先使用接口返回的用量，流式接口通常不返回，此时使用本地估算
+	choice := resp.Choices[0]
+	promptTokens := intInfo(choice.GenerationInfo, "PromptTokens")
+	if promptTokens == 0 {
+		promptTokens = EstimateTokens(prompt)
+	}
+	completionTokens := intInfo(choice.GenerationInfo, "CompletionTokens")
+	if completionTokens == 0 {
+		completionTokens = EstimateTokens(choice.Content)
+	}
+
+	return &Result{
+		Content:          choice.Content,
+		Model:            p.model,
+		FinishReason:     choice.StopReason,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+	}, nil
+}
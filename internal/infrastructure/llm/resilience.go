@@ -0,0 +1,239 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 未配置时使用的退避参数
+const (
+	defaultBaseBackoff = 500 * time.Millisecond
+	defaultMaxBackoff  = 10 * time.Second
+)
+
+// ErrCircuitOpen 熔断期间直接返回的错误
+var ErrCircuitOpen = errors.New("大模型服务暂不可用，已熔断")
+
+// UnavailableError 上游持续不可用（熔断或重试耗尽），返回给客户端时对应 codes.Unavailable
+type UnavailableError struct {
+	Err error
+}
+
+func (e *UnavailableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus 使被 %w 包装后的错误经 gRPC 返回时仍为 codes.Unavailable
+func (e *UnavailableError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+type codeKey struct{}
+
+// WithCode 在 ctx 中记录本次调用所属的 AICode，用于选择超时时间和统计指标
+func WithCode(ctx context.Context, code constant.AICode) context.Context {
+	return context.WithValue(ctx, codeKey{}, code)
+}
+
+// CodeFromContext 取出 WithCode 记录的 AICode，未记录时返回 "unknown"
+func CodeFromContext(ctx context.Context) constant.AICode {
+	if code, ok := ctx.Value(codeKey{}).(constant.AICode); ok {
+		return code
+	}
+	return "unknown"
+}
+
+// resilientProvider 为大模型调用增加超时、重试和熔断
+// 对话和向量分别使用独立的熔断器，避免一方故障影响另一方
+type resilientProvider struct {
+	next         LLMProvider
+	timeout      time.Duration
+	timeouts     map[constant.AICode]time.Duration
+	maxRetries   int
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+	chatBreaker  *circuitBreaker
+	embedBreaker *circuitBreaker
+}
+
+// NewResilientProvider 按 cfg.Resilience 为 next 包装超时、重试和熔断策略，name 用于区分各端点的熔断器
+func NewResilientProvider(name string, next LLMProvider, cfg config.Config) LLMProvider {
+	rc := cfg.Resilience
+
+	timeouts := make(map[constant.AICode]time.Duration, len(rc.Timeouts))
+	for code, seconds := range rc.Timeouts {
+		timeouts[constant.AICode(code)] = time.Duration(seconds) * time.Second
+	}
+
+	p := &resilientProvider{
+		next:         next,
+		timeout:      time.Duration(rc.Timeout) * time.Second,
+		timeouts:     timeouts,
+		maxRetries:   rc.MaxRetries,
+		baseBackoff:  time.Duration(rc.BaseBackoff) * time.Millisecond,
+		maxBackoff:   time.Duration(rc.MaxBackoff) * time.Millisecond,
+		chatBreaker:  newCircuitBreaker(name+"/chat", rc.FailureThreshold, time.Duration(rc.OpenTimeout)*time.Second),
+		embedBreaker: newCircuitBreaker(name+"/embedding", rc.FailureThreshold, time.Duration(rc.OpenTimeout)*time.Second),
+	}
+	if p.baseBackoff <= 0 {
+		p.baseBackoff = defaultBaseBackoff
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = defaultMaxBackoff
+	}
+	return p
+}
+
+// Generate 根据提示词生成完整回答
+func (p *resilientProvider) Generate(ctx context.Context, prompt string, opts ...llms.CallOption) (*Result, error) {
+	var res *Result
+	err := p.do(ctx, p.chatBreaker, nil, func(ctx context.Context) (err error) {
+		res, err = p.next.Generate(ctx, prompt, opts...)
+		return err
+	})
+	return res, err
+}
+
+// GenerateStream 流式生成回答，已经向调用方输出内容后不再重试，避免内容重复
+func (p *resilientProvider) GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error, opts ...llms.CallOption) (*Result, error) {
+	started := false
+	chunkFunc := func(chunk string) error {
+		started = true
+		return onChunk(chunk)
+	}
+
+	var res *Result
+	err := p.do(ctx, p.chatBreaker, func() bool { return !started }, func(ctx context.Context) (err error) {
+		res, err = p.next.GenerateStream(ctx, prompt, chunkFunc, opts...)
+		return err
+	})
+	return res, err
+}
+
+// CreateEmbedding 为每段文本生成向量
+func (p *resilientProvider) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	var embedding [][]float32
+	err := p.do(ctx, p.embedBreaker, nil, func(ctx context.Context) (err error) {
+		embedding, err = p.next.CreateEmbedding(ctx, texts)
+		return err
+	})
+	return embedding, err
+}
+
+// do 执行一次调用，临时性错误按指数退避加抖动重试，canRetry 不为空时还需其返回 true 才会重试
+func (p *resilientProvider) do(ctx context.Context, breaker *circuitBreaker, canRetry func() bool, fn func(ctx context.Context) error) error {
+	code := string(CodeFromContext(ctx))
+	callsTotal.Add(code, 1)
+	start := time.Now()
+	defer func() {
+		latencyMs.Add(code, time.Since(start).Milliseconds())
+	}()
+
+	for attempt := 0; ; attempt++ {
+		if !breaker.allow() {
+			rejectsTotal.Add(code, 1)
+			failuresTotal.Add(code, 1)
+			return &UnavailableError{Err: fmt.Errorf("%s: %w", breaker.name, ErrCircuitOpen)}
+		}
+
+		err := p.attempt(ctx, code, fn)
+		if err == nil {
+			breaker.success()
+			return nil
+		}
+
+		// 调用方取消或业务性错误（如 4xx）不代表上游故障，不计入熔断也不重试
+		if ctx.Err() != nil || !isTransient(err) {
+			breaker.release()
+			failuresTotal.Add(code, 1)
+			return err
+		}
+		breaker.failure()
+
+		if attempt >= p.maxRetries || (canRetry != nil && !canRetry()) {
+			failuresTotal.Add(code, 1)
+			return &UnavailableError{Err: err}
+		}
+
+		delay := p.backoff(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			failuresTotal.Add(code, 1)
+			return &UnavailableError{Err: err}
+		}
+
+		zap.L().Warn("大模型调用失败，准备重试",
+			zap.String("code", code),
+			zap.String("breaker", breaker.name),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+		retriesTotal.Add(code, 1)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			failuresTotal.Add(code, 1)
+			return err
+		}
+	}
+}
+
+// attempt 按 AICode 对应的超时时间执行单次调用
+func (p *resilientProvider) attempt(ctx context.Context, code string, fn func(ctx context.Context) error) error {
+	timeout, ok := p.timeouts[constant.AICode(code)]
+	if !ok {
+		timeout = p.timeout
+	}
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(attemptCtx)
+}
+
+// backoff 计算第 attempt 次重试前的等待时间，上游返回 Retry-After 时至少等待该时长
+func (p *resilientProvider) backoff(attempt int, err error) time.Duration {
+	delay := p.baseBackoff << attempt
+	if delay <= 0 || delay > p.maxBackoff {
+		delay = p.maxBackoff
+	}
+	// 保留一半，另一半随机，避免多个请求同时重试
+	delay = delay/2 + rand.N(delay/2+1)
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+		delay = statusErr.RetryAfter
+	}
+	return delay
+}
+
+// isTransient 判断错误是否为可重试的临时性错误：429、5xx、单次调用超时和网络错误
+func isTransient(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,234 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"siwuai/internal/infrastructure/config"
+)
+
+const chatCompletion = `{"id":"1","object":"chat.completion","created":1,"model":"test-model",` +
+	`"choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],` +
+	`"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`
+
+// fakeServer 模拟 OpenAI 兼容接口，第 n 次请求(从 0 开始)的响应由 respond 决定
+type fakeServer struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newFakeServer(t *testing.T, respond func(n int, w http.ResponseWriter)) *fakeServer {
+	t.Helper()
+	s := &fakeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(int(s.calls.Add(1)-1), w)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func respondOK(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(chatCompletion))
+}
+
+func respondError(w http.ResponseWriter, code int) {
+	http.Error(w, `{"error":{"message":"upstream error"}}`, code)
+}
+
+// newTestProvider 创建指向 fakeServer 的带重试和熔断的提供方
+func newTestProvider(t *testing.T, s *fakeServer, maxRetries, threshold int) *resilientProvider {
+	t.Helper()
+	var cfg config.Config
+	cfg.Resilience.Timeout = 5
+	cfg.Resilience.MaxRetries = maxRetries
+	cfg.Resilience.BaseBackoff = 1
+	cfg.Resilience.MaxBackoff = 5
+	cfg.Resilience.FailureThreshold = threshold
+	cfg.Resilience.OpenTimeout = 60
+	cfg.Embedding.ApiKey = "test"
+	cfg.Embedding.BaseURL = s.URL
+
+	next, err := NewOpenAIProvider(config.LlmEndpoint{Model: "test-model", ApiKey: "test", BaseURL: s.URL}, cfg)
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() err: %v", err)
+	}
+	return NewResilientProvider(t.Name(), next, cfg).(*resilientProvider)
+}
+
+func TestRetryAfter(t *testing.T) {
+	s := newFakeServer(t, func(n int, w http.ResponseWriter) {
+		if n == 0 {
+			w.Header().Set("Retry-After", "1")
+			respondError(w, http.StatusTooManyRequests)
+			return
+		}
+		respondOK(w)
+	})
+	p := newTestProvider(t, s, 2, 0)
+
+	start := time.Now()
+	res, err := p.Generate(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("Generate() err: %v", err)
+	}
+	if res.Content != "ok" || s.calls.Load() != 2 {
+		t.Fatalf("content = %q, calls = %d, want ok after 2 calls", res.Content, s.calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("重试前只等待了 %v，应至少等待 Retry-After 指定的 1s", elapsed)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int // 依次返回的状态码，用完后返回成功
+		maxRetries  int
+		wantCalls   int32
+		wantErr     bool
+		unavailable bool
+	}{
+		{name: "5xx 重试后成功", statuses: []int{500, 503}, maxRetries: 2, wantCalls: 3},
+		{name: "重试耗尽", statuses: []int{502, 502, 502, 502}, maxRetries: 2, wantCalls: 3, wantErr: true, unavailable: true},
+		{name: "4xx 不重试", statuses: []int{400}, maxRetries: 2, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newFakeServer(t, func(n int, w http.ResponseWriter) {
+				if n < len(tt.statuses) {
+					respondError(w, tt.statuses[n])
+					return
+				}
+				respondOK(w)
+			})
+			p := newTestProvider(t, s, tt.maxRetries, 0)
+
+			_, err := p.Generate(context.Background(), "hi")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generate() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := s.calls.Load(); got != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			var unavailable *UnavailableError
+			if errors.As(err, &unavailable) != tt.unavailable {
+				t.Fatalf("err = %v, UnavailableError = %v, want %v", err, !tt.unavailable, tt.unavailable)
+			}
+			if tt.unavailable {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != 502 {
+					t.Fatalf("err = %v, want wrapped StatusError 502", err)
+				}
+				if code := status.Code(err); code != codes.Unavailable {
+					t.Fatalf("status.Code() = %v, want Unavailable", code)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	s := newFakeServer(t, func(n int, w http.ResponseWriter) {
+		if healthy.Load() {
+			respondOK(w)
+			return
+		}
+		respondError(w, http.StatusInternalServerError)
+	})
+	p := newTestProvider(t, s, 0, 2)
+	ctx := context.Background()
+
+	// 连续失败达到阈值后熔断
+	for i := 0; i < 2; i++ {
+		if _, err := p.Generate(ctx, "hi"); err == nil {
+			t.Fatal("Generate() 应失败")
+		}
+	}
+	if p.chatBreaker.state != breakerOpen {
+		t.Fatalf("state = %v, want open", p.chatBreaker.state)
+	}
+
+	// 熔断期间不请求上游，直接返回 Unavailable
+	_, err := p.Generate(ctx, "hi")
+	if !errors.Is(err, ErrCircuitOpen) || status.Code(err) != codes.Unavailable {
+		t.Fatalf("err = %v, want ErrCircuitOpen with codes.Unavailable", err)
+	}
+	if got := s.calls.Load(); got != 2 {
+		t.Fatalf("熔断期间请求了上游，calls = %d", got)
+	}
+
+	// 向量使用独立的熔断器，不受影响
+	if p.embedBreaker.state != breakerClosed {
+		t.Fatalf("embed state = %v, want closed", p.embedBreaker.state)
+	}
+
+	// 到期后半开，探测失败重新熔断
+	p.chatBreaker.openTimeout = 20 * time.Millisecond
+	time.Sleep(30 * time.Millisecond)
+	if _, err = p.Generate(ctx, "hi"); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("探测请求 err = %v, want upstream error", err)
+	}
+	if p.chatBreaker.state != breakerOpen || s.calls.Load() != 3 {
+		t.Fatalf("state = %v, calls = %d, want open after 3 calls", p.chatBreaker.state, s.calls.Load())
+	}
+
+	// 再次到期后探测成功，恢复正常
+	healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+	if _, err = p.Generate(ctx, "hi"); err != nil {
+		t.Fatalf("探测请求 err: %v", err)
+	}
+	if p.chatBreaker.state != breakerClosed {
+		t.Fatalf("state = %v, want closed", p.chatBreaker.state)
+	}
+}
+
+func TestHalfOpenSingleProbe(t *testing.T) {
+	b := newCircuitBreaker(t.Name(), 1, time.Millisecond)
+	b.failure()
+	time.Sleep(2 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("到期后应放行探测请求")
+	}
+	if b.state != breakerHalfOpen {
+		t.Fatalf("state = %v, want half_open", b.state)
+	}
+	if b.allow() {
+		t.Fatal("半开状态下只放行一个探测请求")
+	}
+	// 探测请求被取消时释放名额
+	b.release()
+	if !b.allow() {
+		t.Fatal("释放后应再次放行探测请求")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{now.Add(5 * time.Second).Format(http.TimeFormat), 5 * time.Second},
+		{now.Add(-5 * time.Second).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
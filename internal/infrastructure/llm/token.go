@@ -0,0 +1,34 @@
+package llm
+
+import "unicode"
+
+// EstimateTokens 在接口未返回用量或需要判断内容长短时估算文本的 token 数，不同模型的实际 token 数会有差异
+// 中日韩字符按每字 1 个 token，空白不计，其余字符按每 4 个 1 个 token
+func EstimateTokens(text string) int {
+	cjk, others := 0, 0
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			cjk++
+		case !unicode.IsSpace(r):
+			others++
+		}
+	}
+	return cjk + (others+3)/4
+}
+
+// RuneTokens 单个字符估算的 token 数，与 EstimateTokens 的规则一致，用于按 token 数切分文本
+func RuneTokens(r rune) float64 {
+	switch {
+	case isCJK(r):
+		return 1
+	case unicode.IsSpace(r):
+		return 0
+	default:
+		return 0.25
+	}
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
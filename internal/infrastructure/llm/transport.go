@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxErrorBody 错误响应体最多读取的字节数
+const maxErrorBody = 4 << 10
+
+// StatusError 大模型接口返回的非 2xx 响应
+// langchaingo 只返回拼接后的错误字符串，在 http 层拦截下来才能拿到状态码和 Retry-After
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // 响应头 Retry-After 的值，未返回时为 0
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("大模型接口返回 %d: %s", e.StatusCode, e.Body)
+}
+
+// Temporary 429 和 5xx 视为临时性错误，可以重试
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// statusTransport 将 429 和 5xx 响应转换为 *StatusError
+type statusTransport struct {
+	next http.RoundTripper
+}
+
+// newHTTPClient 创建交给 langchaingo 客户端使用的 http.Client
+func newHTTPClient() *http.Client {
+	return &http.Client{Transport: &statusTransport{next: http.DefaultTransport}}
+}
+
+func (t *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	statusErr := &StatusError{StatusCode: resp.StatusCode}
+	if !statusErr.Temporary() {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	statusErr.Body = string(body)
+	statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return nil, statusErr
+}
+
+// parseRetryAfter 解析 Retry-After，支持秒数和 HTTP 日期两种格式
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"go.uber.org/zap"
+)
+
+// Usage 一次大模型调用的 token 用量
+type Usage struct {
+	UserID           uint
+	Code             constant.AICode
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// UsageRecorder 记录大模型调用的 token 用量
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, usage Usage) error
+}
+
+type userKey struct{}
+
+// WithUser 在 ctx 中记录发起本次调用的用户，用于按用户统计用量
+func WithUser(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userKey{}, userID)
+}
+
+// UserFromContext 取出 WithUser 记录的用户ID，未记录时返回 0
+func UserFromContext(ctx context.Context) uint {
+	userID, _ := ctx.Value(userKey{}).(uint)
+	return userID
+}
+
+// usageProvider 在每次调用后记录 token 用量
+type usageProvider struct {
+	next           LLMProvider
+	recorder       UsageRecorder
+	embeddingModel string
+}
+
+// NewUsageProvider 为 next 增加用量记录，记录失败只打印日志，不影响调用结果
+func NewUsageProvider(next LLMProvider, recorder UsageRecorder, cfg config.Config) LLMProvider {
+	return &usageProvider{
+		next:           next,
+		recorder:       recorder,
+		embeddingModel: cfg.Embedding.Model,
+	}
+}
+
+// Generate 根据提示词生成完整回答
+func (p *usageProvider) Generate(ctx context.Context, prompt string, opts ...llms.CallOption) (*Result, error) {
+	res, err := p.next.Generate(ctx, prompt, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.record(ctx, res.Model, res.PromptTokens, res.CompletionTokens)
+	return res, nil
+}
+
+// GenerateStream 流式生成回答
+// 中途失败或被取消时已经消耗了 token，按提示词和已输出的内容估算用量，此时不知道实际使用的模型，模型记为空
+func (p *usageProvider) GenerateStream(ctx context.Context, prompt string, onChunk func(chunk string) error, opts ...llms.CallOption) (*Result, error) {
+	var streamed strings.Builder
+	res, err := p.next.GenerateStream(ctx, prompt, func(chunk string) error {
+		streamed.WriteString(chunk)
+		return onChunk(chunk)
+	}, opts...)
+	if err != nil {
+		// 未输出任何内容且不是被取消的调用(如熔断、连接失败)通常没有消耗 token
+		if streamed.Len() > 0 || ctx.Err() != nil || errors.Is(err, context.Canceled) {
+			p.record(ctx, "", EstimateTokens(prompt), EstimateTokens(streamed.String()))
+		}
+		return nil, err
+	}
+	p.record(ctx, res.Model, res.PromptTokens, res.CompletionTokens)
+	return res, nil
+}
+
+// CreateEmbedding 为每段文本生成向量，接口不返回用量，按输入文本估算
+func (p *usageProvider) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	embedding, err := p.next.CreateEmbedding(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	tokens := 0
+	for _, text := range texts {
+		tokens += EstimateTokens(text)
+	}
+	p.record(ctx, p.embeddingModel, tokens, 0)
+	return embedding, nil
+}
+
+func (p *usageProvider) record(ctx context.Context, model string, promptTokens, completionTokens int) {
+	usage := Usage{
+		UserID:           UserFromContext(ctx),
+		Code:             CodeFromContext(ctx),
+		Model:            model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+	}
+	// 请求结束后 ctx 可能已被取消，用量仍需写入
+	if err := p.recorder.RecordUsage(context.WithoutCancel(ctx), usage); err != nil {
+		zap.L().Error("记录大模型用量失败", zap.Any("usage", usage), zap.Error(err))
+	}
+}
+
+// intInfo 读取 GenerationInfo 中的整数值
+func intInfo(info map[string]any, key string) int {
+	switch v := info[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
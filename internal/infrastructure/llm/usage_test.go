@@ -0,0 +1,86 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+	"siwuai/internal/infrastructure/config"
+)
+
+type usageRecorderFunc func(usage Usage)
+
+func (f usageRecorderFunc) RecordUsage(_ context.Context, usage Usage) error {
+	f(usage)
+	return nil
+}
+
+// streamProvider 依次输出 chunks 后返回 err，ctx 被取消时立即返回
+type streamProvider struct {
+	LLMProvider
+	chunks []string
+	err    error
+}
+
+func (p *streamProvider) GenerateStream(ctx context.Context, _ string, onChunk func(chunk string) error, _ ...llms.CallOption) (*Result, error) {
+	for _, chunk := range p.chunks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := onChunk(chunk); err != nil {
+			return nil, err
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Result{Model: "m", PromptTokens: 10, CompletionTokens: 20}, nil
+}
+
+func TestUsageProviderStream(t *testing.T) {
+	const prompt = "一二三四五"
+	upstream := errors.New("upstream closed")
+
+	tests := []struct {
+		name     string
+		provider *streamProvider
+		cancel   bool // 输出第一段后取消
+		want     *Usage
+	}{
+		{name: "成功时使用接口返回的用量", provider: &streamProvider{chunks: []string{"你好"}},
+			want: &Usage{Model: "m", PromptTokens: 10, CompletionTokens: 20}},
+		{name: "中途失败按已输出内容估算", provider: &streamProvider{chunks: []string{"你好", "世界"}, err: upstream},
+			want: &Usage{PromptTokens: 5, CompletionTokens: 4}},
+		{name: "中途取消按已输出内容估算", provider: &streamProvider{chunks: []string{"你好", "世界"}}, cancel: true,
+			want: &Usage{PromptTokens: 5, CompletionTokens: 2}},
+		{name: "未输出内容的失败不记录", provider: &streamProvider{err: upstream}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Usage
+			p := NewUsageProvider(tt.provider, usageRecorderFunc(func(usage Usage) { got = &usage }), config.Config{})
+
+			ctx, cancel := context.WithCancel(WithUser(context.Background(), 7))
+			defer cancel()
+			_, _ = p.GenerateStream(ctx, prompt, func(string) error {
+				if tt.cancel {
+					cancel()
+				}
+				return nil
+			})
+
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("不应记录用量，got %+v", got)
+				}
+				return
+			}
+			tt.want.UserID = 7
+			tt.want.Code = "unknown"
+			if got == nil || *got != *tt.want {
+				t.Fatalf("usage = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,36 @@
 package persistence
 
-import "siwuai/internal/domain/model/entity"
+import (
+	"errors"
+	"siwuai/internal/domain/model/entity"
+)
+
+// ErrArticleNotFound 数据库中没有该文章ID的记录
+var ErrArticleNotFound = errors.New("数据库中没有该文章的信息")
+
+// ErrArticleVersionNotFound 数据库中没有该版本的摘要和总结
+var ErrArticleVersionNotFound = errors.New("数据库中没有该版本的信息")
 
 type ArticleRepositoryInterface interface {
 	VerifyHash(key string) (*entity.Article, error)
 	SaveArticleInfo(article *entity.Article) error
 	SaveArticleID(key string, articleID uint) error
-	GetArticleInfo(articleID uint) (*entity.Article, error)
+	GetArticleInfo(articleID uint, language string) (*entity.Article, error)
 	DelArticleInfo(articleID uint) error
+	ListArticles(afterID uint, limit int) ([]entity.Article, error)
+	UpdateArticleContent(id uint, key string, content string) error
+	LinkArticleVersion(id uint, articleID uint, language string) error
+	SaveArticleVersion(version *entity.ArticleVersion) error
+	ListArticleVersions(articleID uint) ([]entity.ArticleVersion, error)
+	GetArticleVersion(versionID uint) (*entity.ArticleVersion, error)
+	PinArticleVersion(id uint, version *entity.ArticleVersion) error
+	SaveArticleOverride(override *entity.ArticleOverride) error
+	GetArticleOverride(articleID uint) (*entity.ArticleOverride, error)
+	DelArticleOverride(articleID uint) error
+	SaveArticleTags(recordID uint, articleID uint, tags []string) error
+	GetArticleTags(recordID uint) ([]string, error)
+	ListArticlesByTag(tag string, afterID uint, limit int) ([]uint, error)
+	SaveArticleTranslation(translation *entity.ArticleTranslation) error
+	SaveArticleSEO(id uint, seo entity.ArticleSEO) error
+	GetArticleTranslation(articleID uint, key string, language string) (*entity.ArticleTranslation, error)
 }
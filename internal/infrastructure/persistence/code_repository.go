@@ -10,4 +10,5 @@ type CodeRepository interface {
 	SaveCode(code *entity.Code) (uint, error)
 	SaveHistory(entity.History) error
 	GetHistory(userId string) ([]entity.Code, error)
+	ListCodes(afterID uint, limit int) ([]entity.Code, error)
 }
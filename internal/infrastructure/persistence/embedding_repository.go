@@ -0,0 +1,13 @@
+package persistence
+
+import (
+	"siwuai/internal/domain/model/entity"
+)
+
+// EmbeddingRepository 定义了向量存储的访问接口
+type EmbeddingRepository interface {
+	SaveEmbedding(embedding *entity.Embedding) error
+	DelEmbedding(docType, docID string) (bool, error)
+	GetEmbeddings(docs [][2]string) ([]entity.Embedding, error)
+	ListEmbeddings(afterID uint, limit int) ([]entity.Embedding, error)
+}
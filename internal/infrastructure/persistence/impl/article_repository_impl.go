@@ -3,8 +3,10 @@ package impl
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"siwuai/internal/domain/model/entity"
 	"siwuai/internal/infrastructure/persistence"
+	"siwuai/internal/infrastructure/prompt"
 )
 
 type articleRepository struct {
@@ -71,6 +73,13 @@ func (a *articleRepository) SaveArticleID(key string, articleID uint) error {
 		return fmt.Errorf("保存文章的ID失败")
 	}
 
+	// 记录生成的标签一并关联到文章ID
+	records := tx.Model(&entity.Article{}).Select("id").Where("`key` = ?", key)
+	if err := tx.Model(&entity.ArticleTag{}).Where("record_id IN (?)", records).Update("article_id", articleID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("(a *articleRepository) SaveArticleID -> %v", err)
+	}
+
 	err := tx.Commit().Error
 	if err != nil {
 		tx.Rollback()
@@ -80,11 +89,11 @@ func (a *articleRepository) SaveArticleID(key string, articleID uint) error {
 	return nil
 }
 
-// GetArticleInfo 查询文章信息
-func (a *articleRepository) GetArticleInfo(articleID uint) (*entity.Article, error) {
+// GetArticleInfo 查询文章在指定语言下的信息，同一文章ID有多条记录时返回最新的一条
+func (a *articleRepository) GetArticleInfo(articleID uint, language string) (*entity.Article, error) {
 
 	var articleInfo entity.Article
-	result := a.db.Model(&entity.Article{}).Where("article_id = ?", articleID).Scan(&articleInfo)
+	result := a.db.Model(&entity.Article{}).Where("article_id = ? AND language = ?", articleID, language).Order("id DESC").Limit(1).Scan(&articleInfo)
 	if result.Error != nil {
 		return nil, fmt.Errorf("(a *articleRepository) GetArticleInfo -> %v", result.Error)
 	}
@@ -98,6 +107,246 @@ func (a *articleRepository) GetArticleInfo(articleID uint) (*entity.Article, err
 	return &articleInfo, nil
 }
 
+// ListArticles 按主键顺序分页读取已保存文章ID的默认语言记录
+func (a *articleRepository) ListArticles(afterID uint, limit int) ([]entity.Article, error) {
+	var articles []entity.Article
+	err := a.db.Where("id > ? AND article_id <> 0 AND language = ?", afterID, prompt.DefaultLanguage).Order("id").Limit(limit).Find(&articles).Error
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleRepository) ListArticles -> %v", err)
+	}
+	return articles, nil
+}
+
+// UpdateArticleContent 文章改动较小时只更新记录的 hash值和内容，保留原有的摘要和总结
+func (a *articleRepository) UpdateArticleContent(id uint, key string, content string) error {
+	result := a.db.Model(&entity.Article{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"key":     key,
+		"content": content,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("(a *articleRepository) UpdateArticleContent -> %v", result.Error)
+	} else if result.RowsAffected <= 0 {
+		return fmt.Errorf("更新文章内容失败")
+	}
+	return nil
+}
+
+// LinkArticleVersion 将新版本内容的记录(主键为 id)关联到文章ID，并删除该文章ID下同一语言其他版本的记录及其生成的标签
+func (a *articleRepository) LinkArticleVersion(id uint, articleID uint, language string) error {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Article{}).Where("id = ?", id).Update("article_id", articleID)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected <= 0 {
+			return fmt.Errorf("文章记录不存在")
+		}
+		if err := tx.Model(&entity.ArticleTag{}).Where("record_id = ?", id).Update("article_id", articleID).Error; err != nil {
+			return err
+		}
+
+		var staleIDs []uint
+		err := tx.Model(&entity.Article{}).Where("article_id = ? AND language = ? AND id <> ?", articleID, language, id).Pluck("id", &staleIDs).Error
+		if err != nil || len(staleIDs) == 0 {
+			return err
+		}
+		if err = tx.Where("id IN ?", staleIDs).Delete(&entity.Article{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("record_id IN ?", staleIDs).Delete(&entity.ArticleTag{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) LinkArticleVersion -> %v", err)
+	}
+	return nil
+}
+
+// SaveArticleVersion 保存文章记录生成的版本，同一条记录只保存一次
+// 记录尚未关联版本时设为其当前使用的版本，已固定其他版本的记录不受影响
+func (a *articleRepository) SaveArticleVersion(version *entity.ArticleVersion) error {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where(&entity.ArticleVersion{ArticleID: version.ArticleID, RecordID: version.RecordID}).FirstOrCreate(version).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&entity.Article{}).Where("id = ? AND version_id = 0", version.RecordID).Update("version_id", version.ID).Error
+	})
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) SaveArticleVersion -> %v", err)
+	}
+	return nil
+}
+
+// ListArticleVersions 查询文章的全部版本，按生成时间从新到旧排序
+func (a *articleRepository) ListArticleVersions(articleID uint) ([]entity.ArticleVersion, error) {
+	var versions []entity.ArticleVersion
+	err := a.db.Where("article_id = ?", articleID).Order("id DESC").Find(&versions).Error
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleRepository) ListArticleVersions -> %v", err)
+	}
+	return versions, nil
+}
+
+// GetArticleVersion 查询指定的版本，没有记录时返回 persistence.ErrArticleVersionNotFound
+func (a *articleRepository) GetArticleVersion(versionID uint) (*entity.ArticleVersion, error) {
+	var version entity.ArticleVersion
+	result := a.db.Where("id = ?", versionID).Limit(1).Find(&version)
+	if result.Error != nil {
+		return nil, fmt.Errorf("(a *articleRepository) GetArticleVersion -> %v", result.Error)
+	} else if result.RowsAffected == 0 {
+		return nil, persistence.ErrArticleVersionNotFound
+	}
+	return &version, nil
+}
+
+// PinArticleVersion 将文章记录(主键为 id)的摘要、总结和标签替换为指定的版本
+func (a *articleRepository) PinArticleVersion(id uint, version *entity.ArticleVersion) error {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Article{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"abstract":       version.Abstract,
+			"summary":        version.Summary,
+			"confidence":     version.Confidence,
+			"llm_model":      version.LLMModel,
+			"prompt_version": version.PromptVersion,
+			"version_id":     version.ID,
+		})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected <= 0 {
+			return fmt.Errorf("文章记录不存在")
+		}
+		return replaceArticleTags(tx, entity.NewArticleTags(version.ArticleID, id, version.Tags), "record_id = ?", id)
+	})
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) PinArticleVersion -> %v", err)
+	}
+	return nil
+}
+
+// SaveArticleOverride 保存编辑人工修改的内容，文章已有修改时整体替换
+func (a *articleRepository) SaveArticleOverride(override *entity.ArticleOverride) error {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "article_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"abstract", "summary", "tags", "editor_id", "updated_at"}),
+		}).Create(override).Error
+		if err != nil {
+			return err
+		}
+		return replaceArticleTags(tx, entity.NewArticleTags(override.ArticleID, 0, override.Tags), "article_id = ? AND record_id = 0", override.ArticleID)
+	})
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) SaveArticleOverride -> %v", err)
+	}
+	return nil
+}
+
+// GetArticleOverride 查询编辑人工修改的内容，没有修改时返回 nil
+func (a *articleRepository) GetArticleOverride(articleID uint) (*entity.ArticleOverride, error) {
+	var override entity.ArticleOverride
+	result := a.db.Where("article_id = ?", articleID).Limit(1).Find(&override)
+	if result.Error != nil {
+		return nil, fmt.Errorf("(a *articleRepository) GetArticleOverride -> %v", result.Error)
+	} else if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &override, nil
+}
+
+// DelArticleOverride 删除编辑人工修改的内容，文章ID有唯一索引，直接物理删除以便之后重新保存
+func (a *articleRepository) DelArticleOverride(articleID uint) error {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleOverride{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("article_id = ? AND record_id = 0", articleID).Delete(&entity.ArticleTag{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) DelArticleOverride -> %v", err)
+	}
+	return nil
+}
+
+// SaveArticleTags 保存文章记录生成的标签，替换该记录原有的标签
+func (a *articleRepository) SaveArticleTags(recordID uint, articleID uint, tags []string) error {
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		return replaceArticleTags(tx, entity.NewArticleTags(articleID, recordID, tags), "record_id = ?", recordID)
+	})
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) SaveArticleTags -> %v", err)
+	}
+	return nil
+}
+
+// GetArticleTags 查询文章记录生成的标签，按保存的顺序返回
+func (a *articleRepository) GetArticleTags(recordID uint) ([]string, error) {
+	var tags []string
+	err := a.db.Model(&entity.ArticleTag{}).Where("record_id = ?", recordID).Order("id").Pluck("tag", &tags).Error
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleRepository) GetArticleTags -> %v", err)
+	}
+	return tags, nil
+}
+
+// ListArticlesByTag 按文章ID顺序分页查询带有该标签的文章ID，有人工修改标签的文章只按修改后的标签匹配
+func (a *articleRepository) ListArticlesByTag(tag string, afterID uint, limit int) ([]uint, error) {
+	var articleIDs []uint
+	edited := a.db.Model(&entity.ArticleTag{}).Select("article_id").Where("record_id = 0")
+	err := a.db.Model(&entity.ArticleTag{}).Distinct("article_id").
+		Where("tag = ? AND article_id > ?", tag, afterID).
+		Where("record_id = 0 OR article_id NOT IN (?)", edited).
+		Order("article_id").Limit(limit).Pluck("article_id", &articleIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("(a *articleRepository) ListArticlesByTag -> %v", err)
+	}
+	return articleIDs, nil
+}
+
+// SaveArticleSEO 保存文章记录的 SEO 信息，替换原有的 SEO 信息
+func (a *articleRepository) SaveArticleSEO(id uint, seo entity.ArticleSEO) error {
+	result := a.db.Model(&entity.Article{}).Where("id = ?", id).
+		Select("seo_title", "meta_description", "slug", "og_title", "og_description").
+		Updates(&entity.Article{ArticleSEO: seo})
+	if result.Error != nil {
+		return fmt.Errorf("(a *articleRepository) SaveArticleSEO -> %v", result.Error)
+	}
+	return nil
+}
+
+// SaveArticleTranslation 保存文章的译文，同一文章、内容和语言已有译文时替换
+func (a *articleRepository) SaveArticleTranslation(translation *entity.ArticleTranslation) error {
+	err := a.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}, {Name: "key"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content", "llm_model", "updated_at"}),
+	}).Create(translation).Error
+	if err != nil {
+		return fmt.Errorf("(a *articleRepository) SaveArticleTranslation -> %v", err)
+	}
+	return nil
+}
+
+// GetArticleTranslation 查询文章指定内容和语言的译文，没有译文时返回 nil
+func (a *articleRepository) GetArticleTranslation(articleID uint, key string, language string) (*entity.ArticleTranslation, error) {
+	var translation entity.ArticleTranslation
+	result := a.db.Where("article_id = ? AND `key` = ? AND language = ?", articleID, key, language).Limit(1).Find(&translation)
+	if result.Error != nil {
+		return nil, fmt.Errorf("(a *articleRepository) GetArticleTranslation -> %v", result.Error)
+	} else if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &translation, nil
+}
+
+// replaceArticleTags 在事务中删除查询条件匹配的标签关联后保存新的关联，标签关联直接物理删除
+func replaceArticleTags(tx *gorm.DB, tags []entity.ArticleTag, query string, args ...interface{}) error {
+	if err := tx.Unscoped().Where(query, args...).Delete(&entity.ArticleTag{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tx.Create(&tags).Error
+}
+
 // DelArticleInfo 删除文章信息
 func (a *articleRepository) DelArticleInfo(articleID uint) error {
 
@@ -113,6 +362,24 @@ func (a *articleRepository) DelArticleInfo(articleID uint) error {
 		return fmt.Errorf("(a *articleRepository) DelArticleInfo -> %v", result.Error)
 	}
 
+	// 文章的历史版本、人工修改的内容、标签和译文一并删除
+	if err := tx.Where("article_id = ?", articleID).Delete(&entity.ArticleVersion{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("(a *articleRepository) DelArticleInfo -> %v", err)
+	}
+	if err := tx.Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleOverride{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("(a *articleRepository) DelArticleInfo -> %v", err)
+	}
+	if err := tx.Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleTag{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("(a *articleRepository) DelArticleInfo -> %v", err)
+	}
+	if err := tx.Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleTranslation{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("(a *articleRepository) DelArticleInfo -> %v", err)
+	}
+
 	//else if result.RowsAffected == 0 {
 	//	tx.Rollback()
 	//	return fmt.Errorf("数据库中没有该文章的信息")
@@ -79,3 +79,11 @@ func (r *mysqlCodeRepository) SaveHistory(history entity.History) (err error) {
 	}
 	return
 }
+
+// ListCodes 按主键顺序分页读取代码解释
+func (r *mysqlCodeRepository) ListCodes(afterID uint, limit int) (codes []entity.Code, err error) {
+	if err = r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&codes).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Find() err: %v", err)
+	}
+	return codes, nil
+}
@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/persistence"
+)
+
+type mysqlEmbeddingRepository struct {
+	db *gorm.DB
+}
+
+// NewMySQLEmbeddingRepository 返回基于 MySQL 的向量仓储实现
+func NewMySQLEmbeddingRepository(db *gorm.DB) persistence.EmbeddingRepository {
+	return &mysqlEmbeddingRepository{db: db}
+}
+
+// SaveEmbedding 保存向量，(类型, ID) 已存在时覆盖
+func (r *mysqlEmbeddingRepository) SaveEmbedding(embedding *entity.Embedding) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "doc_type"}, {Name: "doc_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content", "tags", "vector", "dim", "llm_model", "updated_at"}),
+	}).Create(embedding).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Create() err: %v", err)
+	}
+	return nil
+}
+
+// DelEmbedding 删除向量，记录不存在时返回 false
+// 使用硬删除，软删除的记录会占用唯一索引，导致无法再次保存
+func (r *mysqlEmbeddingRepository) DelEmbedding(docType, docID string) (bool, error) {
+	result := r.db.Unscoped().Where("doc_type = ? AND doc_id = ?", docType, docID).Delete(&entity.Embedding{})
+	if result.Error != nil {
+		return false, fmt.Errorf("r.db.Delete() err: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetEmbeddings 按 (类型, ID) 批量查询向量
+func (r *mysqlEmbeddingRepository) GetEmbeddings(docs [][2]string) (embeddings []entity.Embedding, err error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	pairs := make([][]interface{}, len(docs))
+	for i, doc := range docs {
+		pairs[i] = []interface{}{doc[0], doc[1]}
+	}
+	if err = r.db.Where("(doc_type, doc_id) IN ?", pairs).Find(&embeddings).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Find() err: %v", err)
+	}
+	return embeddings, nil
+}
+
+// ListEmbeddings 按主键顺序分页读取向量，用于启动时加载内存索引
+func (r *mysqlEmbeddingRepository) ListEmbeddings(afterID uint, limit int) (embeddings []entity.Embedding, err error) {
+	if err = r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&embeddings).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Find() err: %v", err)
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,78 @@
+package impl
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/persistence"
+)
+
+type questionRepository struct {
+	db *gorm.DB
+}
+
+func NewQuestionRepository(db *gorm.DB) persistence.QuestionRepositoryInterface {
+	return &questionRepository{
+		db: db,
+	}
+}
+
+// VerifyHash 验证hash值，没有记录时返回 persistence.ErrQuestionNotFound
+func (q *questionRepository) VerifyHash(key string) (*entity.Question, error) {
+	var question entity.Question
+	result := q.db.Where("`key` = ?", key).Limit(1).Find(&question)
+	if result.Error != nil {
+		return nil, fmt.Errorf("(q *questionRepository) VerifyHash -> %v", result.Error)
+	} else if result.RowsAffected == 0 {
+		return nil, persistence.ErrQuestionNotFound
+	}
+	return &question, nil
+}
+
+// SaveQuestionInfo 保存问题的信息，hash 值已存在时只更新 columns 中的列
+// 标题和答案分别生成，各自只更新自己的列，互不覆盖
+func (q *questionRepository) SaveQuestionInfo(question *entity.Question, columns ...string) error {
+	err := q.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns(append(columns, "updated_at")),
+	}).Create(question).Error
+	if err != nil {
+		return fmt.Errorf("(q *questionRepository) SaveQuestionInfo -> %v", err)
+	}
+	return nil
+}
+
+// SaveQuestionID 保存问题的ID
+func (q *questionRepository) SaveQuestionID(key string, questionID uint) error {
+	result := q.db.Model(&entity.Question{}).Where("`key` = ?", key).Update("question_id", questionID)
+	if result.Error != nil {
+		return fmt.Errorf("(q *questionRepository) SaveQuestionID -> %v", result.Error)
+	} else if result.RowsAffected <= 0 {
+		return fmt.Errorf("保存问题的ID失败")
+	}
+	return nil
+}
+
+// GetByQuestionIDs 根据问题ID批量查询，问题内容修改过时同一ID有多条记录，按更新时间从新到旧排序
+func (q *questionRepository) GetByQuestionIDs(questionIDs []uint) ([]entity.Question, error) {
+	var questions []entity.Question
+	if len(questionIDs) == 0 {
+		return questions, nil
+	}
+	err := q.db.Where("question_id IN ?", questionIDs).Order("updated_at DESC").Find(&questions).Error
+	if err != nil {
+		return nil, fmt.Errorf("(q *questionRepository) GetByQuestionIDs -> %v", err)
+	}
+	return questions, nil
+}
+
+// IncrVisitCount 问题被访问的次数加一
+func (q *questionRepository) IncrVisitCount(key string) error {
+	err := q.db.Model(&entity.Question{}).Where("`key` = ?", key).
+		UpdateColumn("visit_count", gorm.Expr("visit_count + ?", 1)).Error
+	if err != nil {
+		return fmt.Errorf("(q *questionRepository) IncrVisitCount -> %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package impl
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/persistence"
+	"time"
+)
+
+type mysqlTagRepository struct {
+	db *gorm.DB
+}
+
+// NewMySQLTagRepository 创建标签仓库
+func NewMySQLTagRepository(db *gorm.DB) persistence.TagRepository {
+	return &mysqlTagRepository{db: db}
+}
+
+// CreateTag 保存新标签
+func (r *mysqlTagRepository) CreateTag(tag *entity.Tag) error {
+	if err := r.db.Create(tag).Error; err != nil {
+		return fmt.Errorf("(r *mysqlTagRepository) CreateTag -> %v", err)
+	}
+	return nil
+}
+
+// UpdateTag 更新标签的名称、别名、父标签、描述和状态
+func (r *mysqlTagRepository) UpdateTag(tag *entity.Tag) error {
+	err := r.db.Model(tag).Select("name", "aliases", "parent_id", "description", "status").Updates(tag).Error
+	if err != nil {
+		return fmt.Errorf("(r *mysqlTagRepository) UpdateTag -> %v", err)
+	}
+	return nil
+}
+
+// DelTag 删除标签，子标签改为挂在被删除标签的父标签下
+// 标签名称有唯一索引，直接物理删除以便之后重新创建同名标签
+func (r *mysqlTagRepository) DelTag(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tag entity.Tag
+		result := tx.Where("id = ?", id).Limit(1).Find(&tag)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return persistence.ErrTagNotFound
+		}
+		if err := tx.Model(&entity.Tag{}).Where("parent_id = ?", id).Update("parent_id", tag.ParentID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&tag).Error
+	})
+	if err != nil {
+		return fmt.Errorf("(r *mysqlTagRepository) DelTag -> %w", err)
+	}
+	return nil
+}
+
+// GetTag 根据ID查询标签，没有记录时返回 persistence.ErrTagNotFound
+func (r *mysqlTagRepository) GetTag(id uint) (*entity.Tag, error) {
+	return r.getTag("id = ?", id)
+}
+
+// GetTagByName 根据名称查询标签，没有记录时返回 persistence.ErrTagNotFound
+func (r *mysqlTagRepository) GetTagByName(name string) (*entity.Tag, error) {
+	return r.getTag("name = ?", name)
+}
+
+func (r *mysqlTagRepository) getTag(query string, arg any) (*entity.Tag, error) {
+	var tag entity.Tag
+	result := r.db.Where(query, arg).Limit(1).Find(&tag)
+	if result.Error != nil {
+		return nil, fmt.Errorf("(r *mysqlTagRepository) getTag -> %v", result.Error)
+	} else if result.RowsAffected == 0 {
+		return nil, persistence.ErrTagNotFound
+	}
+	return &tag, nil
+}
+
+// ListTags 查询指定状态的标签，status 为空时查询全部，建议标签按出现次数从多到少排序
+func (r *mysqlTagRepository) ListTags(status string) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	db := r.db.Order("suggest_count DESC, id")
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if err := db.Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("(r *mysqlTagRepository) ListTags -> %v", err)
+	}
+	return tags, nil
+}
+
+// AddSuggestion 记录一个建议标签，已存在时出现次数加一
+func (r *mysqlTagRepository) AddSuggestion(name string) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"suggest_count": gorm.Expr("suggest_count + 1"), "updated_at": time.Now()}),
+	}).Create(&entity.Tag{Name: name, Status: dto.TagStatusSuggested, SuggestCount: 1}).Error
+	if err != nil {
+		return fmt.Errorf("(r *mysqlTagRepository) AddSuggestion -> %v", err)
+	}
+	return nil
+}
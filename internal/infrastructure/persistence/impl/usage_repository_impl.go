@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+	"siwuai/internal/infrastructure/persistence"
+	"strings"
+)
+
+// usageGroupColumns 允许的分组维度与对应的列
+var usageGroupColumns = map[string]string{
+	"user":    "user_id",
+	"ai_code": "ai_code",
+	"model":   "llm_model",
+	"day":     "day",
+}
+
+type mysqlUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewMySQLUsageRepository 返回基于 MySQL 的用量仓储实现
+func NewMySQLUsageRepository(db *gorm.DB) persistence.UsageRepository {
+	return &mysqlUsageRepository{db: db}
+}
+
+// AddUsage 将用量累加到 (用户, 功能, 模型, 日期) 对应的记录上，记录不存在时创建
+func (r *mysqlUsageRepository) AddUsage(usage *entity.Usage) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "ai_code"}, {Name: "llm_model"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"calls":             gorm.Expr("calls + ?", usage.Calls),
+			"prompt_tokens":     gorm.Expr("prompt_tokens + ?", usage.PromptTokens),
+			"completion_tokens": gorm.Expr("completion_tokens + ?", usage.CompletionTokens),
+			"updated_at":        gorm.Expr("NOW()"),
+		}),
+	}).Create(usage).Error
+	if err != nil {
+		return fmt.Errorf("r.db.Create() err: %v", err)
+	}
+	return nil
+}
+
+// ListUsage 按查询条件过滤并按 GroupBy 分组汇总用量
+func (r *mysqlUsageRepository) ListUsage(query *dto.UsageQuery) (stats []dto.UsageStat, err error) {
+	columns := make([]string, 0, len(query.GroupBy))
+	for _, group := range query.GroupBy {
+		column, ok := usageGroupColumns[group]
+		if !ok {
+			return nil, fmt.Errorf("不支持的分组维度: %s", group)
+		}
+		columns = append(columns, column)
+	}
+
+	db := r.db.Model(&entity.Usage{})
+	if query.UserID != nil {
+		db = db.Where("user_id = ?", *query.UserID)
+	}
+	if query.AICode != "" {
+		db = db.Where("ai_code = ?", query.AICode)
+	}
+	if query.Model != "" {
+		db = db.Where("llm_model = ?", query.Model)
+	}
+	if query.StartDay != "" {
+		db = db.Where("day >= ?", query.StartDay)
+	}
+	if query.EndDay != "" {
+		db = db.Where("day <= ?", query.EndDay)
+	}
+
+	selects := append(append([]string{}, columns...),
+		"COALESCE(SUM(calls), 0) AS calls",
+		"COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens",
+		"COALESCE(SUM(completion_tokens), 0) AS completion_tokens",
+	)
+	db = db.Select(strings.Join(selects, ", "))
+	if len(columns) > 0 {
+		db = db.Group(strings.Join(columns, ", ")).Order(strings.Join(columns, ", "))
+	}
+
+	if err = db.Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("r.db.Scan() err: %v", err)
+	}
+	return stats, nil
+}
@@ -35,6 +35,14 @@ func NewMySQLDB(cfg config.Config) (db *gorm.DB, err error) {
 		&entity.Code{},
 		&entity.History{},
 		&entity.Article{},
+		&entity.Usage{},
+		&entity.Question{},
+		&entity.Embedding{},
+		&entity.ArticleVersion{},
+		&entity.ArticleOverride{},
+		&entity.ArticleTag{},
+		&entity.ArticleTranslation{},
+		&entity.Tag{},
 	)
 	if err != nil {
 		err = fmt.Errorf("db.AutoMigrate() err: %v", err)
@@ -0,0 +1,18 @@
+package persistence
+
+import (
+	"errors"
+	"siwuai/internal/domain/model/entity"
+)
+
+// ErrQuestionNotFound 数据库中没有该问题的记录
+var ErrQuestionNotFound = errors.New("数据库中没有该 hash值")
+
+// QuestionRepositoryInterface 定义了问题的访问接口
+type QuestionRepositoryInterface interface {
+	VerifyHash(key string) (*entity.Question, error)
+	SaveQuestionInfo(question *entity.Question, columns ...string) error
+	SaveQuestionID(key string, questionID uint) error
+	IncrVisitCount(key string) error
+	GetByQuestionIDs(questionIDs []uint) ([]entity.Question, error)
+}
@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"errors"
+	"siwuai/internal/domain/model/entity"
+)
+
+// ErrTagNotFound 数据库中没有该标签
+var ErrTagNotFound = errors.New("数据库中没有该标签")
+
+// TagRepository 定义了标签体系的访问接口
+type TagRepository interface {
+	CreateTag(tag *entity.Tag) error
+	UpdateTag(tag *entity.Tag) error
+	DelTag(id uint) error
+	GetTag(id uint) (*entity.Tag, error)
+	GetTagByName(name string) (*entity.Tag, error)
+	ListTags(status string) ([]entity.Tag, error)
+	AddSuggestion(name string) error
+}
@@ -0,0 +1,12 @@
+package persistence
+
+import (
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/model/entity"
+)
+
+// UsageRepository 定义了大模型用量的访问接口
+type UsageRepository interface {
+	AddUsage(usage *entity.Usage) error
+	ListUsage(query *dto.UsageQuery) ([]dto.UsageStat, error)
+}
@@ -0,0 +1,274 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultVersion 未在配置文件中指定版本时使用的模板版本
+const DefaultVersion = "v1"
+
+// DefaultLanguage 未指定语言时模型输出的语言，未声明 language 的模板都是该语言
+const DefaultLanguage = "zh"
+
+// ErrLanguageNotSupported 没有该语言的提示词模板
+var ErrLanguageNotSupported = errors.New("不支持该语言")
+
+// Registry 提示词模板注册表
+type Registry interface {
+	// Get 根据 AICode 和版本获取模板
+	Get(code constant.AICode, version string) (*Template, error)
+	// Select 根据 AICode 选择模板，配置了 A/B 实验时按 key 的哈希值分流，相同的 key 总是得到相同的版本
+	Select(code constant.AICode, key string) (*Template, error)
+	// Localize 返回模板在指定语言下的版本，选中的版本没有该语言时使用默认版本的该语言模板
+	Localize(tpl *Template, language string) (*Template, error)
+	// Languages 返回 AICode 的默认版本支持的全部语言，第一个为 DefaultLanguage
+	Languages(code constant.AICode) []string
+}
+
+type registry struct {
+	templates   map[constant.AICode]map[string]*Template
+	localized   map[constant.AICode]map[string]map[string]*Template // AICode -> 版本 -> 语言 -> 模板，不含 DefaultLanguage
+	versions    map[string]string
+	experiments map[string]map[string]int
+}
+
+// NormalizeLanguage 将语言规范化为小写的主语言标签，例如 en-US 为 en，为空时为 DefaultLanguage
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if language == "" {
+		return DefaultLanguage
+	}
+	return language
+}
+
+// NewRegistry 从 cfg.Prompt.Dir 加载全部模板并校验，required 中的每个 AICode 都必须有可用的默认版本
+func NewRegistry(cfg config.Config, required ...constant.AICode) (Registry, error) {
+	r := &registry{
+		templates:   make(map[constant.AICode]map[string]*Template),
+		localized:   make(map[constant.AICode]map[string]map[string]*Template),
+		versions:    cfg.Prompt.Versions,
+		experiments: cfg.Prompt.Experiments,
+	}
+
+	dir := cfg.Prompt.Dir
+	if dir == "" {
+		dir = "prompts"
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return nil
+		}
+		return r.load(path)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("加载提示词模板失败: %v", err)
+	}
+
+	if err = r.validate(required); err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("提示词模板加载完成", zap.String("dir", dir), zap.Int("count", r.count()))
+	return r, nil
+}
+
+// load 加载单个模板文件
+// 直接使用 yaml 解析而不是 viper，viper 会将键名转为小写，schema 中的字段名需要保持原样
+func (r *registry) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取模板 %s 失败: %v", path, err)
+	}
+
+	var t Template
+	if err = yaml.Unmarshal(data, &t); err != nil {
+		return fmt.Errorf("解析模板 %s 失败: %v", path, err)
+	}
+	if err := t.validate(); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	t.Language = NormalizeLanguage(t.Language)
+	if t.Language != DefaultLanguage {
+		if r.localized[t.Code] == nil {
+			r.localized[t.Code] = make(map[string]map[string]*Template)
+		}
+		if r.localized[t.Code][t.Version] == nil {
+			r.localized[t.Code][t.Version] = make(map[string]*Template)
+		}
+		if _, ok := r.localized[t.Code][t.Version][t.Language]; ok {
+			return fmt.Errorf("%s: 模板 %s/%s/%s 重复定义", path, t.Code, t.Version, t.Language)
+		}
+		r.localized[t.Code][t.Version][t.Language] = &t
+		return nil
+	}
+
+	if r.templates[t.Code] == nil {
+		r.templates[t.Code] = make(map[string]*Template)
+	}
+	if _, ok := r.templates[t.Code][t.Version]; ok {
+		return fmt.Errorf("%s: 模板 %s/%s 重复定义", path, t.Code, t.Version)
+	}
+	r.templates[t.Code][t.Version] = &t
+	return nil
+}
+
+// validate 校验配置文件中引用的版本都存在，其他语言的模板都有对应的默认语言模板且声明的变量相同
+func (r *registry) validate(required []constant.AICode) error {
+	for code, versions := range r.localized {
+		for version, languages := range versions {
+			base, err := r.Get(code, version)
+			if err != nil {
+				return err
+			}
+			for language, t := range languages {
+				if !sameVariables(base.Variables, t.Variables) {
+					return fmt.Errorf("模板 %s/%s/%s 声明的变量与默认语言的模板不一致", code, version, language)
+				}
+			}
+		}
+	}
+
+	for _, code := range required {
+		if _, err := r.Get(code, r.defaultVersion(code)); err != nil {
+			return err
+		}
+	}
+
+	for code, version := range r.versions {
+		if _, err := r.Get(constant.AICode(code), version); err != nil {
+			return err
+		}
+	}
+
+	for code, weights := range r.experiments {
+		for version, weight := range weights {
+			if weight < 0 {
+				return fmt.Errorf("A/B 实验 %s 中版本 %s 的权重不能为负数", code, version)
+			}
+			if _, err := r.Get(constant.AICode(code), version); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Get 根据 AICode 和版本获取模板
+func (r *registry) Get(code constant.AICode, version string) (*Template, error) {
+	t, ok := r.templates[code][version]
+	if !ok {
+		return nil, fmt.Errorf("提示词模板 %s/%s 不存在", code, version)
+	}
+	return t, nil
+}
+
+// Select 根据 AICode 选择模板
+func (r *registry) Select(code constant.AICode, key string) (*Template, error) {
+	weights := r.experiments[string(code)]
+
+	// 版本排序，保证相同的 key 每次都落在同一个版本上
+	versions := make([]string, 0, len(weights))
+	total := 0
+	for version, weight := range weights {
+		versions = append(versions, version)
+		total += weight
+	}
+	if total == 0 {
+		return r.Get(code, r.defaultVersion(code))
+	}
+	sort.Strings(versions)
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	n := int(h.Sum32() % uint32(total))
+	for _, version := range versions {
+		if n < weights[version] {
+			return r.Get(code, version)
+		}
+		n -= weights[version]
+	}
+	return r.Get(code, r.defaultVersion(code))
+}
+
+// Localize 返回模板在指定语言下的版本
+func (r *registry) Localize(tpl *Template, language string) (*Template, error) {
+	language = NormalizeLanguage(language)
+	if language == DefaultLanguage {
+		return tpl, nil
+	}
+	if t, ok := r.localized[tpl.Code][tpl.Version][language]; ok {
+		return t, nil
+	}
+	if t, ok := r.localized[tpl.Code][r.defaultVersion(tpl.Code)][language]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("提示词模板 %s 没有 %s 语言的版本: %w", tpl.Code, language, ErrLanguageNotSupported)
+}
+
+// Languages 返回 AICode 的默认版本支持的全部语言
+func (r *registry) Languages(code constant.AICode) []string {
+	languages := []string{DefaultLanguage}
+	for language := range r.localized[code][r.defaultVersion(code)] {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages[1:])
+	return languages
+}
+
+// defaultVersion 配置文件中指定的默认版本
+func (r *registry) defaultVersion(code constant.AICode) string {
+	if version, ok := r.versions[string(code)]; ok && version != "" {
+		return version
+	}
+	return DefaultVersion
+}
+
+func (r *registry) count() int {
+	count := 0
+	for _, versions := range r.templates {
+		count += len(versions)
+	}
+	for _, versions := range r.localized {
+		for _, languages := range versions {
+			count += len(languages)
+		}
+	}
+	return count
+}
+
+// sameVariables 两个模板声明的变量是否相同，不考虑顺序
+func sameVariables(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	declared := make(map[string]bool, len(a))
+	for _, v := range a {
+		declared[v] = true
+	}
+	for _, v := range b {
+		if !declared[v] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateOutput 清理模型输出中多余的代码块标记，并校验其是否为符合 schema 的 JSON，返回清理后的 JSON
+func (t *Template) ValidateOutput(output string) (string, error) {
+	cleaned := CleanJSON(output)
+
+	var value any
+	if err := json.Unmarshal([]byte(cleaned), &value); err != nil {
+		return "", fmt.Errorf("不是有效的 JSON: %v", err)
+	}
+	if err := validateValue(t.Schema, value, "$"); err != nil {
+		return "", err
+	}
+	return cleaned, nil
+}
+
+// CleanJSON 移除模型输出中包裹 JSON 的 ``` 代码块标记和首尾空白
+func CleanJSON(output string) string {
+	output = strings.TrimSpace(output)
+	if strings.HasPrefix(output, "```") {
+		output = strings.TrimPrefix(output, "```json")
+		output = strings.TrimPrefix(output, "```")
+		output = strings.TrimSuffix(output, "```")
+	}
+	output = strings.Trim(output, "`")
+	return strings.TrimSpace(output)
+}
+
+// validateValue 按 JSON Schema 的常用子集（type、required、properties、items、长度、pattern 和取值范围）校验 value
+func validateValue(schema map[string]any, value any, path string) error {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s 应为 object", path)
+		}
+		for _, name := range toStrings(schema["required"]) {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s 缺少必填字段 %s", path, name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, sub := range properties {
+			field, ok := obj[name]
+			if !ok {
+				continue
+			}
+			subSchema, _ := sub.(map[string]any)
+			if err := validateValue(subSchema, field, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s 应为 array", path)
+		}
+		if n, ok := toFloat(schema["minItems"]); ok && float64(len(arr)) < n {
+			return fmt.Errorf("%s 至少需要 %v 项", path, n)
+		}
+		if n, ok := toFloat(schema["maxItems"]); ok && float64(len(arr)) > n {
+			return fmt.Errorf("%s 最多允许 %v 项", path, n)
+		}
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range arr {
+			if err := validateValue(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s 应为 string", path)
+		}
+		length := float64(utf8.RuneCountInString(str))
+		if n, ok := toFloat(schema["minLength"]); ok && length < n {
+			return fmt.Errorf("%s 长度不能少于 %v 个字符", path, n)
+		}
+		if n, ok := toFloat(schema["maxLength"]); ok && length > n {
+			return fmt.Errorf("%s 长度不能超过 %v 个字符", path, n)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%s 的 pattern 无效: %v", path, err)
+			}
+			if !re.MatchString(str) {
+				return fmt.Errorf("%s 不符合格式 %s", path, pattern)
+			}
+		}
+	case "number", "integer":
+		num, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s 应为 %s", path, schema["type"])
+		}
+		if schema["type"] == "integer" && num != float64(int64(num)) {
+			return fmt.Errorf("%s 应为 integer", path)
+		}
+		if n, ok := toFloat(schema["minimum"]); ok && num < n {
+			return fmt.Errorf("%s 不能小于 %v", path, n)
+		}
+		if n, ok := toFloat(schema["maximum"]); ok && num > n {
+			return fmt.Errorf("%s 不能大于 %v", path, n)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s 应为 boolean", path)
+		}
+	}
+	return nil
+}
+
+// toFloat 将 yaml 解析出的数字统一转换为 float64
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// toStrings 将 yaml 解析出的列表转换为字符串切片
+func toStrings(v any) []string {
+	list, _ := v.([]any)
+	res := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// SchemaString 将 schema 序列化为 JSON 字符串，便于写入提示词
+func (t *Template) SchemaString() string {
+	data, err := json.MarshalIndent(t.Schema, "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
@@ -0,0 +1,97 @@
+package prompt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/constant"
+)
+
+// loadPrompts 加载仓库中真实的 prompts 目录
+func loadPrompts(t *testing.T) Registry {
+	t.Helper()
+	var cfg config.Config
+	cfg.Prompt.Dir = "../../../prompts"
+	r, err := NewRegistry(cfg, constant.ArticleSEOCode)
+	if err != nil {
+		t.Fatalf("NewRegistry() err: %v", err)
+	}
+	return r
+}
+
+func seoOutput(t *testing.T, override map[string]string) string {
+	t.Helper()
+	out := map[string]string{
+		"seoTitle":        "Go 并发入门",
+		"metaDescription": "介绍 goroutine 和 channel 的基本用法",
+		"slug":            "go-concurrency-intro",
+		"ogTitle":         "一文读懂 Go 并发",
+		"ogDescription":   "从 goroutine 到 channel",
+	}
+	for k, v := range override {
+		out[k] = v
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestArticleSEOSchema(t *testing.T) {
+	r := loadPrompts(t)
+
+	tests := []struct {
+		name     string
+		override map[string]string
+		wantErr  string
+	}{
+		{name: "合法输出"},
+		{name: "seoTitle 过长", override: map[string]string{"seoTitle": strings.Repeat("标", 61)}, wantErr: "$.seoTitle"},
+		{name: "metaDescription 过长", override: map[string]string{"metaDescription": strings.Repeat("a", 161)}, wantErr: "$.metaDescription"},
+		{name: "ogTitle 过长", override: map[string]string{"ogTitle": strings.Repeat("a", 91)}, wantErr: "$.ogTitle"},
+		{name: "ogDescription 过长", override: map[string]string{"ogDescription": strings.Repeat("a", 201)}, wantErr: "$.ogDescription"},
+		{name: "slug 格式错误", override: map[string]string{"slug": "Go_Concurrency"}, wantErr: "$.slug"},
+	}
+
+	for _, language := range r.Languages(constant.ArticleSEOCode) {
+		base, err := r.Get(constant.ArticleSEOCode, DefaultVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tpl, err := r.Localize(base, language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(language+"/"+tt.name, func(t *testing.T) {
+				_, err := tpl.ValidateOutput(seoOutput(t, tt.override))
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Fatalf("ValidateOutput() err: %v", err)
+					}
+					return
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ValidateOutput() err = %v, want error on %s", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+// TestSchemaStringKeepsCase 修复提示词中的 schema 与 required 使用相同的字段名
+func TestSchemaStringKeepsCase(t *testing.T) {
+	tpl, err := loadPrompts(t).Get(constant.ArticleSEOCode, DefaultVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := tpl.SchemaString()
+	for _, name := range []string{`"seoTitle"`, `"metaDescription"`, `"ogTitle"`, `"ogDescription"`, `"maxLength"`} {
+		if !strings.Contains(schema, name) {
+			t.Errorf("SchemaString() 缺少 %s:\n%s", name, schema)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package prompt
+
+import (
+	"fmt"
+	"siwuai/internal/infrastructure/constant"
+	"strings"
+
+	"github.com/tmc/langchaingo/prompts"
+)
+
+// Template 一个版本的提示词模板，对应 prompts 目录下的一个 yaml 文件
+type Template struct {
+	Code      constant.AICode `yaml:"code"`      // 对应的 AICode
+	Version   string          `yaml:"version"`   // 模板版本，例如 v1
+	Language  string          `yaml:"language"`  // 要求模型输出的语言，为空表示 DefaultLanguage
+	System    string          `yaml:"system"`    // 系统提示词
+	Human     string          `yaml:"human"`     // 用户提示词
+	Variables []string        `yaml:"variables"` // 模板中声明的变量
+	Schema    map[string]any  `yaml:"schema"`    // 期望的输出格式（JSON Schema），为空表示自由文本
+}
+
+// Format 将输入渲染为最终的提示词
+func (t *Template) Format(input map[string]any) (string, error) {
+	promptTemplate := prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
+		prompts.NewSystemMessagePromptTemplate(t.System, t.Variables),
+		prompts.NewHumanMessagePromptTemplate(t.Human, t.Variables),
+	})
+
+	promptValue, err := promptTemplate.Format(input)
+	if err != nil {
+		return "", fmt.Errorf("promptTemplate.Format() err: %v", err)
+	}
+	return promptValue, nil
+}
+
+// validate 校验模板：必填字段、模板语法、声明的变量与模板中使用的变量一致
+func (t *Template) validate() error {
+	if t.Code == "" || t.Version == "" {
+		return fmt.Errorf("模板缺少 code 或 version")
+	}
+	if t.Human == "" {
+		return fmt.Errorf("模板 %s/%s 缺少 human 提示词", t.Code, t.Version)
+	}
+
+	// 每个变量使用不同的占位值渲染，模板中使用了未声明的变量时渲染会报错
+	input := make(map[string]any, len(t.Variables))
+	for _, v := range t.Variables {
+		input[v] = fmt.Sprintf("<%s:%s>", t.Code, v)
+	}
+	promptValue, err := t.Format(input)
+	if err != nil {
+		return fmt.Errorf("模板 %s/%s 渲染失败: %v", t.Code, t.Version, err)
+	}
+
+	// 声明了但未使用的变量
+	for _, v := range t.Variables {
+		if !strings.Contains(promptValue, input[v].(string)) {
+			return fmt.Errorf("模板 %s/%s 声明的变量 %s 未被使用", t.Code, t.Version, v)
+		}
+	}
+
+	if len(t.Schema) > 0 {
+		if _, ok := t.Schema["type"]; !ok {
+			return fmt.Errorf("模板 %s/%s 的 schema 缺少 type", t.Code, t.Version)
+		}
+	}
+	return nil
+}
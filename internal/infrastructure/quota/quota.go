@@ -0,0 +1,202 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"siwuai/internal/infrastructure/config"
+	"siwuai/internal/infrastructure/redis_utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// remainingMetadata 剩余配额通过 trailer 返回，完整的键为 x-quota-remaining-{user|client}-{daily|monthly}
+const remainingMetadata = "x-quota-remaining-"
+
+// acquireScript 检查所有计数是否都未达到上限，都未达到时才全部加一
+// 返回值第一个元素为达到上限的计数下标(从 1 开始)，0 表示占用成功，其后为各计数的当前值
+const acquireScript = `
+local n = #KEYS
+local counts = {}
+local exceeded = 0
+for i = 1, n do
+  counts[i] = tonumber(redis.call('GET', KEYS[i]) or '0')
+  if exceeded == 0 and counts[i] >= tonumber(ARGV[i]) then
+    exceeded = i
+  end
+end
+if exceeded == 0 then
+  for i = 1, n do
+    counts[i] = redis.call('INCR', KEYS[i])
+    if counts[i] == 1 then
+      redis.call('EXPIRE', KEYS[i], ARGV[n + i])
+    end
+  end
+end
+table.insert(counts, 1, exceeded)
+return counts
+`
+
+// Limiter 按用户和调用方限制调用大模型生成内容的次数
+type Limiter interface {
+	// Acquire 在缓存未命中、即将调用大模型生成内容前检查并占用一次配额，超出时返回 codes.ResourceExhausted 错误
+	// 用户和调用方取自 WithIdentity 记录的 token 中的身份，同一请求只占用一次；没有身份的 ctx（后台任务）不限制
+	Acquire(ctx context.Context) error
+}
+
+// counter 一项配额计数
+type counter struct {
+	name  string // 用于 trailer，如 user-daily
+	key   string
+	limit int64
+	ttl   time.Duration
+}
+
+type redisLimiter struct {
+	rdb     *redis_utils.RedisClient
+	user    config.QuotaLimit
+	client  config.QuotaLimit
+	users   map[string]config.QuotaLimit
+	clients map[string]config.QuotaLimit
+}
+
+// NewRedisLimiter 创建基于 Redis 计数的配额限制器，按自然日和自然月计数
+func NewRedisLimiter(rdb *redis_utils.RedisClient, cfg config.Config) Limiter {
+	clients := make(map[string]config.QuotaLimit, len(cfg.Quota.Clients))
+	for client, limit := range cfg.Quota.Clients {
+		clients[strings.ToLower(client)] = limit
+	}
+	return &redisLimiter{
+		rdb:     rdb,
+		user:    cfg.Quota.User,
+		client:  cfg.Quota.Client,
+		users:   cfg.Quota.Users,
+		clients: clients,
+	}
+}
+
+// Acquire 检查并占用一次配额，并通过 trailer 返回剩余配额
+func (l *redisLimiter) Acquire(ctx context.Context) error {
+	r, ok := ctx.Value(identityKey{}).(*request)
+	if !ok {
+		return nil
+	}
+	r.once.Do(func() {
+		r.err = l.acquire(ctx, r.userID, r.client)
+	})
+	return r.err
+}
+
+// acquire token 中没有用户ID时只限制调用方配额，没有调用方时只限制用户配额
+func (l *redisLimiter) acquire(ctx context.Context, userID uint, client string) error {
+	now := time.Now()
+	var counters []counter
+
+	if userID != 0 {
+		id := strconv.FormatUint(uint64(userID), 10)
+		limit, ok := l.users[id]
+		if !ok {
+			limit = l.user
+		}
+		counters = appendCounters(counters, "user", id, limit, now)
+	}
+	if client != "" {
+		client = strings.ToLower(client)
+		limit, ok := l.clients[client]
+		if !ok {
+			limit = l.client
+		}
+		counters = appendCounters(counters, "client", client, limit, now)
+	}
+
+	if len(counters) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(counters))
+	args := make([]interface{}, 0, 2*len(counters))
+	for i, c := range counters {
+		keys[i] = c.key
+		args = append(args, c.limit)
+	}
+	for _, c := range counters {
+		args = append(args, int64(c.ttl/time.Second))
+	}
+
+	// Redis 不可用时放行，避免配额检查影响正常服务
+	val, err := l.rdb.Eval(acquireScript, keys, args...)
+	if err != nil {
+		zap.L().Error("配额检查失败，放行本次请求", zap.Error(err))
+		return nil
+	}
+	result, ok := val.([]interface{})
+	if !ok || len(result) != len(counters)+1 {
+		zap.L().Error("配额脚本返回值格式错误，放行本次请求", zap.Any("result", val))
+		return nil
+	}
+
+	md := metadata.MD{}
+	for i, c := range counters {
+		count, _ := result[i+1].(int64)
+		md.Set(remainingMetadata+c.name, strconv.FormatInt(max(c.limit-count, 0), 10))
+	}
+	// 非 gRPC 请求的 ctx 无法设置 trailer，忽略错误
+	_ = grpc.SetTrailer(ctx, md)
+
+	if exceeded, _ := result[0].(int64); exceeded > 0 {
+		c := counters[exceeded-1]
+		return status.Errorf(codes.ResourceExhausted, "%s 配额已用完(上限 %d)", c.name, c.limit)
+	}
+	return nil
+}
+
+// appendCounters 添加一个对象的日、月配额计数，不限制的不计数
+func appendCounters(counters []counter, scope, id string, limit config.QuotaLimit, now time.Time) []counter {
+	if limit.Daily > 0 {
+		counters = append(counters, counter{
+			name:  scope + "-daily",
+			key:   fmt.Sprintf("quota:%s:%s:d:%s", scope, id, now.Format("20060102")),
+			limit: limit.Daily,
+			ttl:   48 * time.Hour,
+		})
+	}
+	if limit.Monthly > 0 {
+		counters = append(counters, counter{
+			name:  scope + "-monthly",
+			key:   fmt.Sprintf("quota:%s:%s:m:%s", scope, id, now.Format("200601")),
+			limit: limit.Monthly,
+			ttl:   32 * 24 * time.Hour,
+		})
+	}
+	return counters
+}
+
+type identityKey struct{}
+
+// request 一次请求的配额占用情况，同一请求内多次生成(如摘要之后补充 SEO 信息)只占用一次
+type request struct {
+	userID uint
+	client string
+	once   sync.Once
+	err    error
+}
+
+// WithIdentity 在 ctx 中记录验证过的 token 中的用户和调用方，并开始一次新请求的配额计数
+func WithIdentity(ctx context.Context, userID uint, client string) context.Context {
+	return context.WithValue(ctx, identityKey{}, &request{userID: userID, client: client})
+}
+
+// ClientFromContext 取出 WithIdentity 记录的调用方
+func ClientFromContext(ctx context.Context) string {
+	if r, ok := ctx.Value(identityKey{}).(*request); ok {
+		return r.client
+	}
+	return ""
+}
@@ -73,6 +73,15 @@ func (r *RedisClient) Del(key string) error {
 	return nil
 }
 
+// Eval 执行 Lua 脚本，脚本内的多个命令原子执行
+func (r *RedisClient) Eval(script string, keys []string, args ...interface{}) (interface{}, error) {
+	val, err := r.client.Eval(r.ctx, script, keys, args...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("r.client.Eval() err: %v", err)
+	}
+	return val, nil
+}
+
 // TryLock 尝试获取分布式锁
 func (r *RedisClient) TryLock(key string, expiration time.Duration) (bool, error) {
 	ctx, cancel := context.WithTimeout(r.ctx, 5*time.Second) // 设置 5 秒超时
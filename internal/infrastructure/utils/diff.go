@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ChangeRatio 估算两版内容的改动比例(0~1)
+// 按行比较，忽略空行和首尾空白，只在一版中出现的行按字符数计入改动，调整行的顺序不算改动
+func ChangeRatio(oldContent, newContent string) float64 {
+	oldLines, oldTotal := countLines(oldContent)
+	newLines, newTotal := countLines(newContent)
+	if oldTotal+newTotal == 0 {
+		return 0
+	}
+
+	changed := 0
+	for line, n := range oldLines {
+		if diff := n - newLines[line]; diff > 0 {
+			changed += diff * utf8.RuneCountInString(line)
+		}
+	}
+	for line, n := range newLines {
+		if diff := n - oldLines[line]; diff > 0 {
+			changed += diff * utf8.RuneCountInString(line)
+		}
+	}
+	return float64(changed) / float64(oldTotal+newTotal)
+}
+
+// countLines 统计每个非空行出现的次数和所有非空行的字符数
+func countLines(content string) (map[string]int, int) {
+	lines := make(map[string]int)
+	total := 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines[line]++
+		total += utf8.RuneCountInString(line)
+	}
+	return lines, total
+}
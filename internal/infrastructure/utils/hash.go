@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"go.uber.org/zap"
 	"io"
+	"siwuai/internal/infrastructure/prompt"
 )
 
 // Hash 用于计算问题的hash值
@@ -13,12 +14,19 @@ func Hash(question string) (hashVal string, err error) {
 	hasher := sha256.New()
 	_, err = io.WriteString(hasher, question)
 	if err != nil {
-		err = fmt.Errorf("io.WriteString(hasher, question) err: %v", zap.Error(err))
+		err = fmt.Errorf("io.WriteString(hasher, question) err: %v", err)
 		return
 	}
 	// 使用 hex.EncodeToString 将二进制哈希结果转换为十六进制字符串
 	hashVal = hex.EncodeToString(hasher.Sum(nil))
-	//fmt.Printf("\n问题: %s\n", question)
-	fmt.Printf("Hash值: %s\n", hashVal)
+	zap.L().Debug("计算hash值", zap.String("hash", hashVal))
 	return
 }
+
+// HashLanguage 计算内容在指定输出语言下的 hash值，默认语言与 Hash 相同，其他语言的结果不会与默认语言冲突
+func HashLanguage(content string, language string) (string, error) {
+	if language == "" || language == prompt.DefaultLanguage {
+		return Hash(content)
+	}
+	return Hash(language + ":" + content)
+}
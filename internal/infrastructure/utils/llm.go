@@ -3,87 +3,68 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/prompt"
 	"strings"
 	"time"
 
-	"github.com/tmc/langchaingo/chains"
 	"go.uber.org/zap"
 
 	"siwuai/internal/domain/model/dto"
 
 	"github.com/tmc/langchaingo/llms"
-	"github.com/tmc/langchaingo/llms/openai"
-	"github.com/tmc/langchaingo/prompts"
 )
 
+// repairRetries 模型输出不符合 schema 时使用修复提示词重试的次数
+const repairRetries = 2
+
 // Generate 函数
-func Generate(flag constant.AICode, value interface{}, cfg config.Config) (answer map[string]any, err error) {
-	var promptTemplate prompts.ChatPromptTemplate
+func Generate(ctx context.Context, provider llm.LLMProvider, registry prompt.Registry, flag constant.AICode, value interface{}) (answer map[string]any, err error) {
 	var input map[string]any
-
-	// 初始化LLM
-	llm, err := openai.New(
-		openai.WithToken(cfg.Llm.ApiKey),
-		openai.WithModel(cfg.Llm.Model),
-		openai.WithBaseURL(cfg.Llm.BaseURL),
-	)
-	if err != nil {
-		zap.L().Error("openai.New() err:", zap.Error(err))
-		return
-	}
+	var key string      // 用于选择提示词模板版本
+	var language string // 用于选择提示词模板的语言
 
 	if flag == constant.ArticleAICode {
 		a := value.(*dto.ArticlePrompt)
-		// 定义提示词模板
-		promptTemplate = prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
-			prompts.NewSystemMessagePromptTemplate("你是一个专业的技术文章分析助手", []string{}),
-			//prompts.NewHumanMessagePromptTemplate("请根据以下文章内容提取摘要和总结，并根据给定的标签匹配文章的标签。回答中应仅仅只包含三部分: 摘要、总结、匹配的标签，其他多余部分都不要。文章内容如下：\n{{.article}}\n\n标签列表：{{.tags}}\n\n摘要：\n{{.abstract}}\n\n总结：\n{{.summary}}\n\n匹配的标签：\n{{.matchedTags}}", []string{"article", "abstract", "summary", "tags", "matchedTags"}),
-			prompts.NewHumanMessagePromptTemplate(
-				"请根据以下文章内容提取摘要和总结，并根据给定的标签匹配文章的标签。回答中应仅仅只包含三部分: 摘要、总结、匹配的标签，其他多余部分都不要。格式如下"+
-					"摘要: \n\n总结: \n\n匹配的标签:"+
-					"当文章内容无法识别或为空，未提供有效信息，或提供的文本为无意义字符，无法提取实质性内容或进行总结时，返回nil即可，其他的什么都不需要返回。格式如下"+
-					"nil"+
-					"文章内容如下：\n{{.article}}\n\n标签列表：{{.tags}}}", []string{"article", "tags"}),
-		})
-
 		// 格式化输入
+		key = a.Content
+		language = a.Language
 		input = map[string]any{
 			"article": a.Content,
 			"tags":    strings.Join(a.Tags, "、"), // 将标签列表转换为字符串
 		}
+	} else if flag == constant.ArticleChunkCode {
+		c := value.(*dto.ArticleChunkPrompt)
+		key = c.Chunk
+		language = c.Language
+		input = map[string]any{
+			"chunk":    c.Chunk,
+			"position": chunkPosition(c.Language, c.Index, c.Total),
+		}
+	} else if flag == constant.ArticleSEOCode {
+		s := value.(*dto.ArticleSEOPrompt)
+		key = s.Content
+		language = s.Language
+		input = map[string]any{
+			"article":  s.Content,
+			"abstract": s.Abstract,
+			"summary":  s.Summary,
+			"tags":     strings.Join(s.Tags, "、"),
+		}
 	} else if flag == constant.CodeAICode {
 
 	} else if flag == constant.QuestionAICode {
 		// 新增：处理问题AI生成标题和标签
 		q := value.(*dto.QuestionPrompt)
-		// 构造prompt和输入参数
-		promptTemplate = prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
-			prompts.NewSystemMessagePromptTemplate("你是一个专业的问题标题和标签生成助手。你必须严格按照指定的JSON格式返回结果，不要添加任何额外的文字说明。", []string{}),
-			prompts.NewHumanMessagePromptTemplate(
-				"请根据以下问题内容生成3个合适的标题，并为该问题匹配3个相关标签。标签应该是广泛的技术领域或技术框架，例如：'Django'、'React'、'Python'、'Maven'、'Unity'、'Vue.js'、'MySQL'、'Docker'、'Spring Boot'、'机器学习'等，而不是过于细节的具体功能或特性。\n"+
-					"你必须严格按照以下JSON格式返回结果，不要添加任何其他内容：\n"+
-					"{\n"+
-					"  \"titles\": [\"标题1\", \"标题2\", \"标题3\"],\n"+
-					"  \"tags\": [\"标签1\", \"标签2\", \"标签3\"]\n"+
-					"}\n"+
-					"注意：\n"+
-					"1. 必须返回3个标题和3个标签\n"+
-					"2. 标签必须是广泛的技术领域或框架名称，不要过于细节\n"+
-					"3. 不要添加任何额外的说明文字\n"+
-					"4. 不要使用反引号包裹JSON\n"+
-					"5. 确保返回的是有效的JSON格式\n"+
-					"问题内容如下：\n{{.content}}",
-				[]string{"content"}),
-		})
 		input = map[string]any{
 			"content": q.Content,
 		}
 		// 调用LLM
-		chain := chains.NewLLMChain(llm, promptTemplate)
-		result, err := chain.Call(context.Background(), input)
+		result, err := call(ctx, provider, registry, flag, q.Content, q.Language, input)
 		if err != nil {
 			return nil, err
 		}
@@ -136,125 +117,142 @@ func Generate(flag constant.AICode, value interface{}, cfg config.Config) (answe
 		answer = map[string]any{
 			"titles": aiResponse.Titles,
 			"tags":   aiResponse.Tags,
-			"key":    "ai-question-key",
+			"model":  result["model"],
 		}
 		return answer, nil
-	} else if flag == constant.QuestionAnswerCode {
-		// 处理问题AI生成答案
-		q := value.(*dto.QuestionPrompt)
-		// 构造prompt和输入参数
-		promptTemplate = prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
-			prompts.NewSystemMessagePromptTemplate("你是一个专业的问题回答助手。你必须严格按照指定的JSON格式返回结果，不要添加任何额外的文字说明。", []string{}),
-			prompts.NewHumanMessagePromptTemplate(
-				"请根据以下问题内容生成一个专业、准确、详细的回答。\n"+
-					"你必须严格按照以下JSON格式返回结果，不要添加任何其他内容：\n"+
-					"{\n"+
-					"  \"answer\": \"你的回答内容\"\n"+
-					"}\n"+
-					"注意：\n"+
-					"1. 回答要专业、准确、详细\n"+
-					"2. 不要添加任何额外的说明文字\n"+
-					"3. 不要使用反引号包裹JSON\n"+
-					"4. 确保返回的是有效的JSON格式\n"+
-					"问题内容如下：\n{{.content}}",
-				[]string{"content"}),
-		})
-		input = map[string]any{
-			"content": q.Content,
-		}
-		// 调用LLM
-		chain := chains.NewLLMChain(llm, promptTemplate)
-		result, err := chain.Call(context.Background(), input)
-		if err != nil {
-			return nil, err
-		}
+	} else {
+		return nil, fmt.Errorf("flag的值超出范围")
+	}
 
-		// 解析AI返回的JSON字符串
-		var aiResponse struct {
-			Answer string `json:"answer"`
-		}
+	// 调用LLM
+	result, err := call(ctx, provider, registry, flag, key, language, input)
+	if err != nil {
+		zap.L().Error("call(ctx, provider, registry, flag, key, language, input) : ", zap.Error(err))
+		return
+	}
 
-		if resultStr, ok := result["text"].(string); ok {
-			// 记录原始返回内容
-			zap.L().Info("AI原始返回内容",
-				zap.String("raw_response", resultStr))
+	return result, nil
+}
 
-			// 清理返回的字符串，移除可能的反引号和其他无效字符
-			resultStr = strings.TrimSpace(resultStr)
-			resultStr = strings.Trim(resultStr, "`")
+// call 选择提示词模板及其 language 语言的版本并调用大模型，返回结果保持 {"text": 回答} 的格式，"model" 为实际生成回答的模型，"promptVersion" 为使用的模板版本
+// 模板声明了 schema 时校验输出，不符合时使用修复提示词重试，校验通过的 JSON 放在 "json" 中；
+// 修复后仍不符合时排除该模型端点，交给模型链中的下一个端点重新生成
+func call(ctx context.Context, provider llm.LLMProvider, registry prompt.Registry, flag constant.AICode, key string, language string, input map[string]any) (map[string]any, error) {
+	ctx = llm.WithCode(ctx, flag)
+	tpl, err := selectTemplate(registry, flag, key, language)
+	if err != nil {
+		return nil, err
+	}
 
-			// 记录清理后的内容
-			zap.L().Info("清理后的内容",
-				zap.String("cleaned_response", resultStr))
+	promptValue, err := tpl.Format(input)
+	if err != nil {
+		return nil, err
+	}
 
-			// 尝试解析JSON
-			if err := json.Unmarshal([]byte(resultStr), &aiResponse); err != nil {
-				zap.L().Error("解析AI返回结果失败",
-					zap.String("raw_response", resultStr),
-					zap.Error(err))
-				return nil, err
+	var answer map[string]any
+	var excluded []string
+	for {
+		ctx := llm.WithExclude(ctx, excluded...)
+		res, err := provider.Generate(ctx, promptValue)
+		if err != nil {
+			if answer != nil && errors.Is(err, llm.ErrNoEndpoint) {
+				// 所有端点的输出都无法使用，返回最后一次的原始输出，由调用方决定是否使用兜底解析
+				return answer, nil
 			}
-		} else {
-			zap.L().Error("无法获取AI返回的文本内容")
-			return nil, fmt.Errorf("无法获取AI返回的文本内容")
+			return nil, err
 		}
 
-		answer = map[string]any{
-			"answer": aiResponse.Answer,
+		answer = map[string]any{"text": res.Content, "model": res.Model, "promptVersion": tpl.Version}
+		if len(tpl.Schema) == 0 {
+			return answer, nil
 		}
-		return answer, nil
-	} else {
-		return nil, fmt.Errorf("flag的值超出范围")
+		if validate(ctx, provider, registry, tpl, res, answer) {
+			return answer, nil
+		}
+
+		zap.L().Warn("模型输出无法使用，尝试备用模型端点",
+			zap.String("code", string(flag)),
+			zap.String("endpoint", res.Endpoint),
+			zap.String("model", res.Model))
+		excluded = append(excluded, res.Endpoint)
 	}
+}
 
-	// 创建链
-	chain := chains.NewLLMChain(llm, promptTemplate)
+// validate 校验模型输出是否符合模板的 schema，不符合时使用修复提示词重试，通过后写入 answer
+func validate(ctx context.Context, provider llm.LLMProvider, registry prompt.Registry, tpl *prompt.Template, res *llm.Result, answer map[string]any) bool {
+	output := res.Content
+	for i := 0; ; i++ {
+		cleaned, verr := tpl.ValidateOutput(output)
+		if verr == nil {
+			answer["text"] = cleaned
+			answer["json"] = cleaned
+			return true
+		}
+		zap.L().Warn("模型输出不符合 schema",
+			zap.String("code", string(tpl.Code)),
+			zap.String("version", tpl.Version),
+			zap.String("model", res.Model),
+			zap.Int("attempt", i),
+			zap.String("output", output),
+			zap.Error(verr))
+
+		if i >= repairRetries {
+			return false
+		}
+		repaired, err := repair(ctx, provider, registry, tpl, output, verr)
+		if err != nil {
+			zap.L().Error("修复模型输出失败", zap.Error(err))
+			return false
+		}
+		output = repaired
+	}
+}
 
-	// 运行链
-	ctx := context.Background()
-	result, err := chain.Call(ctx, input)
+// repair 使用修复提示词让模型将输出修正为符合 schema 的 JSON
+func repair(ctx context.Context, provider llm.LLMProvider, registry prompt.Registry, tpl *prompt.Template, output string, verr error) (string, error) {
+	repairTpl, err := registry.Select(constant.RepairAICode, output)
 	if err != nil {
-		zap.L().Error("chain.Call(ctx, input) : ", zap.Error(err))
-		return
+		return "", fmt.Errorf("registry.Select() err: %v", err)
 	}
 
-	var count int
-	fmt.Println()
-	fmt.Println("------------------>")
-	for i, v := range result {
-		count++
-		fmt.Println(i+"=======", v.(string))
+	promptValue, err := repairTpl.Format(map[string]any{
+		"schema": tpl.SchemaString(),
+		"output": output,
+		"error":  verr.Error(),
+	})
+	if err != nil {
+		return "", err
 	}
-	fmt.Println("------------------>")
-	fmt.Println("*********>", count)
-	fmt.Println()
 
-	return result, nil
+	res, err := provider.Generate(ctx, promptValue)
+	if err != nil {
+		return "", err
+	}
+	return res.Content, nil
+}
+
+// StreamResult 流式生成结束后的结果
+type StreamResult struct {
+	Model         string // 实际生成内容的模型
+	PromptVersion string // 使用的提示词模板版本
+	Err           error  // ctx 被取消或模型出错时不为 nil，此时 chan 中的内容不完整
 }
 
 // GenerateStream 用于调用AI大模型接口，传入你要提问的问题，返回2个正在写入的chan
-func GenerateStream(flag constant.AICode, value interface{}, cfg config.Config) (streamChan1, streamChan2 chan string, err error) {
-	fmt.Println("开始调用llm生成新答案, 请稍等......")
+// 生成结束后 doneChan 中会写入最终结果
+func GenerateStream(ctx context.Context, provider llm.LLMProvider, registry prompt.Registry, flag constant.AICode, value interface{}, cfg config.Config) (streamChan1, streamChan2 chan string, doneChan chan StreamResult, err error) {
+	zap.L().Debug("开始调用llm流式生成新答案", zap.String("code", string(flag)))
 
 	streamChan1 = make(chan string, 1)
 	streamChan2 = make(chan string, 1)
-	errChan := make(chan error, 1) // 添加错误通道
-
-	// 初始化 LLM
-	llm, err := openai.New(
-		openai.WithToken(cfg.Llm.ApiKey),
-		openai.WithModel(cfg.Llm.Model),
-		openai.WithBaseURL(cfg.Llm.BaseURL),
-	)
-	if err != nil {
-		err = fmt.Errorf("openai.New() err: %v", err)
-		return
-	}
+	doneChan = make(chan StreamResult, 1)
+
+	ctx = llm.WithCode(ctx, flag)
 
 	// 将模板和输入渲染为最终的提示词
-	promptValue, err := setPrompt(flag, value)
+	promptValue, version, err := setPrompt(registry, flag, value)
 	if err != nil {
-		err = fmt.Errorf("promptTemplate.Format() err: %v", err)
+		err = fmt.Errorf("setPrompt() err: %w", err)
 		return
 	}
 
@@ -262,87 +260,165 @@ func GenerateStream(flag constant.AICode, value interface{}, cfg config.Config)
 	go func() {
 		defer close(streamChan1)
 		defer close(streamChan2)
-		defer close(errChan) // 关闭错误通道
+		defer close(doneChan)
 
-		var temp string
-		streamingFunc := func(ctx context.Context, chunk []byte) error {
-			temp = string(chunk)
-			if temp == "" || temp == "\n\n" {
+		// 客户端断开或服务关闭时 ctx 被取消，停止写入并中止上游的流式生成
+		onChunk := func(chunk string) error {
+			if chunk == "" || chunk == "\n\n" {
 				return nil
 			}
-			streamChan1 <- temp
-			streamChan2 <- temp
+			for _, ch := range []chan string{streamChan1, streamChan2} {
+				select {
+				case ch <- chunk:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
 			return nil
 		}
 
-		ctx := context.Background()
-		_, err = llms.GenerateFromSinglePrompt(
+		res, genErr := provider.GenerateStream(
 			ctx,
-			llm,
 			promptValue,
-			llms.WithStreamingFunc(streamingFunc),
-			llms.WithTemperature(cfg.Llm.TemperatureCode),
+			onChunk,
+			llms.WithTemperature(temperature(cfg, flag)),
 		)
-		if err != nil {
+		if genErr != nil {
 			// 通过通道将协程中的错误传递给主线程
-			errChan <- fmt.Errorf("llms.GenerateFromSinglePrompt() err: %v", err)
+			doneChan <- StreamResult{Err: fmt.Errorf("provider.GenerateStream() err: %w", genErr)}
 			return
 		}
-		errChan <- nil // 成功时发送 nil
+		doneChan <- StreamResult{Model: res.Model, PromptVersion: version}
 	}()
 
-	// 主线程等待 goroutine 的错误, 为不阻碍后续的运行关联llm生成答案，此处阻塞2s。
-	count := 0
-	for count < 1 {
-		time.Sleep(1 * time.Second)
-		select {
-		case err = <-errChan:
-			return nil, nil, err
-		default:
-			count++
+	// 主线程等待 goroutine 的错误, 为不阻碍后续的运行关联llm生成答案，此处阻塞1s。
+	select {
+	case done := <-doneChan:
+		if done.Err != nil {
+			return nil, nil, nil, done.Err
 		}
+		// 生成在等待期间已正常结束，将结果重新写回，供调用方读取
+		doneChan = make(chan StreamResult, 1)
+		doneChan <- done
+		close(doneChan)
+	case <-time.After(1 * time.Second):
 	}
 
-	return streamChan1, streamChan2, nil
+	return streamChan1, streamChan2, doneChan, nil
 }
 
-// setPrompt 用于设置提示词
-func setPrompt(flag constant.AICode, value interface{}) (promptValue string, err error) {
-	var promptTemplate prompts.ChatPromptTemplate
+// Stream 流式调用大模型，每收到一段内容调用一次 onChunk，内容原样回调，不过滤空行
+// 生成结束后返回完整回答和用量，onChunk 返回错误时中止生成
+func Stream(ctx context.Context, provider llm.LLMProvider, registry prompt.Registry, flag constant.AICode, value interface{}, cfg config.Config, onChunk func(chunk string) error) (*llm.Result, error) {
+	ctx = llm.WithCode(ctx, flag)
+
+	promptValue, _, err := setPrompt(registry, flag, value)
+	if err != nil {
+		return nil, fmt.Errorf("setPrompt() err: %w", err)
+	}
+
+	res, err := provider.GenerateStream(ctx, promptValue, onChunk, llms.WithTemperature(temperature(cfg, flag)))
+	if err != nil {
+		return nil, fmt.Errorf("provider.GenerateStream() err: %w", err)
+	}
+	return res, nil
+}
+
+// temperature 文章分析和翻译使用单独的温度，其余使用代码解释的温度
+func temperature(cfg config.Config, flag constant.AICode) float64 {
+	if flag == constant.ArticleAICode || flag == constant.ArticleTranslateCode {
+		return cfg.Llm.TemperatureArticle
+	}
+	return cfg.Llm.TemperatureCode
+}
+
+// setPrompt 用于设置提示词，同时返回使用的模板版本
+func setPrompt(registry prompt.Registry, flag constant.AICode, value interface{}) (promptValue string, version string, err error) {
 	var input map[string]any
+	var key string
 
-	// 根据 flag 设置提示词模板和输入
+	var language string
+
+	// 根据 flag 设置模板输入
 	if flag == constant.ArticleAICode {
 		a := value.(*dto.ArticlePrompt)
-		promptTemplate = prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
-			prompts.NewSystemMessagePromptTemplate("你是一个专业的技术文章分析助手", []string{}),
-			prompts.NewHumanMessagePromptTemplate("请根据以下文章内容提取摘要和总结，并根据给定的标签匹配文章的标签。回答中应仅仅只包含三部分: 摘要、总结、匹配的标签，其他多余部分都不要。请尽量选择广泛的技术领域或框架名称作为标签，例如：'Django'、'React'、'Python'、'Maven'、'Unity'等，而不是过于细节的具体功能或特性。文章内容如下：\n{{.article}}\n\n标签列表：{{.tags}}}", []string{"article", "tags"}),
-		})
+		key = a.Content
+		language = a.Language
 		input = map[string]any{
 			"article": a.Content,
 			"tags":    strings.Join(a.Tags, "、"),
 		}
+	} else if flag == constant.ArticleTranslateCode {
+		t := value.(*dto.ArticleTranslatePrompt)
+		key = t.Chunk
+		input = map[string]any{
+			"chunk":    t.Chunk,
+			"language": t.LanguageName,
+			"position": fmt.Sprintf("第 %d 部分，共 %d 部分", t.Index, t.Total),
+		}
 	} else if flag == constant.CodeAICode {
 		cp := value.(*dto.CodeReq)
-		promptTemplate = prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
-			prompts.NewSystemMessagePromptTemplate("你是一个专业的代码解释助手", []string{}),
-			prompts.NewHumanMessagePromptTemplate("请根据以下{{.language}}代码生成解释，要求解释内容为一段话，字数在300字以内，代码如下：\n{{.code}}", []string{"language", "code"}),
-		})
+		key = cp.Question
+		language = cp.Language
 		input = map[string]any{
 			"language": cp.CodeType,
 			"code":     cp.Question,
 		}
+	} else if flag == constant.QuestionAnswerCode {
+		q := value.(*dto.QuestionPrompt)
+		key = q.Content
+		language = q.Language
+		input = map[string]any{
+			"content": q.Content,
+		}
+	} else if flag == constant.QuestionRAGCode {
+		q := value.(*dto.QuestionPrompt)
+		key = q.Content
+		language = q.Language
+		input = map[string]any{
+			"content": q.Content,
+			"sources": q.Sources,
+		}
 	} else {
-		fmt.Println("flag的值超出范围")
+		err = fmt.Errorf("flag的值超出范围")
 		return
 	}
 
-	// 将模板和输入渲染为最终的提示词
-	promptValue, err = promptTemplate.Format(input)
+	return formatPrompt(registry, flag, key, language, input)
+}
+
+// formatPrompt 从注册表中选择模板并渲染为最终的提示词
+func formatPrompt(registry prompt.Registry, flag constant.AICode, key string, language string, input map[string]any) (string, string, error) {
+	tpl, err := selectTemplate(registry, flag, key, language)
 	if err != nil {
-		err = fmt.Errorf("promptTemplate.Format() err: %v", err)
-		return
+		return "", "", err
+	}
+
+	promptValue, err := tpl.Format(input)
+	return promptValue, tpl.Version, err
+}
+
+// chunkPosition 分段总结时这一段在文章中的位置，使用与模板相同的语言
+func chunkPosition(language string, index, total int) string {
+	switch prompt.NormalizeLanguage(language) {
+	case "en":
+		return fmt.Sprintf("part %d of %d", index, total)
+	case "ja":
+		return fmt.Sprintf("全 %d 部分中の第 %d 部分", total, index)
+	default:
+		return fmt.Sprintf("第 %d 部分，共 %d 部分", index, total)
 	}
+}
 
-	return
+// selectTemplate 按 key 选择模板版本后取该版本 language 语言的模板，没有该语言时返回的错误包装 prompt.ErrLanguageNotSupported
+func selectTemplate(registry prompt.Registry, flag constant.AICode, key string, language string) (*prompt.Template, error) {
+	tpl, err := registry.Select(flag, key)
+	if err != nil {
+		return nil, fmt.Errorf("registry.Select() err: %v", err)
+	}
+	if tpl, err = registry.Localize(tpl, language); err != nil {
+		return nil, fmt.Errorf("registry.Localize() err: %w", err)
+	}
+	zap.L().Debug("选择提示词模板", zap.String("code", string(flag)), zap.String("version", tpl.Version), zap.String("language", tpl.Language))
+	return tpl, nil
 }
@@ -0,0 +1,67 @@
+package vectorstore
+
+import (
+	"fmt"
+	"sync"
+)
+
+// flatIndex 暴力检索，每次检索计算与所有向量的相似度
+type flatIndex struct {
+	mu    sync.RWMutex
+	items map[string]*Item
+	dim   int
+}
+
+func newFlatIndex() *flatIndex {
+	return &flatIndex{items: make(map[string]*Item)}
+}
+
+// Upsert 添加或替换一条向量
+func (f *flatIndex) Upsert(item Item) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.dim != 0 && len(item.Vector) != f.dim {
+		return fmt.Errorf("向量维度 %d 与索引的维度 %d 不一致", len(item.Vector), f.dim)
+	}
+	f.dim = len(item.Vector)
+	item.Vector = Normalize(item.Vector)
+	f.items[item.Key] = &item
+	return nil
+}
+
+// Delete 删除一条向量
+func (f *flatIndex) Delete(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.items, key)
+	if len(f.items) == 0 {
+		f.dim = 0
+	}
+}
+
+// Search 返回与 query 最相似的 k 条向量
+func (f *flatIndex) Search(query []float32, k int, filter Filter) []Hit {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if k <= 0 || len(query) != f.dim {
+		return nil
+	}
+	return scan(f.items, Normalize(query), k, filter)
+}
+
+// Len 索引中的向量数量
+func (f *flatIndex) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.items)
+}
+
+// Dim 索引中向量的维度
+func (f *flatIndex) Dim() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.dim
+}
@@ -0,0 +1,144 @@
+package vectorstore
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"siwuai/internal/infrastructure/config"
+)
+
+// 可选的内存索引类型
+const (
+	FlatIndex = "flat" // 暴力检索，结果精确
+	IVFIndex  = "ivf"  // 倒排聚类索引，向量较多时只在最相近的几个聚类中检索
+)
+
+// Item 索引中的一条向量，Type、Tags 用于检索时过滤
+type Item struct {
+	Key    string
+	Type   string
+	Tags   []string
+	Vector []float32
+}
+
+// Hit 一条检索结果，Score 为余弦相似度
+type Hit struct {
+	Key   string
+	Score float32
+}
+
+// Filter 检索时的过滤条件，返回 false 的向量不参与检索
+type Filter func(item *Item) bool
+
+// Index 内存向量索引，按余弦相似度检索，所有向量的维度必须相同
+type Index interface {
+	// Upsert 添加或替换一条向量，维度与索引中已有的向量不一致时返回错误
+	Upsert(item Item) error
+	// Delete 删除一条向量
+	Delete(key string)
+	// Search 返回与 query 最相似的 k 条向量，按相似度从高到低排序
+	Search(query []float32, k int, filter Filter) []Hit
+	// Len 索引中的向量数量
+	Len() int
+	// Dim 索引中向量的维度，索引为空时为 0
+	Dim() int
+}
+
+// NewIndex 根据配置文件创建内存向量索引
+func NewIndex(cfg config.Config) (Index, error) {
+	switch cfg.Vector.Index {
+	case FlatIndex:
+		return newFlatIndex(), nil
+	case "", IVFIndex:
+		return newIVFIndex(cfg.Vector.NList, cfg.Vector.NProbe, cfg.Vector.TrainThreshold), nil
+	default:
+		return nil, fmt.Errorf("不支持的向量索引类型: %s", cfg.Vector.Index)
+	}
+}
+
+// Normalize 返回归一化后的向量，归一化后余弦相似度等于内积
+func Normalize(vec []float32) []float32 {
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	out := make([]float32, len(vec))
+	if norm == 0 {
+		return out
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range vec {
+		out[i] = float32(float64(v) / norm)
+	}
+	return out
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// topK 保留相似度最高的 k 条结果，内部为小顶堆
+// 相似度相同时按 Key 排序，保证相同的数据和查询得到相同的结果
+type topK struct {
+	k    int
+	hits []Hit
+}
+
+func newTopK(k int) *topK {
+	return &topK{k: k, hits: make([]Hit, 0, k)}
+}
+
+func (t *topK) Len() int           { return len(t.hits) }
+func (t *topK) Less(i, j int) bool { return worse(t.hits[i], t.hits[j]) }
+func (t *topK) Swap(i, j int)      { t.hits[i], t.hits[j] = t.hits[j], t.hits[i] }
+func (t *topK) Push(x any)         { t.hits = append(t.hits, x.(Hit)) }
+func (t *topK) Pop() any {
+	hit := t.hits[len(t.hits)-1]
+	t.hits = t.hits[:len(t.hits)-1]
+	return hit
+}
+
+// add 加入一条结果，超过 k 条时淘汰相似度最低的
+func (t *topK) add(key string, score float32) {
+	if len(t.hits) < t.k {
+		heap.Push(t, Hit{Key: key, Score: score})
+		return
+	}
+	if hit := (Hit{Key: key, Score: score}); worse(t.hits[0], hit) {
+		t.hits[0] = hit
+		heap.Fix(t, 0)
+	}
+}
+
+// worse a 是否排在 b 之后
+func worse(a, b Hit) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.Key > b.Key
+}
+
+// sorted 按相似度从高到低返回结果
+func (t *topK) sorted() []Hit {
+	hits := make([]Hit, len(t.hits))
+	for i := len(hits) - 1; i >= 0; i-- {
+		hits[i] = heap.Pop(t).(Hit)
+	}
+	return hits
+}
+
+// scan 在 items 中暴力检索
+func scan(items map[string]*Item, query []float32, k int, filter Filter) []Hit {
+	top := newTopK(k)
+	for key, item := range items {
+		if filter != nil && !filter(item) {
+			continue
+		}
+		top.add(key, dot(query, item.Vector))
+	}
+	return top.sorted()
+}
@@ -0,0 +1,263 @@
+package vectorstore
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultNProbe         = 8    // 默认检索的聚类数
+	defaultTrainThreshold = 2000 // 默认开始训练聚类的向量数量
+	maxSamplesPerList     = 64   // 训练时每个聚类最多使用的样本数
+	kmeansIterations      = 10   // k-means 迭代次数
+	kmeansSeed            = 1    // k-means 的随机种子，相同的数据训练出相同的聚类
+)
+
+// ivfIndex 倒排聚类索引：用 k-means 将向量分到 nlist 个聚类，检索时只在与查询最相近的 nprobe 个聚类中查找
+// 向量数量未达到 threshold 前使用暴力检索；数量较上次训练翻倍时在后台重新训练，训练期间照常读写
+type ivfIndex struct {
+	mu    sync.RWMutex
+	items map[string]*Item
+	dim   int
+
+	centroids [][]float32           // 聚类中心，未训练时为 nil
+	lists     []map[string]struct{} // 每个聚类中的向量
+	assign    map[string]int        // 向量所在的聚类
+
+	nlist       int
+	nprobe      int
+	threshold   int
+	trainedSize int  // 上次训练时的向量数量
+	training    bool // 是否正在后台训练
+}
+
+func newIVFIndex(nlist, nprobe, threshold int) *ivfIndex {
+	if nprobe <= 0 {
+		nprobe = defaultNProbe
+	}
+	if threshold <= 0 {
+		threshold = defaultTrainThreshold
+	}
+	return &ivfIndex{
+		items:     make(map[string]*Item),
+		assign:    make(map[string]int),
+		nlist:     nlist,
+		nprobe:    nprobe,
+		threshold: threshold,
+	}
+}
+
+// Upsert 添加或替换一条向量，达到训练条件时在后台训练聚类
+func (v *ivfIndex) Upsert(item Item) error {
+	v.mu.Lock()
+	if v.dim != 0 && len(item.Vector) != v.dim {
+		v.mu.Unlock()
+		return fmt.Errorf("向量维度 %d 与索引的维度 %d 不一致", len(item.Vector), v.dim)
+	}
+	v.dim = len(item.Vector)
+	item.Vector = Normalize(item.Vector)
+	v.items[item.Key] = &item
+	if v.centroids != nil {
+		v.place(item.Key, nearest(v.centroids, item.Vector))
+	}
+
+	var snapshot []*Item
+	if !v.training && len(v.items) >= v.threshold && (v.centroids == nil || len(v.items) >= 2*v.trainedSize) {
+		v.training = true
+		snapshot = make([]*Item, 0, len(v.items))
+		for _, it := range v.items {
+			snapshot = append(snapshot, it)
+		}
+	}
+	v.mu.Unlock()
+
+	if snapshot != nil {
+		go v.train(snapshot)
+	}
+	return nil
+}
+
+// Delete 删除一条向量
+func (v *ivfIndex) Delete(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if c, ok := v.assign[key]; ok {
+		delete(v.lists[c], key)
+		delete(v.assign, key)
+	}
+	delete(v.items, key)
+	if len(v.items) == 0 {
+		v.dim = 0
+		v.centroids, v.lists, v.trainedSize = nil, nil, 0
+		v.assign = make(map[string]int)
+	}
+}
+
+// Search 返回与 query 最相似的 k 条向量
+// 过滤条件较严格、在检索的聚类中凑不够 k 条时退化为暴力检索，保证结果数量
+func (v *ivfIndex) Search(query []float32, k int, filter Filter) []Hit {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if k <= 0 || len(query) != v.dim {
+		return nil
+	}
+	query = Normalize(query)
+	if v.centroids == nil {
+		return scan(v.items, query, k, filter)
+	}
+
+	order := make([]int, len(v.centroids))
+	scores := make([]float32, len(v.centroids))
+	for c, centroid := range v.centroids {
+		order[c], scores[c] = c, dot(query, centroid)
+	}
+	sort.Slice(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+
+	top := newTopK(k)
+	for _, c := range order[:min(v.nprobe, len(order))] {
+		for key := range v.lists[c] {
+			item := v.items[key]
+			if filter != nil && !filter(item) {
+				continue
+			}
+			top.add(key, dot(query, item.Vector))
+		}
+	}
+	if top.Len() < k && top.Len() < len(v.items) {
+		return scan(v.items, query, k, filter)
+	}
+	return top.sorted()
+}
+
+// Len 索引中的向量数量
+func (v *ivfIndex) Len() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.items)
+}
+
+// Dim 索引中向量的维度
+func (v *ivfIndex) Dim() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.dim
+}
+
+// train 在后台用 snapshot 训练聚类，聚类和分配都在锁外计算，最后加锁替换
+func (v *ivfIndex) train(snapshot []*Item) {
+	start := time.Now()
+	nlist := v.nlist
+	if nlist <= 0 {
+		nlist = int(math.Sqrt(float64(len(snapshot))))
+	}
+	nlist = max(1, min(nlist, len(snapshot)))
+
+	// snapshot 来自 map，先按 Key 排序再抽样
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Key < snapshot[j].Key })
+	rnd := rand.New(rand.NewSource(kmeansSeed))
+	samples := make([][]float32, 0, min(len(snapshot), nlist*maxSamplesPerList))
+	for _, i := range rnd.Perm(len(snapshot))[:cap(samples)] {
+		samples = append(samples, snapshot[i].Vector)
+	}
+	centroids := kmeans(rnd, samples, nlist, kmeansIterations)
+
+	assigned := make(map[*Item]int, len(snapshot))
+	for _, item := range snapshot {
+		assigned[item] = nearest(centroids, item.Vector)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.training = false
+	// 训练期间索引被清空或维度发生变化，丢弃本次结果
+	if v.dim != len(centroids[0]) {
+		return
+	}
+
+	v.centroids = centroids
+	v.lists = make([]map[string]struct{}, len(centroids))
+	for c := range v.lists {
+		v.lists[c] = make(map[string]struct{})
+	}
+	v.assign = make(map[string]int, len(v.items))
+	for key, item := range v.items {
+		// 训练期间新增或替换的向量重新计算所在的聚类
+		c, ok := assigned[item]
+		if !ok {
+			c = nearest(centroids, item.Vector)
+		}
+		v.place(key, c)
+	}
+	v.trainedSize = len(v.items)
+
+	zap.L().Info("向量索引聚类训练完成",
+		zap.Int("count", len(v.items)),
+		zap.Int("nlist", len(centroids)),
+		zap.Duration("elapsed", time.Since(start)))
+}
+
+// place 将向量移动到聚类 c 中，调用方需持有写锁
+func (v *ivfIndex) place(key string, c int) {
+	if old, ok := v.assign[key]; ok {
+		delete(v.lists[old], key)
+	}
+	v.lists[c][key] = struct{}{}
+	v.assign[key] = c
+}
+
+// kmeans 球面 k-means，向量和聚类中心均已归一化，以内积作为相似度
+func kmeans(rnd *rand.Rand, vectors [][]float32, k, iterations int) [][]float32 {
+	dim := len(vectors[0])
+	centroids := make([][]float32, k)
+	for c, i := range rnd.Perm(len(vectors))[:k] {
+		centroids[c] = append([]float32(nil), vectors[i]...)
+	}
+
+	sums := make([][]float64, k)
+	counts := make([]int, k)
+	for iter := 0; iter < iterations; iter++ {
+		for c := range sums {
+			sums[c] = make([]float64, dim)
+			counts[c] = 0
+		}
+		for _, vec := range vectors {
+			c := nearest(centroids, vec)
+			counts[c]++
+			for d, x := range vec {
+				sums[c][d] += float64(x)
+			}
+		}
+		for c := range centroids {
+			// 空聚类重新随机选择一个样本作为中心
+			if counts[c] == 0 {
+				centroids[c] = append([]float32(nil), vectors[rnd.Intn(len(vectors))]...)
+				continue
+			}
+			mean := make([]float32, dim)
+			for d, x := range sums[c] {
+				mean[d] = float32(x / float64(counts[c]))
+			}
+			centroids[c] = Normalize(mean)
+		}
+	}
+	return centroids
+}
+
+// nearest 返回与 vec 最相近的聚类中心
+func nearest(centroids [][]float32, vec []float32) int {
+	best, bestScore := 0, float32(math.Inf(-1))
+	for c, centroid := range centroids {
+		if score := dot(vec, centroid); score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	return best
+}
@@ -2,29 +2,45 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 	"siwuai/internal/app"
 	impl2 "siwuai/internal/app/impl"
+	"siwuai/internal/domain/model/dto"
+	domainservice "siwuai/internal/domain/service"
 	service "siwuai/internal/domain/service/impl"
 	"siwuai/internal/infrastructure/cache"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/persistence"
 	"siwuai/internal/infrastructure/persistence/impl"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
 	pb "siwuai/proto/article"
+	"strings"
 )
 
+// defaultRelatedArticles 未指定数量时返回的相关文章数量
+const defaultRelatedArticles = 5
+
+// defaultArticlesByTag 按标签查询文章时未指定每页数量时的默认值
+const defaultArticlesByTag = 20
+
 type articleGRPCHandler struct {
 	pb.UnimplementedArticleServiceServer
 	repo app.ArticleAppServiceInterface
 }
 
-func NewArticleGRPCHandler(db *gorm.DB, cfg config.Config, cacheManager *cache.CacheManager, jc constant.JudgingCacheType) pb.ArticleServiceServer {
+func NewArticleGRPCHandler(db *gorm.DB, cfg config.Config, cacheManager *cache.CacheManager, jc constant.JudgingCacheType, provider llm.LLMProvider, registry prompt.Registry, knowledge domainservice.KnowledgeDomainService, tags domainservice.TagDomainService, limiter quota.Limiter) pb.ArticleServiceServer {
 	repo := impl.NewArticleRepository(db)
 	sign := constant.NewJudgingSign()
-	ds := service.NewArticleDomainService(repo, sign, cfg, cacheManager, jc)
+	ds := service.NewArticleDomainService(repo, sign, cfg, cacheManager, jc, provider, registry, knowledge, tags)
 	cr := impl.NewMySQLCodeRepository(db)
-	as := impl2.NewArticleAppService(ds, cr)
+	as := impl2.NewArticleAppService(ds, cr, limiter)
 	return &articleGRPCHandler{
 		repo: as,
 	}
@@ -32,24 +48,75 @@ func NewArticleGRPCHandler(db *gorm.DB, cfg config.Config, cacheManager *cache.C
 
 // GetArticleInfoFirst 第一次获取文章的摘要、总结、标签
 func (a *articleGRPCHandler) GetArticleInfoFirst(ctx context.Context, req *pb.GetArticleInfoFirstRequest) (*pb.GetArticleInfoFirstResponse, error) {
-	articleFirst, err := a.repo.GetArticleInfoFirst(req.Content, req.Tags, uint(req.ArticleID))
+	articleFirst, err := a.repo.GetArticleInfoFirst(ctx, req.Content, req.Tags, uint(req.ArticleID), req.Language, req.WithSEO)
 	if err != nil {
-		zap.L().Error("GetArticleInfoFirst -> ", zap.Error(err))
-		return nil, err
+		return nil, articleError("GetArticleInfoFirst", req.ArticleID, err)
 	}
-	// 封装数据
-	res := &pb.GetArticleInfoFirstResponse{
-		Key:      articleFirst.Key,
-		Summary:  articleFirst.Summary,
-		Abstract: articleFirst.Abstract,
-		Tags:     articleFirst.Tags,
+	return articleFirstToPb(articleFirst), nil
+}
+
+// GetArticleInfoFirstStream 流式获取文章的摘要、总结、标签
+func (a *articleGRPCHandler) GetArticleInfoFirstStream(req *pb.GetArticleInfoFirstRequest, stream pb.ArticleService_GetArticleInfoFirstStreamServer) error {
+	err := a.repo.GetArticleInfoFirstStream(stream.Context(), req.Content, req.Tags, uint(req.ArticleID), req.Language, req.WithSEO, func(event dto.ArticleEvent) error {
+		res := &pb.ArticleInfoEvent{
+			Text: event.Text,
+			Tags: event.Tags,
+		}
+		switch event.Type {
+		case dto.ArticleEventAbstract:
+			res.Type = pb.ArticleEventType_ARTICLE_EVENT_ABSTRACT
+		case dto.ArticleEventSummary:
+			res.Type = pb.ArticleEventType_ARTICLE_EVENT_SUMMARY
+		case dto.ArticleEventTags:
+			res.Type = pb.ArticleEventType_ARTICLE_EVENT_TAGS
+		case dto.ArticleEventDone:
+			res.Type = pb.ArticleEventType_ARTICLE_EVENT_DONE
+			res.Result = articleFirstToPb(event.Result)
+		}
+		return stream.Send(res)
+	})
+	if err != nil {
+		if stream.Context().Err() != nil {
+			zap.L().Error("GetArticleInfoFirstStream -> ", zap.Error(err))
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+		return articleError("GetArticleInfoFirstStream", req.ArticleID, err)
+	}
+	return nil
+}
+
+// articleFirstToPb 封装数据
+func articleFirstToPb(articleFirst *dto.ArticleFirst) *pb.GetArticleInfoFirstResponse {
+	return &pb.GetArticleInfoFirstResponse{
+		Key:           articleFirst.Key,
+		Summary:       articleFirst.Summary,
+		Abstract:      articleFirst.Abstract,
+		Tags:          articleFirst.Tags,
+		Confidence:    articleFirst.Confidence,
+		Model:         articleFirst.Model,
+		SuggestedTags: articleFirst.SuggestedTags,
+		Language:      articleFirst.Language,
+		Seo:           articleSEOToPb(&articleFirst.SEO),
+	}
+}
+
+// articleSEOToPb 封装数据，没有生成 SEO 信息时返回 nil
+func articleSEOToPb(seo *dto.ArticleSEO) *pb.ArticleSEO {
+	if seo.Empty() {
+		return nil
+	}
+	return &pb.ArticleSEO{
+		SeoTitle:        seo.SEOTitle,
+		MetaDescription: seo.MetaDescription,
+		Slug:            seo.Slug,
+		OgTitle:         seo.OGTitle,
+		OgDescription:   seo.OGDescription,
 	}
-	return res, nil
 }
 
 // SaveArticleID 保存文章的ID
 func (a *articleGRPCHandler) SaveArticleID(ctx context.Context, req *pb.SaveArticleIDRequest) (*pb.SaveArticleIDResponse, error) {
-	err := a.repo.SaveArticleID(req.Key, uint(req.ArticleID))
+	err := a.repo.SaveArticleID(ctx, req.Key, uint(req.ArticleID))
 	if err != nil {
 		zap.L().Error("SaveArticleID -> ", zap.Error(err))
 		return nil, err
@@ -60,16 +127,36 @@ func (a *articleGRPCHandler) SaveArticleID(ctx context.Context, req *pb.SaveArti
 	return res, nil
 }
 
+// UpdateArticleInfo 文章内容修改后更新文章信息
+func (a *articleGRPCHandler) UpdateArticleInfo(ctx context.Context, req *pb.UpdateArticleInfoRequest) (*pb.UpdateArticleInfoResponse, error) {
+	if req.ArticleID == 0 || req.Content == "" {
+		return nil, status.Error(codes.InvalidArgument, "articleID 和 content 不能为空")
+	}
+
+	update, err := a.repo.UpdateArticleInfo(ctx, req.Content, req.Tags, uint(req.ArticleID), req.Language, req.Force, req.WithSEO)
+	if err != nil {
+		return nil, articleError("UpdateArticleInfo", req.ArticleID, err)
+	}
+	return &pb.UpdateArticleInfoResponse{
+		Article:     articleFirstToPb(&update.ArticleFirst),
+		Regenerated: update.Regenerated,
+		ChangeRatio: update.ChangeRatio,
+	}, nil
+}
+
 // GetArticleInfo 非首次获取文章的信息
 func (a *articleGRPCHandler) GetArticleInfo(ctx context.Context, req *pb.GetArticleInfoRequest) (*pb.GetArticleInfoResponse, error) {
-	articleSecond, codes, err := a.repo.GetArticleInfo(uint(req.ArticleID), uint(req.UserID))
+	articleSecond, codes, err := a.repo.GetArticleInfo(uint(req.ArticleID), uint(req.UserID), req.Language)
 	if err != nil {
-		zap.L().Error("GetArticleInfo -> ", zap.Error(err))
-		return nil, err
+		return nil, articleError("GetArticleInfo", req.ArticleID, err)
 	}
 	res := &pb.GetArticleInfoResponse{
-		Summary:  articleSecond.Summary,
-		Abstract: articleSecond.Abstract,
+		Summary:     articleSecond.Summary,
+		Abstract:    articleSecond.Abstract,
+		Tags:        articleSecond.Tags,
+		HumanEdited: articleSecond.HumanEdited,
+		Language:    articleSecond.Language,
+		Seo:         articleSEOToPb(&articleSecond.SEO),
 	}
 
 	for _, v := range codes {
@@ -85,7 +172,7 @@ func (a *articleGRPCHandler) GetArticleInfo(ctx context.Context, req *pb.GetArti
 
 // DelArticleInfo 删除文章信息
 func (a *articleGRPCHandler) DelArticleInfo(ctx context.Context, req *pb.DelArticleInfoRequest) (*pb.DelArticleInfoResponse, error) {
-	err := a.repo.DelArticleInfo(uint(req.ArticleID))
+	err := a.repo.DelArticleInfo(ctx, uint(req.ArticleID))
 	if err != nil {
 		zap.L().Error("DelArticleInfo -> ", zap.Error(err))
 		return nil, err
@@ -95,3 +182,185 @@ func (a *articleGRPCHandler) DelArticleInfo(ctx context.Context, req *pb.DelArti
 	}
 	return res, nil
 }
+
+// GetRelatedArticles 获取相关文章
+func (a *articleGRPCHandler) GetRelatedArticles(ctx context.Context, req *pb.GetRelatedArticlesRequest) (*pb.GetRelatedArticlesResponse, error) {
+	if req.ArticleID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "articleID 不能为空")
+	}
+	k := int(req.K)
+	if k <= 0 {
+		k = defaultRelatedArticles
+	}
+
+	related, err := a.repo.GetRelatedArticles(ctx, uint(req.ArticleID), k)
+	if err != nil {
+		if errors.Is(err, domainservice.ErrVectorNotFound) {
+			return nil, status.Errorf(codes.NotFound, "文章 %d 尚未生成摘要和总结", req.ArticleID)
+		}
+		zap.L().Error("GetRelatedArticles -> ", zap.Error(err))
+		return nil, err
+	}
+
+	res := &pb.GetRelatedArticlesResponse{
+		Articles: make([]*pb.RelatedArticle, len(related)),
+	}
+	for i, r := range related {
+		res.Articles[i] = &pb.RelatedArticle{
+			ArticleID:  uint32(r.ArticleID),
+			Score:      r.Score,
+			Similarity: r.Similarity,
+			CommonTags: r.CommonTags,
+		}
+	}
+	return res, nil
+}
+
+// ListArticleVersions 查询文章的全部摘要和总结版本
+func (a *articleGRPCHandler) ListArticleVersions(ctx context.Context, req *pb.ListArticleVersionsRequest) (*pb.ListArticleVersionsResponse, error) {
+	if req.ArticleID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "articleID 不能为空")
+	}
+	versions, err := a.repo.ListArticleVersions(uint(req.ArticleID))
+	if err != nil {
+		return nil, articleError("ListArticleVersions", req.ArticleID, err)
+	}
+
+	res := &pb.ListArticleVersionsResponse{
+		Versions: make([]*pb.ArticleVersion, len(versions)),
+	}
+	for i := range versions {
+		res.Versions[i] = articleVersionToPb(&versions[i])
+	}
+	return res, nil
+}
+
+// GetArticleVersion 查询文章的指定版本
+func (a *articleGRPCHandler) GetArticleVersion(ctx context.Context, req *pb.GetArticleVersionRequest) (*pb.GetArticleVersionResponse, error) {
+	if req.ArticleID == 0 || req.VersionID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "articleID 和 versionID 不能为空")
+	}
+	version, err := a.repo.GetArticleVersion(uint(req.ArticleID), uint(req.VersionID))
+	if err != nil {
+		return nil, articleError("GetArticleVersion", req.ArticleID, err)
+	}
+	return &pb.GetArticleVersionResponse{Version: articleVersionToPb(version)}, nil
+}
+
+// PinArticleVersion 将指定版本设为文章当前使用的摘要和总结
+func (a *articleGRPCHandler) PinArticleVersion(ctx context.Context, req *pb.PinArticleVersionRequest) (*pb.PinArticleVersionResponse, error) {
+	if req.ArticleID == 0 || req.VersionID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "articleID 和 versionID 不能为空")
+	}
+	version, err := a.repo.PinArticleVersion(ctx, uint(req.ArticleID), uint(req.VersionID))
+	if err != nil {
+		return nil, articleError("PinArticleVersion", req.ArticleID, err)
+	}
+	return &pb.PinArticleVersionResponse{Version: articleVersionToPb(version)}, nil
+}
+
+// SaveArticleOverride 保存编辑人工修改的摘要、总结和标签，所有字段都为空时恢复使用模型生成的结果
+func (a *articleGRPCHandler) SaveArticleOverride(ctx context.Context, req *pb.SaveArticleOverrideRequest) (*pb.SaveArticleOverrideResponse, error) {
+	if req.ArticleID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "articleID 不能为空")
+	}
+	override := &dto.ArticleOverride{
+		ArticleID: uint(req.ArticleID),
+		Abstract:  strings.TrimSpace(req.Abstract),
+		Summary:   strings.TrimSpace(req.Summary),
+		Tags:      req.Tags,
+		EditorID:  uint(req.UserID),
+	}
+	if err := a.repo.SaveArticleOverride(ctx, override); err != nil {
+		return nil, articleError("SaveArticleOverride", req.ArticleID, err)
+	}
+	res := &pb.SaveArticleOverrideResponse{
+		Inform: "保存人工修改成功",
+	}
+	if override.Empty() {
+		res.Inform = "已恢复使用模型生成的结果"
+	}
+	return res, nil
+}
+
+// ListArticlesByTag 按标签分页查询文章ID
+func (a *articleGRPCHandler) ListArticlesByTag(ctx context.Context, req *pb.ListArticlesByTagRequest) (*pb.ListArticlesByTagResponse, error) {
+	if strings.TrimSpace(req.Tag) == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag 不能为空")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultArticlesByTag
+	}
+
+	articles, err := a.repo.ListArticlesByTag(req.Tag, uint(req.AfterID), limit)
+	if err != nil {
+		zap.L().Error("ListArticlesByTag -> ", zap.Error(err))
+		return nil, err
+	}
+
+	res := &pb.ListArticlesByTagResponse{
+		Tag:        articles.Tag,
+		ArticleIDs: make([]uint32, len(articles.ArticleIDs)),
+	}
+	for i, id := range articles.ArticleIDs {
+		res.ArticleIDs[i] = uint32(id)
+	}
+	return res, nil
+}
+
+// TranslateArticle 将文章翻译为指定语言，逐段返回译文，最后一条消息携带翻译信息
+func (a *articleGRPCHandler) TranslateArticle(req *pb.TranslateArticleRequest, stream pb.ArticleService_TranslateArticleServer) error {
+	if req.ArticleID == 0 || strings.TrimSpace(req.Content) == "" || strings.TrimSpace(req.Language) == "" {
+		return status.Error(codes.InvalidArgument, "articleID、content 和 language 不能为空")
+	}
+
+	ctx := stream.Context()
+	translation, err := a.repo.TranslateArticle(ctx, uint(req.ArticleID), req.Content, req.Language, func(chunk string) error {
+		return stream.Send(&pb.TranslateArticleResponse{Content: chunk})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			zap.L().Error("TranslateArticle -> ", zap.Error(err))
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return articleError("TranslateArticle", req.ArticleID, err)
+	}
+
+	return stream.Send(&pb.TranslateArticleResponse{Metadata: &pb.TranslationMetadata{
+		Key:      translation.Key,
+		Language: translation.Language,
+		Model:    translation.Model,
+		Cached:   translation.Cached,
+	}})
+}
+
+// articleError 将文章或版本不存在转换为 NotFound，不支持的语言转换为 InvalidArgument，其余错误记录日志后原样返回
+func articleError(method string, articleID uint32, err error) error {
+	switch {
+	case errors.Is(err, persistence.ErrArticleNotFound):
+		return status.Errorf(codes.NotFound, "文章 %d 不存在", articleID)
+	case errors.Is(err, persistence.ErrArticleVersionNotFound):
+		return status.Errorf(codes.NotFound, "文章 %d 没有该版本", articleID)
+	case errors.Is(err, prompt.ErrLanguageNotSupported):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	zap.L().Error(method+" -> ", zap.Error(err))
+	return err
+}
+
+// articleVersionToPb 封装数据
+func articleVersionToPb(version *dto.ArticleVersion) *pb.ArticleVersion {
+	return &pb.ArticleVersion{
+		VersionID:     uint32(version.VersionID),
+		Key:           version.Key,
+		Abstract:      version.Abstract,
+		Summary:       version.Summary,
+		Tags:          version.Tags,
+		Confidence:    version.Confidence,
+		Model:         version.Model,
+		PromptVersion: version.PromptVersion,
+		CreatedAt:     version.CreatedAt.Unix(),
+		Current:       version.Current,
+	}
+}
@@ -1,48 +1,58 @@
 package grpc
 
 import (
-	"fmt"
 	"github.com/bits-and-blooms/bloom/v3"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 	"siwuai/internal/app"
 	appimpl "siwuai/internal/app/impl"
 	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
 	serviceimpl "siwuai/internal/domain/service/impl"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
 	persistenceimpl "siwuai/internal/infrastructure/persistence/impl"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
 	"siwuai/internal/infrastructure/redis_utils"
 	pb "siwuai/proto/code"
 )
 
+// modelTrailer 返回生成解释的模型的 trailer 键
+const modelTrailer = "x-llm-model"
+
+// languageTrailer 返回解释使用的语言的 trailer 键
+const languageTrailer = "x-output-language"
+
 type codeGRPCHandler struct {
 	pb.UnimplementedCodeServiceServer
 	uc app.CodeApp
 }
 
-func NewCodeGRPCHandler(db *gorm.DB, redisClient *redis_utils.RedisClient, bf *bloom.BloomFilter, cfg config.Config) pb.CodeServiceServer {
+func NewCodeGRPCHandler(db *gorm.DB, redisClient *redis_utils.RedisClient, bf *bloom.BloomFilter, cfg config.Config, provider llm.LLMProvider, registry prompt.Registry, knowledge service.KnowledgeDomainService, limiter quota.Limiter) pb.CodeServiceServer {
 	repo := persistenceimpl.NewMySQLCodeRepository(db)
 	sign := constant.NewJudgingSign()
-	ds := serviceimpl.NewCodeDomainService(repo, redisClient, bf, sign, cfg)
-	uc := appimpl.NewCodeApp(repo, ds)
+	ds := serviceimpl.NewCodeDomainService(repo, redisClient, bf, sign, cfg, provider, registry, knowledge)
+	uc := appimpl.NewCodeApp(repo, ds, limiter)
 	return &codeGRPCHandler{uc: uc}
 }
 
 func (h *codeGRPCHandler) ExplainCode(req *pb.CodeRequest, stream pb.CodeService_ExplainCodeServer) error {
 	// 接收
-	req1 := dto.CodeReq{UserId: uint(req.UserId), Question: req.CodeQuestion, CodeType: req.CodeType}
+	req1 := dto.CodeReq{UserId: uint(req.UserId), Question: req.CodeQuestion, CodeType: req.CodeType, Language: prompt.NormalizeLanguage(req.Language)}
 
 	// 业务
-	code1, err := h.uc.ExplainCode(&req1)
+	code1, err := h.uc.ExplainCode(stream.Context(), &req1)
 	if err != nil {
 		zap.L().Error("ExplainCode() ", zap.Error(err))
-		return err
+		return languageError(err)
 	}
-	fmt.Printf("最后收到的code1：%#v\n", code1)
-
 	// 如果 code1.Stream 为 nil，说明缓存命中，那么则将缓存的结果手动转换为流式输出
-	if code1.Stream == nil {
+	cached := code1.Stream == nil
+	if cached {
 		code1.Stream = make(chan string) // 初始化通道
 		go func() {
 			defer close(code1.Stream) // 确保通道关闭
@@ -56,9 +66,23 @@ func (h *codeGRPCHandler) ExplainCode(req *pb.CodeRequest, stream pb.CodeService
 			zap.L().Error("stream.Send(&pb.CodeResponse{CodeExplain: chunk}) err: ", zap.Error(err))
 			return err
 		}
-		fmt.Println(chunk)
+	}
 
+	// 流式生成中途失败（模型出错、请求超时等）时返回错误，避免客户端把不完整的解释当作完整结果
+	if code1.Err != nil {
+		if err = <-code1.Err; err != nil {
+			zap.L().Error("ExplainCode() 生成未完成", zap.Error(err))
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			return err
+		}
 	}
 
+	// 生成时 code1.Model 由后台协程在发送 code1.Err 之前写入，收到生成结果后才能读取
+	zap.L().Debug("代码解释返回完成", zap.String("model", code1.Model), zap.Bool("cached", cached))
+
+	// 生成解释的模型和语言通过 trailer 返回，缓存命中时为缓存中记录的模型
+	stream.SetTrailer(metadata.Pairs(modelTrailer, code1.Model, languageTrailer, req1.Language))
 	return nil
 }
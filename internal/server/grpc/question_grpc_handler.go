@@ -2,33 +2,41 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"siwuai/internal/app"
+	appimpl "siwuai/internal/app/impl"
 	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+	serviceimpl "siwuai/internal/domain/service/impl"
 	"siwuai/internal/infrastructure/cache"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
-	"siwuai/internal/infrastructure/utils"
+	"siwuai/internal/infrastructure/llm"
+	"siwuai/internal/infrastructure/persistence/impl"
+	"siwuai/internal/infrastructure/prompt"
+	"siwuai/internal/infrastructure/quota"
 	pbquestion "siwuai/proto/question"
+	"strings"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
 // questionGRPCHandler 实现 pbquestion.QuestionServiceServer 接口
 type questionGRPCHandler struct {
 	pbquestion.UnimplementedQuestionServiceServer
-	db           *gorm.DB
-	cfg          config.Config
-	cacheManager *cache.CacheManager
-	jc           constant.JudgingCacheType
+	repo app.QuestionAppServiceInterface
 }
 
 // NewQuestionGRPCHandler 构造函数
-func NewQuestionGRPCHandler(db *gorm.DB, cfg config.Config, cacheManager *cache.CacheManager, jc constant.JudgingCacheType) pbquestion.QuestionServiceServer {
+func NewQuestionGRPCHandler(db *gorm.DB, cfg config.Config, cacheManager *cache.CacheManager, jc constant.JudgingCacheType, provider llm.LLMProvider, registry prompt.Registry, vectors service.VectorDomainService, knowledge service.KnowledgeDomainService, tags service.TagDomainService, limiter quota.Limiter) pbquestion.QuestionServiceServer {
+	repo := impl.NewQuestionRepository(db)
+	ds := serviceimpl.NewQuestionDomainService(repo, cfg, cacheManager, jc, provider, registry, vectors, knowledge, tags)
+	as := appimpl.NewQuestionAppService(ds, limiter)
 	return &questionGRPCHandler{
-		db:           db,
-		cfg:          cfg,
-		cacheManager: cacheManager,
-		jc:           jc,
+		repo: as,
 	}
 }
 
@@ -36,23 +44,17 @@ func NewQuestionGRPCHandler(db *gorm.DB, cfg config.Config, cacheManager *cache.
 func (h *questionGRPCHandler) GenerateQuestionTitles(ctx context.Context, req *pbquestion.GenerateQuestionTitlesRequest) (*pbquestion.GenerateQuestionTitlesResponse, error) {
 	zap.L().Info("GenerateQuestionTitles called", zap.String("content", req.Content))
 
-	// 构造 AI 请求参数
-	questionPrompt := &dto.QuestionPrompt{
-		Content: req.Content,
-	}
-
-	// 调用 AI 生成标题和标签
-	result, err := utils.Generate(constant.QuestionAICode, questionPrompt, h.cfg)
+	// 调用 AI 生成标题和标签，相同内容的问题直接返回已生成的结果
+	question, err := h.repo.GenerateQuestionTitles(ctx, req.Content, uint(req.QuestionID), req.Language)
 	if err != nil {
 		zap.L().Error("AI 生成标题失败", zap.Error(err))
 		return &pbquestion.GenerateQuestionTitlesResponse{
 			Status: "failed",
-		}, err
+		}, languageError(err)
 	}
 
-	// 解析 AI 返回结果
-	titles, _ := result["titles"].([]string)
-	tags, _ := result["tags"].([]string)
+	titles := question.Titles
+	tags := question.Tags
 
 	// 确保至少有一个标题
 	if len(titles) == 0 {
@@ -65,40 +67,137 @@ func (h *questionGRPCHandler) GenerateQuestionTitles(ctx context.Context, req *p
 	}
 
 	resp := &pbquestion.GenerateQuestionTitlesResponse{
-		Key:    "ai-question-key",
-		Titles: titles,
-		Total:  int32(len(titles)),
-		Status: "success",
-		Tags:   tags,
+		Key:           question.Key,
+		Titles:        titles,
+		Total:         int32(len(titles)),
+		Status:        "success",
+		Tags:          tags,
+		Model:         question.TitleModel,
+		SuggestedTags: question.SuggestedTags,
+		Language:      question.Language,
 	}
 	return resp, nil
 }
 
-// GetAnswer 实现 gRPC 方法
+// GetAnswer 实现 gRPC 方法，与 StreamAnswer 使用相同的生成流程，生成结束后一次性返回
 func (h *questionGRPCHandler) GetAnswer(ctx context.Context, req *pbquestion.GetAnswerRequest) (*pbquestion.GetAnswerResponse, error) {
 	zap.L().Info("GetAnswer called", zap.String("content", req.Content))
 
-	// 构造 AI 请求参数
-	questionPrompt := &dto.QuestionPrompt{
-		Content: req.Content,
+	answer, err := h.repo.GetAnswer(ctx, req.Content, answerOptions(req), func(chunk string) error {
+		return nil
+	})
+	if err != nil {
+		zap.L().Error("AI 生成答案失败", zap.Error(err))
+		return nil, languageError(err)
 	}
 
-	// 调用 AI 生成答案
-	result, err := utils.Generate(constant.QuestionAnswerCode, questionPrompt, h.cfg)
+	resp := &pbquestion.GetAnswerResponse{
+		Content:  answer.Answer,
+		Model:    answer.Model,
+		Metadata: answerMetadata(answer),
+	}
+	return resp, nil
+}
+
+// StreamAnswer 实现 gRPC 方法，逐段返回 markdown 内容，最后一条消息携带生成信息
+func (h *questionGRPCHandler) StreamAnswer(req *pbquestion.GetAnswerRequest, stream pbquestion.QuestionService_StreamAnswerServer) error {
+	zap.L().Info("StreamAnswer called", zap.String("content", req.Content))
+
+	ctx := stream.Context()
+	answer, err := h.repo.GetAnswer(ctx, req.Content, answerOptions(req), func(chunk string) error {
+		return stream.Send(&pbquestion.StreamAnswerResponse{Content: chunk})
+	})
 	if err != nil {
-		zap.L().Error("AI 生成答案失败", zap.Error(err))
-		return nil, err
+		zap.L().Error("AI 流式生成答案失败", zap.Error(err))
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return languageError(err)
 	}
 
-	// 解析 AI 返回结果
-	answer, ok := result["answer"].(string)
-	if !ok {
-		zap.L().Error("AI 返回结果格式错误")
+	return stream.Send(&pbquestion.StreamAnswerResponse{Metadata: answerMetadata(answer)})
+}
+
+// SaveQuestionID 保存问题的ID
+func (h *questionGRPCHandler) SaveQuestionID(ctx context.Context, req *pbquestion.SaveQuestionIDRequest) (*pbquestion.SaveQuestionIDResponse, error) {
+	err := h.repo.SaveQuestionID(ctx, req.Key, uint(req.QuestionID))
+	if err != nil {
+		zap.L().Error("SaveQuestionID -> ", zap.Error(err))
 		return nil, err
 	}
+	res := &pbquestion.SaveQuestionIDResponse{
+		Inform: "保存问题ID成功",
+	}
+	return res, nil
+}
 
-	resp := &pbquestion.GetAnswerResponse{
-		Content: answer,
+// FindSimilarQuestions 检索内容相近的已有问题
+func (h *questionGRPCHandler) FindSimilarQuestions(ctx context.Context, req *pbquestion.FindSimilarQuestionsRequest) (*pbquestion.FindSimilarQuestionsResponse, error) {
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, status.Error(codes.InvalidArgument, "content 不能为空")
+	}
+
+	questions, err := h.repo.FindSimilarQuestions(ctx, req.Content, int(req.TopK), uint(req.QuestionID))
+	if err != nil {
+		zap.L().Error("检索相似问题失败", zap.Error(err))
+		return nil, vectorError(err)
+	}
+
+	resp := &pbquestion.FindSimilarQuestionsResponse{
+		Questions: make([]*pbquestion.SimilarQuestion, len(questions)),
+	}
+	for i, q := range questions {
+		resp.Questions[i] = &pbquestion.SimilarQuestion{
+			QuestionID: uint32(q.QuestionID),
+			Key:        q.Key,
+			Titles:     q.Titles,
+			Tags:       q.Tags,
+			Score:      q.Score,
+		}
 	}
 	return resp, nil
 }
+
+// languageError 将不支持的语言转换为 InvalidArgument，其余错误原样返回
+func languageError(err error) error {
+	if errors.Is(err, prompt.ErrLanguageNotSupported) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+// answerOptions 生成答案的选项
+func answerOptions(req *pbquestion.GetAnswerRequest) dto.AnswerOptions {
+	return dto.AnswerOptions{
+		WithSources: req.WithSources,
+		TopK:        int(req.TopK),
+		Language:    req.Language,
+	}
+}
+
+// answerMetadata 封装答案的生成信息
+func answerMetadata(answer *dto.QuestionAnswer) *pbquestion.AnswerMetadata {
+	metadata := &pbquestion.AnswerMetadata{
+		Model:            answer.Model,
+		PromptTokens:     int32(answer.PromptTokens),
+		CompletionTokens: int32(answer.CompletionTokens),
+		FinishReason:     answer.FinishReason,
+		Cached:           answer.Cached,
+		Citations:        make([]*pbquestion.Citation, len(answer.Citations)),
+		CitedArticleIDs:  make([]uint32, len(answer.CitedArticleIDs)),
+		Language:         answer.Language,
+	}
+	for i, c := range answer.Citations {
+		metadata.Citations[i] = &pbquestion.Citation{
+			Index: int32(c.Index),
+			Type:  c.Type,
+			Id:    c.ID,
+			Score: c.Score,
+			Cited: c.Cited,
+		}
+	}
+	for i, id := range answer.CitedArticleIDs {
+		metadata.CitedArticleIDs[i] = uint32(id)
+	}
+	return metadata
+}
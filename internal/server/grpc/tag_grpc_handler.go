@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"siwuai/internal/app"
+	appimpl "siwuai/internal/app/impl"
+	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
+	"siwuai/internal/infrastructure/persistence"
+	pbtag "siwuai/proto/tag"
+)
+
+type tagGRPCHandler struct {
+	pbtag.UnimplementedTagServiceServer
+	ta app.TagApp
+}
+
+// NewTagGRPCHandler 构造方法
+func NewTagGRPCHandler(tags service.TagDomainService) pbtag.TagServiceServer {
+	return &tagGRPCHandler{ta: appimpl.NewTagApp(tags)}
+}
+
+// CreateTag 创建标准标签
+func (h *tagGRPCHandler) CreateTag(ctx context.Context, req *pbtag.CreateTagRequest) (*pbtag.CreateTagResponse, error) {
+	tag, err := h.ta.CreateTag(ctx, &dto.Tag{
+		Name:        req.Name,
+		Aliases:     req.Aliases,
+		ParentID:    uint(req.ParentID),
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, tagError("CreateTag", err)
+	}
+	return &pbtag.CreateTagResponse{Tag: tagToPb(tag)}, nil
+}
+
+// UpdateTag 更新标签
+func (h *tagGRPCHandler) UpdateTag(ctx context.Context, req *pbtag.UpdateTagRequest) (*pbtag.UpdateTagResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id 不能为空")
+	}
+	tag, err := h.ta.UpdateTag(ctx, &dto.Tag{
+		ID:          uint(req.Id),
+		Name:        req.Name,
+		Aliases:     req.Aliases,
+		ParentID:    uint(req.ParentID),
+		Description: req.Description,
+		Status:      req.Status,
+	})
+	if err != nil {
+		return nil, tagError("UpdateTag", err)
+	}
+	return &pbtag.UpdateTagResponse{Tag: tagToPb(tag)}, nil
+}
+
+// DeleteTag 删除标签
+func (h *tagGRPCHandler) DeleteTag(ctx context.Context, req *pbtag.DeleteTagRequest) (*pbtag.DeleteTagResponse, error) {
+	if req.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id 不能为空")
+	}
+	if err := h.ta.DelTag(ctx, uint(req.Id)); err != nil {
+		return nil, tagError("DeleteTag", err)
+	}
+	return &pbtag.DeleteTagResponse{Inform: "删除标签成功"}, nil
+}
+
+// GetTag 查询标签
+func (h *tagGRPCHandler) GetTag(ctx context.Context, req *pbtag.GetTagRequest) (*pbtag.GetTagResponse, error) {
+	tag, err := h.ta.GetTag(uint(req.Id))
+	if err != nil {
+		return nil, tagError("GetTag", err)
+	}
+	return &pbtag.GetTagResponse{Tag: tagToPb(tag)}, nil
+}
+
+// ListTags 查询标签列表
+func (h *tagGRPCHandler) ListTags(ctx context.Context, req *pbtag.ListTagsRequest) (*pbtag.ListTagsResponse, error) {
+	tags, err := h.ta.ListTags(req.Status)
+	if err != nil {
+		return nil, tagError("ListTags", err)
+	}
+	res := &pbtag.ListTagsResponse{
+		Tags: make([]*pbtag.Tag, len(tags)),
+	}
+	for i := range tags {
+		res.Tags[i] = tagToPb(&tags[i])
+	}
+	return res, nil
+}
+
+// NormalizeTags 将标签映射到标准标签
+func (h *tagGRPCHandler) NormalizeTags(ctx context.Context, req *pbtag.NormalizeTagsRequest) (*pbtag.NormalizeTagsResponse, error) {
+	mapping := h.ta.NormalizeTags(ctx, req.Tags)
+	return &pbtag.NormalizeTagsResponse{
+		Tags:        mapping.Tags,
+		Suggestions: mapping.Suggestions,
+	}, nil
+}
+
+// tagError 将领域错误转换为对应的 gRPC 状态码，其余错误记录日志后原样返回
+func tagError(method string, err error) error {
+	switch {
+	case errors.Is(err, persistence.ErrTagNotFound):
+		return status.Error(codes.NotFound, "标签不存在")
+	case errors.Is(err, service.ErrInvalidTag):
+		return status.Error(codes.InvalidArgument, "标签名称不能为空且不能超过 64 个字符，状态只能是 active 或 suggested")
+	case errors.Is(err, service.ErrTagConflict):
+		return status.Error(codes.AlreadyExists, service.ErrTagConflict.Error())
+	case errors.Is(err, service.ErrTagCycle):
+		return status.Error(codes.FailedPrecondition, "父标签不存在或形成环")
+	}
+	zap.L().Error(method+" -> ", zap.Error(err))
+	return err
+}
+
+// tagToPb 封装数据
+func tagToPb(tag *dto.Tag) *pbtag.Tag {
+	return &pbtag.Tag{
+		Id:           uint32(tag.ID),
+		Name:         tag.Name,
+		Aliases:      tag.Aliases,
+		ParentID:     uint32(tag.ParentID),
+		Description:  tag.Description,
+		Status:       tag.Status,
+		SuggestCount: uint32(tag.SuggestCount),
+	}
+}
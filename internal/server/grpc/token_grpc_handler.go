@@ -32,6 +32,8 @@ func NewTokenGRPCHandler(cfg config.Config) pbToken.TokenServiceServer {
 func (h *tokenGRPCHandler) GenerateToken(ctx context.Context, req *pbToken.TokenRequest) (resp *pbToken.TokenResponse, err error) {
 	req1 := dto.TokenReq{
 		GenerateTokenKey: req.GenerateTokenKey,
+		Client:           req.Client,
+		UserID:           uint(req.UserId),
 	}
 
 	resp1, err := h.app.GenerateToken(&req1)
@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"siwuai/internal/app"
+	appimpl "siwuai/internal/app/impl"
+	"siwuai/internal/domain/model/dto"
+	serviceimpl "siwuai/internal/domain/service/impl"
+	"siwuai/internal/infrastructure/config"
+	persistenceimpl "siwuai/internal/infrastructure/persistence/impl"
+	pbusage "siwuai/proto/usage"
+	"slices"
+	"time"
+)
+
+// usageGroupBy 支持的分组维度
+var usageGroupBy = []string{"user", "ai_code", "model", "day"}
+
+type usageGRPCHandler struct {
+	pbusage.UnimplementedUsageServiceServer
+	uc app.UsageApp
+}
+
+// NewUsageGRPCHandler 构造方法
+func NewUsageGRPCHandler(db *gorm.DB, cfg config.Config) pbusage.UsageServiceServer {
+	repo := persistenceimpl.NewMySQLUsageRepository(db)
+	ds := serviceimpl.NewUsageDomainService(repo, cfg)
+	uc := appimpl.NewUsageApp(ds)
+	return &usageGRPCHandler{uc: uc}
+}
+
+// GetUsage 按用户、功能、模型、日期汇总大模型的 token 用量和费用
+func (h *usageGRPCHandler) GetUsage(ctx context.Context, req *pbusage.GetUsageRequest) (*pbusage.GetUsageResponse, error) {
+	for _, day := range []string{req.StartDay, req.EndDay} {
+		if day == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, day); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "日期格式错误: %s", day)
+		}
+	}
+
+	for _, group := range req.GroupBy {
+		if !slices.Contains(usageGroupBy, group) {
+			return nil, status.Errorf(codes.InvalidArgument, "不支持的分组维度: %s", group)
+		}
+	}
+
+	query := &dto.UsageQuery{
+		AICode:   req.AiCode,
+		Model:    req.Model,
+		StartDay: req.StartDay,
+		EndDay:   req.EndDay,
+		GroupBy:  req.GroupBy,
+	}
+	if req.UserID != nil {
+		userID := uint(*req.UserID)
+		query.UserID = &userID
+	}
+
+	stats, total, err := h.uc.GetUsage(ctx, query)
+	if err != nil {
+		zap.L().Error("GetUsage() ", zap.Error(err))
+		return nil, err
+	}
+
+	resp := &pbusage.GetUsageResponse{
+		Stats: make([]*pbusage.UsageStat, len(stats)),
+		Total: usageStatToPb(total),
+	}
+	for i, stat := range stats {
+		resp.Stats[i] = usageStatToPb(stat)
+	}
+	return resp, nil
+}
+
+func usageStatToPb(stat dto.UsageStat) *pbusage.UsageStat {
+	return &pbusage.UsageStat{
+		UserID:           uint32(stat.UserID),
+		AiCode:           stat.AICode,
+		Model:            stat.Model,
+		Day:              stat.Day,
+		Calls:            stat.Calls,
+		PromptTokens:     stat.PromptTokens,
+		CompletionTokens: stat.CompletionTokens,
+		TotalTokens:      stat.PromptTokens + stat.CompletionTokens,
+		Cost:             stat.Cost,
+	}
+}
@@ -2,22 +2,40 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"siwuai/internal/app"
+	appimpl "siwuai/internal/app/impl"
 	"siwuai/internal/domain/model/dto"
+	"siwuai/internal/domain/service"
 	"siwuai/internal/infrastructure/config"
 	"siwuai/internal/infrastructure/constant"
+	"siwuai/internal/infrastructure/llm"
 	"siwuai/internal/infrastructure/utils"
 	pbVector "siwuai/proto/vector"
+	"slices"
 )
 
+// maxVectorIDLen 调用方ID的最大长度，与数据库列的长度一致
+const maxVectorIDLen = 64
+
+// vectorTypes 向量存储支持的内容类型
+var vectorTypes = []string{dto.VectorTypeArticle, dto.VectorTypeQuestion, dto.VectorTypeCode}
+
 type VectorGrpcHandler struct {
 	pbVector.UnimplementedVectorServiceServer
-	cfg config.Config
+	cfg      config.Config
+	provider llm.LLMProvider
+	va       app.VectorApp
 }
 
-func NewVectorGrpcHandler(cfg config.Config) *VectorGrpcHandler {
+func NewVectorGrpcHandler(cfg config.Config, provider llm.LLMProvider, vectors service.VectorDomainService) *VectorGrpcHandler {
 	return &VectorGrpcHandler{
-		cfg: cfg,
+		cfg:      cfg,
+		provider: provider,
+		va:       appimpl.NewVectorApp(vectors),
 	}
 }
 
@@ -30,7 +48,7 @@ func (v *VectorGrpcHandler) GetVector(ctx context.Context, req *pbVector.GetVect
 	}
 
 	// 调用ai生成向量
-	vector, err := utils.GenerateVector(constant.QuestionVectorCode, vectorPrompt, v.cfg)
+	vector, err := utils.GenerateVector(ctx, v.provider, constant.QuestionVectorCode, vectorPrompt)
 	if err != nil {
 		zap.L().Error("AI 生成向量失败", zap.Error(err))
 		return nil, err
@@ -47,3 +65,99 @@ func (v *VectorGrpcHandler) GetVector(ctx context.Context, req *pbVector.GetVect
 	}
 	return resp, nil
 }
+
+// UpsertVector 保存内容的向量
+func (v *VectorGrpcHandler) UpsertVector(ctx context.Context, req *pbVector.UpsertVectorRequest) (*pbVector.UpsertVectorResponse, error) {
+	if err := checkVectorDoc(req.Type, req.Id); err != nil {
+		return nil, err
+	}
+
+	doc := &dto.VectorDoc{
+		Type:    req.Type,
+		ID:      req.Id,
+		Content: req.Content,
+		Tags:    req.Tags,
+		Vector:  req.Vector,
+	}
+	if err := v.va.UpsertVector(ctx, doc); err != nil {
+		zap.L().Error("UpsertVector() ", zap.Error(err))
+		return nil, vectorError(err)
+	}
+	return &pbVector.UpsertVectorResponse{Inform: "保存向量成功"}, nil
+}
+
+// DeleteVector 删除内容的向量
+func (v *VectorGrpcHandler) DeleteVector(ctx context.Context, req *pbVector.DeleteVectorRequest) (*pbVector.DeleteVectorResponse, error) {
+	if err := checkVectorDoc(req.Type, req.Id); err != nil {
+		return nil, err
+	}
+
+	deleted, err := v.va.DelVector(ctx, req.Type, req.Id)
+	if err != nil {
+		zap.L().Error("DeleteVector() ", zap.Error(err))
+		return nil, err
+	}
+	if !deleted {
+		return nil, status.Errorf(codes.NotFound, "向量不存在: %s/%s", req.Type, req.Id)
+	}
+	return &pbVector.DeleteVectorResponse{Inform: "删除向量成功"}, nil
+}
+
+// QueryVector 按文本或向量检索最相似的内容
+func (v *VectorGrpcHandler) QueryVector(ctx context.Context, req *pbVector.QueryVectorRequest) (*pbVector.QueryVectorResponse, error) {
+	if req.Text == "" && len(req.Vector) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "text 和 vector 不能同时为空")
+	}
+	for _, t := range req.Types {
+		if !slices.Contains(vectorTypes, t) {
+			return nil, status.Errorf(codes.InvalidArgument, "不支持的类型: %s", t)
+		}
+	}
+
+	query := &dto.VectorQuery{
+		Text:     req.Text,
+		Vector:   req.Vector,
+		TopK:     int(req.TopK),
+		Types:    req.Types,
+		Tags:     req.Tags,
+		MinScore: req.MinScore,
+	}
+	hits, err := v.va.QueryVector(ctx, query)
+	if err != nil {
+		zap.L().Error("QueryVector() ", zap.Error(err))
+		return nil, vectorError(err)
+	}
+
+	resp := &pbVector.QueryVectorResponse{
+		Hits: make([]*pbVector.VectorHit, len(hits)),
+	}
+	for i, hit := range hits {
+		resp.Hits[i] = &pbVector.VectorHit{
+			Type:    hit.Type,
+			Id:      hit.ID,
+			Content: hit.Content,
+			Tags:    hit.Tags,
+			Score:   hit.Score,
+		}
+	}
+	return resp, nil
+}
+
+// checkVectorDoc 校验内容的类型和ID
+func checkVectorDoc(docType, docID string) error {
+	if !slices.Contains(vectorTypes, docType) {
+		return status.Errorf(codes.InvalidArgument, "不支持的类型: %s", docType)
+	}
+	if docID == "" || len(docID) > maxVectorIDLen {
+		return status.Errorf(codes.InvalidArgument, "id 不能为空且长度不能超过 %d", maxVectorIDLen)
+	}
+	return nil
+}
+
+// vectorError 将向量无效的错误转换为 InvalidArgument，其余错误原样返回
+func vectorError(err error) error {
+	if errors.Is(err, service.ErrInvalidVector) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
@@ -21,11 +21,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 流式事件类型
+type ArticleEventType int32
+
+const (
+	ArticleEventType_ARTICLE_EVENT_UNSPECIFIED ArticleEventType = 0
+	ArticleEventType_ARTICLE_EVENT_ABSTRACT    ArticleEventType = 1 // 摘要的增量内容
+	ArticleEventType_ARTICLE_EVENT_SUMMARY     ArticleEventType = 2 // 总结的增量内容
+	ArticleEventType_ARTICLE_EVENT_TAGS        ArticleEventType = 3 // 匹配的标签
+	ArticleEventType_ARTICLE_EVENT_DONE        ArticleEventType = 4 // 生成结束，result 为最终结果
+)
+
+// Enum value maps for ArticleEventType.
+var (
+	ArticleEventType_name = map[int32]string{
+		0: "ARTICLE_EVENT_UNSPECIFIED",
+		1: "ARTICLE_EVENT_ABSTRACT",
+		2: "ARTICLE_EVENT_SUMMARY",
+		3: "ARTICLE_EVENT_TAGS",
+		4: "ARTICLE_EVENT_DONE",
+	}
+	ArticleEventType_value = map[string]int32{
+		"ARTICLE_EVENT_UNSPECIFIED": 0,
+		"ARTICLE_EVENT_ABSTRACT":    1,
+		"ARTICLE_EVENT_SUMMARY":     2,
+		"ARTICLE_EVENT_TAGS":        3,
+		"ARTICLE_EVENT_DONE":        4,
+	}
+)
+
+func (x ArticleEventType) Enum() *ArticleEventType {
+	p := new(ArticleEventType)
+	*p = x
+	return p
+}
+
+func (x ArticleEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArticleEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_article_proto_enumTypes[0].Descriptor()
+}
+
+func (ArticleEventType) Type() protoreflect.EnumType {
+	return &file_article_proto_enumTypes[0]
+}
+
+func (x ArticleEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArticleEventType.Descriptor instead.
+func (ArticleEventType) EnumDescriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{0}
+}
+
 type GetArticleInfoFirstRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`      // 文章的全部内容
 	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`            // 所有标签, 用于给文章匹配相应的标签
 	ArticleID     uint32                 `protobuf:"varint,3,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`    // 输出语言，如 zh、en、ja，为空时使用中文
+	WithSEO       bool                   `protobuf:"varint,5,opt,name=withSEO,proto3" json:"withSEO,omitempty"`     // 为 true 时同时生成 SEO 标题、meta description、URL slug 和 Open Graph 文案
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,12 +139,31 @@ func (x *GetArticleInfoFirstRequest) GetArticleID() uint32 {
 	return 0
 }
 
+func (x *GetArticleInfoFirstRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetArticleInfoFirstRequest) GetWithSEO() bool {
+	if x != nil {
+		return x.WithSEO
+	}
+	return false
+}
+
 type GetArticleInfoFirstResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`           // hash值
-	Abstract      string                 `protobuf:"bytes,3,opt,name=abstract,proto3" json:"abstract,omitempty"` // 文章的摘要
-	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`   // 文章的总结
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`         // 与文章相匹配的标签
+	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`                     // hash值
+	Abstract      string                 `protobuf:"bytes,3,opt,name=abstract,proto3" json:"abstract,omitempty"`           // 文章的摘要
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`             // 文章的总结
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                   // 与文章相匹配的标签
+	Confidence    float64                `protobuf:"fixed64,5,opt,name=confidence,proto3" json:"confidence,omitempty"`     // 模型对摘要和总结的置信度(0~1)
+	Model         string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`                 // 生成摘要和总结的模型
+	SuggestedTags []string               `protobuf:"bytes,7,rep,name=suggestedTags,proto3" json:"suggestedTags,omitempty"` // 标签体系中没有匹配的标签，已记录为待审核的建议标签
+	Language      string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`           // 摘要和总结使用的语言
+	Seo           *ArticleSEO            `protobuf:"bytes,9,opt,name=seo,proto3" json:"seo,omitempty"`                     // SEO 信息，未请求或生成失败时为空
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,28 +226,67 @@ func (x *GetArticleInfoFirstResponse) GetTags() []string {
 	return nil
 }
 
-type SaveArticleIDRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`              // hash值
-	ArticleID     uint32                 `protobuf:"varint,2,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetArticleInfoFirstResponse) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
 }
 
-func (x *SaveArticleIDRequest) Reset() {
-	*x = SaveArticleIDRequest{}
+func (x *GetArticleInfoFirstResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetArticleInfoFirstResponse) GetSuggestedTags() []string {
+	if x != nil {
+		return x.SuggestedTags
+	}
+	return nil
+}
+
+func (x *GetArticleInfoFirstResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetArticleInfoFirstResponse) GetSeo() *ArticleSEO {
+	if x != nil {
+		return x.Seo
+	}
+	return nil
+}
+
+// 文章的 SEO 信息，长度限制由服务端校验
+type ArticleSEO struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SeoTitle        string                 `protobuf:"bytes,1,opt,name=seoTitle,proto3" json:"seoTitle,omitempty"`               // 搜索结果中显示的标题，不超过 60 个字符
+	MetaDescription string                 `protobuf:"bytes,2,opt,name=metaDescription,proto3" json:"metaDescription,omitempty"` // meta description，不超过 160 个字符
+	Slug            string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`                       // URL slug，只包含小写字母、数字和连字符，不超过 80 个字符
+	OgTitle         string                 `protobuf:"bytes,4,opt,name=ogTitle,proto3" json:"ogTitle,omitempty"`                 // Open Graph 标题，不超过 90 个字符
+	OgDescription   string                 `protobuf:"bytes,5,opt,name=ogDescription,proto3" json:"ogDescription,omitempty"`     // Open Graph 描述，不超过 200 个字符
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ArticleSEO) Reset() {
+	*x = ArticleSEO{}
 	mi := &file_article_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveArticleIDRequest) String() string {
+func (x *ArticleSEO) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveArticleIDRequest) ProtoMessage() {}
+func (*ArticleSEO) ProtoMessage() {}
 
-func (x *SaveArticleIDRequest) ProtoReflect() protoreflect.Message {
+func (x *ArticleSEO) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -182,46 +298,70 @@ func (x *SaveArticleIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveArticleIDRequest.ProtoReflect.Descriptor instead.
-func (*SaveArticleIDRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArticleSEO.ProtoReflect.Descriptor instead.
+func (*ArticleSEO) Descriptor() ([]byte, []int) {
 	return file_article_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SaveArticleIDRequest) GetKey() string {
+func (x *ArticleSEO) GetSeoTitle() string {
 	if x != nil {
-		return x.Key
+		return x.SeoTitle
 	}
 	return ""
 }
 
-func (x *SaveArticleIDRequest) GetArticleID() uint32 {
+func (x *ArticleSEO) GetMetaDescription() string {
 	if x != nil {
-		return x.ArticleID
+		return x.MetaDescription
 	}
-	return 0
+	return ""
 }
 
-type SaveArticleIDResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+func (x *ArticleSEO) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ArticleSEO) GetOgTitle() string {
+	if x != nil {
+		return x.OgTitle
+	}
+	return ""
+}
+
+func (x *ArticleSEO) GetOgDescription() string {
+	if x != nil {
+		return x.OgDescription
+	}
+	return ""
+}
+
+type ArticleInfoEvent struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Type          ArticleEventType             `protobuf:"varint,1,opt,name=type,proto3,enum=article.ArticleEventType" json:"type,omitempty"` // 事件类型
+	Text          string                       `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                // 摘要或总结的增量内容，拼接后为完整内容
+	Tags          []string                     `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`                                // 匹配的标签
+	Result        *GetArticleInfoFirstResponse `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`                            // 最终结果，以此为准
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveArticleIDResponse) Reset() {
-	*x = SaveArticleIDResponse{}
+func (x *ArticleInfoEvent) Reset() {
+	*x = ArticleInfoEvent{}
 	mi := &file_article_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveArticleIDResponse) String() string {
+func (x *ArticleInfoEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveArticleIDResponse) ProtoMessage() {}
+func (*ArticleInfoEvent) ProtoMessage() {}
 
-func (x *SaveArticleIDResponse) ProtoReflect() protoreflect.Message {
+func (x *ArticleInfoEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -233,40 +373,61 @@ func (x *SaveArticleIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveArticleIDResponse.ProtoReflect.Descriptor instead.
-func (*SaveArticleIDResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArticleInfoEvent.ProtoReflect.Descriptor instead.
+func (*ArticleInfoEvent) Descriptor() ([]byte, []int) {
 	return file_article_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SaveArticleIDResponse) GetInform() string {
+func (x *ArticleInfoEvent) GetType() ArticleEventType {
 	if x != nil {
-		return x.Inform
+		return x.Type
+	}
+	return ArticleEventType_ARTICLE_EVENT_UNSPECIFIED
+}
+
+func (x *ArticleInfoEvent) GetText() string {
+	if x != nil {
+		return x.Text
 	}
 	return ""
 }
 
-type GetArticleInfoRequest struct {
+func (x *ArticleInfoEvent) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ArticleInfoEvent) GetResult() *GetArticleInfoFirstResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SaveArticleIDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
-	UserID        uint32                 `protobuf:"varint,2,opt,name=userID,proto3" json:"userID,omitempty"`       // 用户ID
+	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`              // hash值
+	ArticleID     uint32                 `protobuf:"varint,2,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetArticleInfoRequest) Reset() {
-	*x = GetArticleInfoRequest{}
+func (x *SaveArticleIDRequest) Reset() {
+	*x = SaveArticleIDRequest{}
 	mi := &file_article_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetArticleInfoRequest) String() string {
+func (x *SaveArticleIDRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetArticleInfoRequest) ProtoMessage() {}
+func (*SaveArticleIDRequest) ProtoMessage() {}
 
-func (x *GetArticleInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *SaveArticleIDRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -278,48 +439,46 @@ func (x *GetArticleInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetArticleInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetArticleInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SaveArticleIDRequest.ProtoReflect.Descriptor instead.
+func (*SaveArticleIDRequest) Descriptor() ([]byte, []int) {
 	return file_article_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetArticleInfoRequest) GetArticleID() uint32 {
+func (x *SaveArticleIDRequest) GetKey() string {
 	if x != nil {
-		return x.ArticleID
+		return x.Key
 	}
-	return 0
+	return ""
 }
 
-func (x *GetArticleInfoRequest) GetUserID() uint32 {
+func (x *SaveArticleIDRequest) GetArticleID() uint32 {
 	if x != nil {
-		return x.UserID
+		return x.ArticleID
 	}
 	return 0
 }
 
-type GetArticleInfoResponse struct {
+type SaveArticleIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Summary       string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`   // 文章的摘要
-	Abstract      string                 `protobuf:"bytes,2,opt,name=abstract,proto3" json:"abstract,omitempty"` // 文章的总结
-	Codes         []*Code                `protobuf:"bytes,3,rep,name=codes,proto3" json:"codes,omitempty"`
+	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetArticleInfoResponse) Reset() {
-	*x = GetArticleInfoResponse{}
+func (x *SaveArticleIDResponse) Reset() {
+	*x = SaveArticleIDResponse{}
 	mi := &file_article_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetArticleInfoResponse) String() string {
+func (x *SaveArticleIDResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetArticleInfoResponse) ProtoMessage() {}
+func (*SaveArticleIDResponse) ProtoMessage() {}
 
-func (x *GetArticleInfoResponse) ProtoReflect() protoreflect.Message {
+func (x *SaveArticleIDResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -331,54 +490,44 @@ func (x *GetArticleInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetArticleInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetArticleInfoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SaveArticleIDResponse.ProtoReflect.Descriptor instead.
+func (*SaveArticleIDResponse) Descriptor() ([]byte, []int) {
 	return file_article_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetArticleInfoResponse) GetSummary() string {
-	if x != nil {
-		return x.Summary
-	}
-	return ""
-}
-
-func (x *GetArticleInfoResponse) GetAbstract() string {
+func (x *SaveArticleIDResponse) GetInform() string {
 	if x != nil {
-		return x.Abstract
+		return x.Inform
 	}
 	return ""
 }
 
-func (x *GetArticleInfoResponse) GetCodes() []*Code {
-	if x != nil {
-		return x.Codes
-	}
-	return nil
-}
-
-type Code struct {
+type UpdateArticleInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Question      string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`       // 代码提问
-	Explanation   string                 `protobuf:"bytes,2,opt,name=explanation,proto3" json:"explanation,omitempty"` // 代码解释
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID，必须已经保存过
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`      // 修改后文章的全部内容
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`            // 所有标签, 重新生成时用于给文章匹配相应的标签
+	Force         bool                   `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`         // 为 true 时不论改动大小都重新生成摘要和总结，并清除编辑人工修改的内容
+	Language      string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`    // 输出语言，为空时使用中文
+	WithSEO       bool                   `protobuf:"varint,6,opt,name=withSEO,proto3" json:"withSEO,omitempty"`     // 为 true 时为没有 SEO 信息的新版本生成 SEO 信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Code) Reset() {
-	*x = Code{}
+func (x *UpdateArticleInfoRequest) Reset() {
+	*x = UpdateArticleInfoRequest{}
 	mi := &file_article_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Code) String() string {
+func (x *UpdateArticleInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Code) ProtoMessage() {}
+func (*UpdateArticleInfoRequest) ProtoMessage() {}
 
-func (x *Code) ProtoReflect() protoreflect.Message {
+func (x *UpdateArticleInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,46 +539,76 @@ func (x *Code) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Code.ProtoReflect.Descriptor instead.
-func (*Code) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateArticleInfoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateArticleInfoRequest) Descriptor() ([]byte, []int) {
 	return file_article_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Code) GetQuestion() string {
+func (x *UpdateArticleInfoRequest) GetArticleID() uint32 {
 	if x != nil {
-		return x.Question
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *UpdateArticleInfoRequest) GetContent() string {
+	if x != nil {
+		return x.Content
 	}
 	return ""
 }
 
-func (x *Code) GetExplanation() string {
+func (x *UpdateArticleInfoRequest) GetTags() []string {
 	if x != nil {
-		return x.Explanation
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateArticleInfoRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *UpdateArticleInfoRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
 	}
 	return ""
 }
 
-type DelArticleInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+func (x *UpdateArticleInfoRequest) GetWithSEO() bool {
+	if x != nil {
+		return x.WithSEO
+	}
+	return false
+}
+
+type UpdateArticleInfoResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Article       *GetArticleInfoFirstResponse `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`           // 更新后的文章信息
+	Regenerated   bool                         `protobuf:"varint,2,opt,name=regenerated,proto3" json:"regenerated,omitempty"`  // 是否重新生成了摘要和总结
+	ChangeRatio   float64                      `protobuf:"fixed64,3,opt,name=changeRatio,proto3" json:"changeRatio,omitempty"` // 与上一版内容相比的改动比例(0~1)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DelArticleInfoRequest) Reset() {
-	*x = DelArticleInfoRequest{}
+func (x *UpdateArticleInfoResponse) Reset() {
+	*x = UpdateArticleInfoResponse{}
 	mi := &file_article_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DelArticleInfoRequest) String() string {
+func (x *UpdateArticleInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DelArticleInfoRequest) ProtoMessage() {}
+func (*UpdateArticleInfoResponse) ProtoMessage() {}
 
-func (x *DelArticleInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateArticleInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,39 +620,55 @@ func (x *DelArticleInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DelArticleInfoRequest.ProtoReflect.Descriptor instead.
-func (*DelArticleInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateArticleInfoResponse.ProtoReflect.Descriptor instead.
+func (*UpdateArticleInfoResponse) Descriptor() ([]byte, []int) {
 	return file_article_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DelArticleInfoRequest) GetArticleID() uint32 {
+func (x *UpdateArticleInfoResponse) GetArticle() *GetArticleInfoFirstResponse {
 	if x != nil {
-		return x.ArticleID
+		return x.Article
+	}
+	return nil
+}
+
+func (x *UpdateArticleInfoResponse) GetRegenerated() bool {
+	if x != nil {
+		return x.Regenerated
+	}
+	return false
+}
+
+func (x *UpdateArticleInfoResponse) GetChangeRatio() float64 {
+	if x != nil {
+		return x.ChangeRatio
 	}
 	return 0
 }
 
-type DelArticleInfoResponse struct {
+type GetArticleInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	UserID        uint32                 `protobuf:"varint,2,opt,name=userID,proto3" json:"userID,omitempty"`       // 用户ID
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`    // 摘要和总结的语言，为空时使用中文
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DelArticleInfoResponse) Reset() {
-	*x = DelArticleInfoResponse{}
+func (x *GetArticleInfoRequest) Reset() {
+	*x = GetArticleInfoRequest{}
 	mi := &file_article_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DelArticleInfoResponse) String() string {
+func (x *GetArticleInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DelArticleInfoResponse) ProtoMessage() {}
+func (*GetArticleInfoRequest) ProtoMessage() {}
 
-func (x *DelArticleInfoResponse) ProtoReflect() protoreflect.Message {
+func (x *GetArticleInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_article_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -485,56 +680,1397 @@ func (x *DelArticleInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use GetArticleInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetArticleInfoRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetArticleInfoRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *GetArticleInfoRequest) GetUserID() uint32 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *GetArticleInfoRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type GetArticleInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`   // 文章的摘要
+	Abstract      string                 `protobuf:"bytes,2,opt,name=abstract,proto3" json:"abstract,omitempty"` // 文章的总结
+	Codes         []*Code                `protobuf:"bytes,3,rep,name=codes,proto3" json:"codes,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                // 文章的标签
+	HumanEdited   bool                   `protobuf:"varint,5,opt,name=humanEdited,proto3" json:"humanEdited,omitempty"` // 摘要、总结或标签中是否有编辑人工修改的内容
+	Language      string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`        // 摘要和总结使用的语言
+	Seo           *ArticleSEO            `protobuf:"bytes,7,opt,name=seo,proto3" json:"seo,omitempty"`                  // SEO 信息，没有生成时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArticleInfoResponse) Reset() {
+	*x = GetArticleInfoResponse{}
+	mi := &file_article_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArticleInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArticleInfoResponse) ProtoMessage() {}
+
+func (x *GetArticleInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArticleInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetArticleInfoResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetArticleInfoResponse) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *GetArticleInfoResponse) GetAbstract() string {
+	if x != nil {
+		return x.Abstract
+	}
+	return ""
+}
+
+func (x *GetArticleInfoResponse) GetCodes() []*Code {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *GetArticleInfoResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetArticleInfoResponse) GetHumanEdited() bool {
+	if x != nil {
+		return x.HumanEdited
+	}
+	return false
+}
+
+func (x *GetArticleInfoResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetArticleInfoResponse) GetSeo() *ArticleSEO {
+	if x != nil {
+		return x.Seo
+	}
+	return nil
+}
+
+type Code struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Question      string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`       // 代码提问
+	Explanation   string                 `protobuf:"bytes,2,opt,name=explanation,proto3" json:"explanation,omitempty"` // 代码解释
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Code) Reset() {
+	*x = Code{}
+	mi := &file_article_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Code) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Code) ProtoMessage() {}
+
+func (x *Code) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Code.ProtoReflect.Descriptor instead.
+func (*Code) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Code) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Code) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type DelArticleInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DelArticleInfoRequest) Reset() {
+	*x = DelArticleInfoRequest{}
+	mi := &file_article_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelArticleInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelArticleInfoRequest) ProtoMessage() {}
+
+func (x *DelArticleInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelArticleInfoRequest.ProtoReflect.Descriptor instead.
+func (*DelArticleInfoRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DelArticleInfoRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+type DelArticleInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DelArticleInfoResponse) Reset() {
+	*x = DelArticleInfoResponse{}
+	mi := &file_article_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelArticleInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelArticleInfoResponse) ProtoMessage() {}
+
+func (x *DelArticleInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
 // Deprecated: Use DelArticleInfoResponse.ProtoReflect.Descriptor instead.
 func (*DelArticleInfoResponse) Descriptor() ([]byte, []int) {
-	return file_article_proto_rawDescGZIP(), []int{8}
+	return file_article_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DelArticleInfoResponse) GetInform() string {
+	if x != nil {
+		return x.Inform
+	}
+	return ""
+}
+
+type GetRelatedArticlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	K             int32                  `protobuf:"varint,2,opt,name=k,proto3" json:"k,omitempty"`                 // 返回的数量，不大于 0 时为 5，最多 20
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedArticlesRequest) Reset() {
+	*x = GetRelatedArticlesRequest{}
+	mi := &file_article_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedArticlesRequest) ProtoMessage() {}
+
+func (x *GetRelatedArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedArticlesRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRelatedArticlesRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *GetRelatedArticlesRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+type RelatedArticle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"`    // 文章ID
+	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`           // 综合得分，按从高到低排序
+	Similarity    float32                `protobuf:"fixed32,3,opt,name=similarity,proto3" json:"similarity,omitempty"` // 摘要和总结的余弦相似度
+	CommonTags    []string               `protobuf:"bytes,4,rep,name=commonTags,proto3" json:"commonTags,omitempty"`   // 两篇文章共同的标签
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedArticle) Reset() {
+	*x = RelatedArticle{}
+	mi := &file_article_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedArticle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedArticle) ProtoMessage() {}
+
+func (x *RelatedArticle) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedArticle.ProtoReflect.Descriptor instead.
+func (*RelatedArticle) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RelatedArticle) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *RelatedArticle) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RelatedArticle) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *RelatedArticle) GetCommonTags() []string {
+	if x != nil {
+		return x.CommonTags
+	}
+	return nil
+}
+
+type GetRelatedArticlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Articles      []*RelatedArticle      `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedArticlesResponse) Reset() {
+	*x = GetRelatedArticlesResponse{}
+	mi := &file_article_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedArticlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedArticlesResponse) ProtoMessage() {}
+
+func (x *GetRelatedArticlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedArticlesResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedArticlesResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRelatedArticlesResponse) GetArticles() []*RelatedArticle {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+type ArticleVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VersionID     uint32                 `protobuf:"varint,1,opt,name=versionID,proto3" json:"versionID,omitempty"`        // 版本ID
+	Key           string                 `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`                     // 生成时文章内容的 hash值
+	Abstract      string                 `protobuf:"bytes,3,opt,name=abstract,proto3" json:"abstract,omitempty"`           // 文章的摘要
+	Summary       string                 `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`             // 文章的总结
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                   // 与文章相匹配的标签
+	Confidence    float64                `protobuf:"fixed64,6,opt,name=confidence,proto3" json:"confidence,omitempty"`     // 模型对摘要和总结的置信度(0~1)
+	Model         string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`                 // 生成摘要和总结的模型
+	PromptVersion string                 `protobuf:"bytes,8,opt,name=promptVersion,proto3" json:"promptVersion,omitempty"` // 使用的提示词模板版本
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`        // 生成时间，Unix 时间戳(秒)
+	Current       bool                   `protobuf:"varint,10,opt,name=current,proto3" json:"current,omitempty"`           // 是否为文章当前使用的版本
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArticleVersion) Reset() {
+	*x = ArticleVersion{}
+	mi := &file_article_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArticleVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticleVersion) ProtoMessage() {}
+
+func (x *ArticleVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticleVersion.ProtoReflect.Descriptor instead.
+func (*ArticleVersion) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ArticleVersion) GetVersionID() uint32 {
+	if x != nil {
+		return x.VersionID
+	}
+	return 0
+}
+
+func (x *ArticleVersion) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ArticleVersion) GetAbstract() string {
+	if x != nil {
+		return x.Abstract
+	}
+	return ""
+}
+
+func (x *ArticleVersion) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ArticleVersion) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ArticleVersion) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ArticleVersion) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ArticleVersion) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *ArticleVersion) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ArticleVersion) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListArticleVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArticleVersionsRequest) Reset() {
+	*x = ListArticleVersionsRequest{}
+	mi := &file_article_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArticleVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticleVersionsRequest) ProtoMessage() {}
+
+func (x *ListArticleVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticleVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListArticleVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListArticleVersionsRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+type ListArticleVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*ArticleVersion      `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArticleVersionsResponse) Reset() {
+	*x = ListArticleVersionsResponse{}
+	mi := &file_article_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArticleVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticleVersionsResponse) ProtoMessage() {}
+
+func (x *ListArticleVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticleVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListArticleVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListArticleVersionsResponse) GetVersions() []*ArticleVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type GetArticleVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	VersionID     uint32                 `protobuf:"varint,2,opt,name=versionID,proto3" json:"versionID,omitempty"` // 版本ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArticleVersionRequest) Reset() {
+	*x = GetArticleVersionRequest{}
+	mi := &file_article_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArticleVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArticleVersionRequest) ProtoMessage() {}
+
+func (x *GetArticleVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArticleVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetArticleVersionRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetArticleVersionRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *GetArticleVersionRequest) GetVersionID() uint32 {
+	if x != nil {
+		return x.VersionID
+	}
+	return 0
+}
+
+type GetArticleVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       *ArticleVersion        `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetArticleVersionResponse) Reset() {
+	*x = GetArticleVersionResponse{}
+	mi := &file_article_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArticleVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArticleVersionResponse) ProtoMessage() {}
+
+func (x *GetArticleVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetArticleVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetArticleVersionResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetArticleVersionResponse) GetVersion() *ArticleVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+type PinArticleVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	VersionID     uint32                 `protobuf:"varint,2,opt,name=versionID,proto3" json:"versionID,omitempty"` // 版本ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinArticleVersionRequest) Reset() {
+	*x = PinArticleVersionRequest{}
+	mi := &file_article_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinArticleVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinArticleVersionRequest) ProtoMessage() {}
+
+func (x *PinArticleVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinArticleVersionRequest.ProtoReflect.Descriptor instead.
+func (*PinArticleVersionRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PinArticleVersionRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *PinArticleVersionRequest) GetVersionID() uint32 {
+	if x != nil {
+		return x.VersionID
+	}
+	return 0
+}
+
+type PinArticleVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       *ArticleVersion        `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"` // 设为当前使用的版本
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinArticleVersionResponse) Reset() {
+	*x = PinArticleVersionResponse{}
+	mi := &file_article_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinArticleVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinArticleVersionResponse) ProtoMessage() {}
+
+func (x *PinArticleVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinArticleVersionResponse.ProtoReflect.Descriptor instead.
+func (*PinArticleVersionResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PinArticleVersionResponse) GetVersion() *ArticleVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+type SaveArticleOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	Abstract      string                 `protobuf:"bytes,2,opt,name=abstract,proto3" json:"abstract,omitempty"`    // 修改后的摘要，为空时沿用模型生成的摘要
+	Summary       string                 `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`      // 修改后的总结，为空时沿用模型生成的总结
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`            // 修改后的标签，为空时沿用模型匹配的标签
+	UserID        uint32                 `protobuf:"varint,5,opt,name=userID,proto3" json:"userID,omitempty"`       // 修改的用户ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveArticleOverrideRequest) Reset() {
+	*x = SaveArticleOverrideRequest{}
+	mi := &file_article_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveArticleOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveArticleOverrideRequest) ProtoMessage() {}
+
+func (x *SaveArticleOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveArticleOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SaveArticleOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SaveArticleOverrideRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *SaveArticleOverrideRequest) GetAbstract() string {
+	if x != nil {
+		return x.Abstract
+	}
+	return ""
 }
 
-func (x *DelArticleInfoResponse) GetInform() string {
+func (x *SaveArticleOverrideRequest) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *SaveArticleOverrideRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SaveArticleOverrideRequest) GetUserID() uint32 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+type SaveArticleOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveArticleOverrideResponse) Reset() {
+	*x = SaveArticleOverrideResponse{}
+	mi := &file_article_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveArticleOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveArticleOverrideResponse) ProtoMessage() {}
+
+func (x *SaveArticleOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveArticleOverrideResponse.ProtoReflect.Descriptor instead.
+func (*SaveArticleOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SaveArticleOverrideResponse) GetInform() string {
 	if x != nil {
 		return x.Inform
 	}
 	return ""
 }
 
+type ListArticlesByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`          // 标签，别名会映射为标准标签
+	AfterID       uint32                 `protobuf:"varint,2,opt,name=afterID,proto3" json:"afterID,omitempty"` // 上一页最后一篇文章的ID，第一页为 0
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`     // 每页数量，不大于 0 时默认 20，最多 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArticlesByTagRequest) Reset() {
+	*x = ListArticlesByTagRequest{}
+	mi := &file_article_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArticlesByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticlesByTagRequest) ProtoMessage() {}
+
+func (x *ListArticlesByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticlesByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListArticlesByTagRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListArticlesByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListArticlesByTagRequest) GetAfterID() uint32 {
+	if x != nil {
+		return x.AfterID
+	}
+	return 0
+}
+
+func (x *ListArticlesByTagRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListArticlesByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`                       // 实际查询的标签
+	ArticleIDs    []uint32               `protobuf:"varint,2,rep,packed,name=articleIDs,proto3" json:"articleIDs,omitempty"` // 文章ID，从小到大排序，少于 limit 时表示没有下一页
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArticlesByTagResponse) Reset() {
+	*x = ListArticlesByTagResponse{}
+	mi := &file_article_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArticlesByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArticlesByTagResponse) ProtoMessage() {}
+
+func (x *ListArticlesByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArticlesByTagResponse.ProtoReflect.Descriptor instead.
+func (*ListArticlesByTagResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListArticlesByTagResponse) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListArticlesByTagResponse) GetArticleIDs() []uint32 {
+	if x != nil {
+		return x.ArticleIDs
+	}
+	return nil
+}
+
+type TranslateArticleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArticleID     uint32                 `protobuf:"varint,1,opt,name=articleID,proto3" json:"articleID,omitempty"` // 文章ID
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`      // 文章的全部内容(markdown)
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`    // 目标语言，如 en、ja
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranslateArticleRequest) Reset() {
+	*x = TranslateArticleRequest{}
+	mi := &file_article_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslateArticleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateArticleRequest) ProtoMessage() {}
+
+func (x *TranslateArticleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateArticleRequest.ProtoReflect.Descriptor instead.
+func (*TranslateArticleRequest) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TranslateArticleRequest) GetArticleID() uint32 {
+	if x != nil {
+		return x.ArticleID
+	}
+	return 0
+}
+
+func (x *TranslateArticleRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *TranslateArticleRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type TranslateArticleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`   // 译文的一段，按顺序拼接后为完整译文
+	Metadata      *TranslationMetadata   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"` // 翻译信息，只在最后一条消息中返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranslateArticleResponse) Reset() {
+	*x = TranslateArticleResponse{}
+	mi := &file_article_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslateArticleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslateArticleResponse) ProtoMessage() {}
+
+func (x *TranslateArticleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslateArticleResponse.ProtoReflect.Descriptor instead.
+func (*TranslateArticleResponse) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TranslateArticleResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *TranslateArticleResponse) GetMetadata() *TranslationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type TranslationMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`           // 原文内容的 hash 值
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"` // 目标语言
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`       // 翻译使用的模型
+	Cached        bool                   `protobuf:"varint,4,opt,name=cached,proto3" json:"cached,omitempty"`    // 是否为已保存的译文
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranslationMetadata) Reset() {
+	*x = TranslationMetadata{}
+	mi := &file_article_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslationMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationMetadata) ProtoMessage() {}
+
+func (x *TranslationMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_article_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationMetadata.ProtoReflect.Descriptor instead.
+func (*TranslationMetadata) Descriptor() ([]byte, []int) {
+	return file_article_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TranslationMetadata) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TranslationMetadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *TranslationMetadata) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TranslationMetadata) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
 var File_article_proto protoreflect.FileDescriptor
 
 const file_article_proto_rawDesc = "" +
 	"\n" +
-	"\rarticle.proto\x12\aarticle\"h\n" +
+	"\rarticle.proto\x12\aarticle\"\x9e\x01\n" +
 	"\x1aGetArticleInfoFirstRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x12\n" +
 	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x1c\n" +
-	"\tarticleID\x18\x03 \x01(\rR\tarticleID\"y\n" +
+	"\tarticleID\x18\x03 \x01(\rR\tarticleID\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\x18\n" +
+	"\awithSEO\x18\x05 \x01(\bR\awithSEO\"\x98\x02\n" +
 	"\x1bGetArticleInfoFirstResponse\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x1a\n" +
 	"\babstract\x18\x03 \x01(\tR\babstract\x12\x18\n" +
 	"\asummary\x18\x02 \x01(\tR\asummary\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"F\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x05 \x01(\x01R\n" +
+	"confidence\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\x12$\n" +
+	"\rsuggestedTags\x18\a \x03(\tR\rsuggestedTags\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12%\n" +
+	"\x03seo\x18\t \x01(\v2\x13.article.ArticleSEOR\x03seo\"\xa6\x01\n" +
+	"\n" +
+	"ArticleSEO\x12\x1a\n" +
+	"\bseoTitle\x18\x01 \x01(\tR\bseoTitle\x12(\n" +
+	"\x0fmetaDescription\x18\x02 \x01(\tR\x0fmetaDescription\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x18\n" +
+	"\aogTitle\x18\x04 \x01(\tR\aogTitle\x12$\n" +
+	"\rogDescription\x18\x05 \x01(\tR\rogDescription\"\xa7\x01\n" +
+	"\x10ArticleInfoEvent\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.article.ArticleEventTypeR\x04type\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12<\n" +
+	"\x06result\x18\x04 \x01(\v2$.article.GetArticleInfoFirstResponseR\x06result\"F\n" +
 	"\x14SaveArticleIDRequest\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x1c\n" +
 	"\tarticleID\x18\x02 \x01(\rR\tarticleID\"/\n" +
 	"\x15SaveArticleIDResponse\x12\x16\n" +
-	"\x06inform\x18\x01 \x01(\tR\x06inform\"M\n" +
+	"\x06inform\x18\x01 \x01(\tR\x06inform\"\xb2\x01\n" +
+	"\x18UpdateArticleInfoRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12\x18\n" +
+	"\awithSEO\x18\x06 \x01(\bR\awithSEO\"\x9f\x01\n" +
+	"\x19UpdateArticleInfoResponse\x12>\n" +
+	"\aarticle\x18\x01 \x01(\v2$.article.GetArticleInfoFirstResponseR\aarticle\x12 \n" +
+	"\vregenerated\x18\x02 \x01(\bR\vregenerated\x12 \n" +
+	"\vchangeRatio\x18\x03 \x01(\x01R\vchangeRatio\"i\n" +
 	"\x15GetArticleInfoRequest\x12\x1c\n" +
 	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x16\n" +
-	"\x06userID\x18\x02 \x01(\rR\x06userID\"s\n" +
+	"\x06userID\x18\x02 \x01(\rR\x06userID\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\"\xec\x01\n" +
 	"\x16GetArticleInfoResponse\x12\x18\n" +
 	"\asummary\x18\x01 \x01(\tR\asummary\x12\x1a\n" +
 	"\babstract\x18\x02 \x01(\tR\babstract\x12#\n" +
-	"\x05codes\x18\x03 \x03(\v2\r.article.CodeR\x05codes\"D\n" +
+	"\x05codes\x18\x03 \x03(\v2\r.article.CodeR\x05codes\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12 \n" +
+	"\vhumanEdited\x18\x05 \x01(\bR\vhumanEdited\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12%\n" +
+	"\x03seo\x18\a \x01(\v2\x13.article.ArticleSEOR\x03seo\"D\n" +
 	"\x04Code\x12\x1a\n" +
 	"\bquestion\x18\x01 \x01(\tR\bquestion\x12 \n" +
 	"\vexplanation\x18\x02 \x01(\tR\vexplanation\"5\n" +
 	"\x15DelArticleInfoRequest\x12\x1c\n" +
 	"\tarticleID\x18\x01 \x01(\rR\tarticleID\"0\n" +
 	"\x16DelArticleInfoResponse\x12\x16\n" +
-	"\x06inform\x18\x01 \x01(\tR\x06inform2\xe8\x02\n" +
+	"\x06inform\x18\x01 \x01(\tR\x06inform\"G\n" +
+	"\x19GetRelatedArticlesRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\f\n" +
+	"\x01k\x18\x02 \x01(\x05R\x01k\"\x84\x01\n" +
+	"\x0eRelatedArticle\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x03 \x01(\x02R\n" +
+	"similarity\x12\x1e\n" +
+	"\n" +
+	"commonTags\x18\x04 \x03(\tR\n" +
+	"commonTags\"Q\n" +
+	"\x1aGetRelatedArticlesResponse\x123\n" +
+	"\barticles\x18\x01 \x03(\v2\x17.article.RelatedArticleR\barticles\"\x9e\x02\n" +
+	"\x0eArticleVersion\x12\x1c\n" +
+	"\tversionID\x18\x01 \x01(\rR\tversionID\x12\x10\n" +
+	"\x03Key\x18\x02 \x01(\tR\x03Key\x12\x1a\n" +
+	"\babstract\x18\x03 \x01(\tR\babstract\x12\x18\n" +
+	"\asummary\x18\x04 \x01(\tR\asummary\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x06 \x01(\x01R\n" +
+	"confidence\x12\x14\n" +
+	"\x05model\x18\a \x01(\tR\x05model\x12$\n" +
+	"\rpromptVersion\x18\b \x01(\tR\rpromptVersion\x12\x1c\n" +
+	"\tcreatedAt\x18\t \x01(\x03R\tcreatedAt\x12\x18\n" +
+	"\acurrent\x18\n" +
+	" \x01(\bR\acurrent\":\n" +
+	"\x1aListArticleVersionsRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\"R\n" +
+	"\x1bListArticleVersionsResponse\x123\n" +
+	"\bversions\x18\x01 \x03(\v2\x17.article.ArticleVersionR\bversions\"V\n" +
+	"\x18GetArticleVersionRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x1c\n" +
+	"\tversionID\x18\x02 \x01(\rR\tversionID\"N\n" +
+	"\x19GetArticleVersionResponse\x121\n" +
+	"\aversion\x18\x01 \x01(\v2\x17.article.ArticleVersionR\aversion\"V\n" +
+	"\x18PinArticleVersionRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x1c\n" +
+	"\tversionID\x18\x02 \x01(\rR\tversionID\"N\n" +
+	"\x19PinArticleVersionResponse\x121\n" +
+	"\aversion\x18\x01 \x01(\v2\x17.article.ArticleVersionR\aversion\"\x9c\x01\n" +
+	"\x1aSaveArticleOverrideRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x1a\n" +
+	"\babstract\x18\x02 \x01(\tR\babstract\x12\x18\n" +
+	"\asummary\x18\x03 \x01(\tR\asummary\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06userID\x18\x05 \x01(\rR\x06userID\"5\n" +
+	"\x1bSaveArticleOverrideResponse\x12\x16\n" +
+	"\x06inform\x18\x01 \x01(\tR\x06inform\"\\\n" +
+	"\x18ListArticlesByTagRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x18\n" +
+	"\aafterID\x18\x02 \x01(\rR\aafterID\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"M\n" +
+	"\x19ListArticlesByTagResponse\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1e\n" +
+	"\n" +
+	"articleIDs\x18\x02 \x03(\rR\n" +
+	"articleIDs\"m\n" +
+	"\x17TranslateArticleRequest\x12\x1c\n" +
+	"\tarticleID\x18\x01 \x01(\rR\tarticleID\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\"n\n" +
+	"\x18TranslateArticleResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x128\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1c.article.TranslationMetadataR\bmetadata\"q\n" +
+	"\x13TranslationMetadata\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x16\n" +
+	"\x06cached\x18\x04 \x01(\bR\x06cached*\x98\x01\n" +
+	"\x10ArticleEventType\x12\x1d\n" +
+	"\x19ARTICLE_EVENT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ARTICLE_EVENT_ABSTRACT\x10\x01\x12\x19\n" +
+	"\x15ARTICLE_EVENT_SUMMARY\x10\x02\x12\x16\n" +
+	"\x12ARTICLE_EVENT_TAGS\x10\x03\x12\x16\n" +
+	"\x12ARTICLE_EVENT_DONE\x10\x042\xb5\t\n" +
 	"\x0earticleService\x12`\n" +
-	"\x13GetArticleInfoFirst\x12#.article.GetArticleInfoFirstRequest\x1a$.article.GetArticleInfoFirstResponse\x12N\n" +
-	"\rSaveArticleID\x12\x1d.article.SaveArticleIDRequest\x1a\x1e.article.SaveArticleIDResponse\x12Q\n" +
+	"\x13GetArticleInfoFirst\x12#.article.GetArticleInfoFirstRequest\x1a$.article.GetArticleInfoFirstResponse\x12]\n" +
+	"\x19GetArticleInfoFirstStream\x12#.article.GetArticleInfoFirstRequest\x1a\x19.article.ArticleInfoEvent0\x01\x12N\n" +
+	"\rSaveArticleID\x12\x1d.article.SaveArticleIDRequest\x1a\x1e.article.SaveArticleIDResponse\x12Z\n" +
+	"\x11UpdateArticleInfo\x12!.article.UpdateArticleInfoRequest\x1a\".article.UpdateArticleInfoResponse\x12Q\n" +
 	"\x0eGetArticleInfo\x12\x1e.article.GetArticleInfoRequest\x1a\x1f.article.GetArticleInfoResponse\x12Q\n" +
-	"\x0eDelArticleInfo\x12\x1e.article.DelArticleInfoRequest\x1a\x1f.article.DelArticleInfoResponseB\x16Z\x14siwuai/proto/articleb\x06proto3"
+	"\x0eDelArticleInfo\x12\x1e.article.DelArticleInfoRequest\x1a\x1f.article.DelArticleInfoResponse\x12]\n" +
+	"\x12GetRelatedArticles\x12\".article.GetRelatedArticlesRequest\x1a#.article.GetRelatedArticlesResponse\x12`\n" +
+	"\x13ListArticleVersions\x12#.article.ListArticleVersionsRequest\x1a$.article.ListArticleVersionsResponse\x12Z\n" +
+	"\x11GetArticleVersion\x12!.article.GetArticleVersionRequest\x1a\".article.GetArticleVersionResponse\x12Z\n" +
+	"\x11PinArticleVersion\x12!.article.PinArticleVersionRequest\x1a\".article.PinArticleVersionResponse\x12`\n" +
+	"\x13SaveArticleOverride\x12#.article.SaveArticleOverrideRequest\x1a$.article.SaveArticleOverrideResponse\x12Z\n" +
+	"\x11ListArticlesByTag\x12!.article.ListArticlesByTagRequest\x1a\".article.ListArticlesByTagResponse\x12Y\n" +
+	"\x10TranslateArticle\x12 .article.TranslateArticleRequest\x1a!.article.TranslateArticleResponse0\x01B\x16Z\x14siwuai/proto/articleb\x06proto3"
 
 var (
 	file_article_proto_rawDescOnce sync.Once
@@ -548,33 +2084,84 @@ func file_article_proto_rawDescGZIP() []byte {
 	return file_article_proto_rawDescData
 }
 
-var file_article_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_article_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_article_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_article_proto_goTypes = []any{
-	(*GetArticleInfoFirstRequest)(nil),  // 0: article.GetArticleInfoFirstRequest
-	(*GetArticleInfoFirstResponse)(nil), // 1: article.GetArticleInfoFirstResponse
-	(*SaveArticleIDRequest)(nil),        // 2: article.SaveArticleIDRequest
-	(*SaveArticleIDResponse)(nil),       // 3: article.SaveArticleIDResponse
-	(*GetArticleInfoRequest)(nil),       // 4: article.GetArticleInfoRequest
-	(*GetArticleInfoResponse)(nil),      // 5: article.GetArticleInfoResponse
-	(*Code)(nil),                        // 6: article.Code
-	(*DelArticleInfoRequest)(nil),       // 7: article.DelArticleInfoRequest
-	(*DelArticleInfoResponse)(nil),      // 8: article.DelArticleInfoResponse
+	(ArticleEventType)(0),               // 0: article.ArticleEventType
+	(*GetArticleInfoFirstRequest)(nil),  // 1: article.GetArticleInfoFirstRequest
+	(*GetArticleInfoFirstResponse)(nil), // 2: article.GetArticleInfoFirstResponse
+	(*ArticleSEO)(nil),                  // 3: article.ArticleSEO
+	(*ArticleInfoEvent)(nil),            // 4: article.ArticleInfoEvent
+	(*SaveArticleIDRequest)(nil),        // 5: article.SaveArticleIDRequest
+	(*SaveArticleIDResponse)(nil),       // 6: article.SaveArticleIDResponse
+	(*UpdateArticleInfoRequest)(nil),    // 7: article.UpdateArticleInfoRequest
+	(*UpdateArticleInfoResponse)(nil),   // 8: article.UpdateArticleInfoResponse
+	(*GetArticleInfoRequest)(nil),       // 9: article.GetArticleInfoRequest
+	(*GetArticleInfoResponse)(nil),      // 10: article.GetArticleInfoResponse
+	(*Code)(nil),                        // 11: article.Code
+	(*DelArticleInfoRequest)(nil),       // 12: article.DelArticleInfoRequest
+	(*DelArticleInfoResponse)(nil),      // 13: article.DelArticleInfoResponse
+	(*GetRelatedArticlesRequest)(nil),   // 14: article.GetRelatedArticlesRequest
+	(*RelatedArticle)(nil),              // 15: article.RelatedArticle
+	(*GetRelatedArticlesResponse)(nil),  // 16: article.GetRelatedArticlesResponse
+	(*ArticleVersion)(nil),              // 17: article.ArticleVersion
+	(*ListArticleVersionsRequest)(nil),  // 18: article.ListArticleVersionsRequest
+	(*ListArticleVersionsResponse)(nil), // 19: article.ListArticleVersionsResponse
+	(*GetArticleVersionRequest)(nil),    // 20: article.GetArticleVersionRequest
+	(*GetArticleVersionResponse)(nil),   // 21: article.GetArticleVersionResponse
+	(*PinArticleVersionRequest)(nil),    // 22: article.PinArticleVersionRequest
+	(*PinArticleVersionResponse)(nil),   // 23: article.PinArticleVersionResponse
+	(*SaveArticleOverrideRequest)(nil),  // 24: article.SaveArticleOverrideRequest
+	(*SaveArticleOverrideResponse)(nil), // 25: article.SaveArticleOverrideResponse
+	(*ListArticlesByTagRequest)(nil),    // 26: article.ListArticlesByTagRequest
+	(*ListArticlesByTagResponse)(nil),   // 27: article.ListArticlesByTagResponse
+	(*TranslateArticleRequest)(nil),     // 28: article.TranslateArticleRequest
+	(*TranslateArticleResponse)(nil),    // 29: article.TranslateArticleResponse
+	(*TranslationMetadata)(nil),         // 30: article.TranslationMetadata
 }
 var file_article_proto_depIdxs = []int32{
-	6, // 0: article.GetArticleInfoResponse.codes:type_name -> article.Code
-	0, // 1: article.articleService.GetArticleInfoFirst:input_type -> article.GetArticleInfoFirstRequest
-	2, // 2: article.articleService.SaveArticleID:input_type -> article.SaveArticleIDRequest
-	4, // 3: article.articleService.GetArticleInfo:input_type -> article.GetArticleInfoRequest
-	7, // 4: article.articleService.DelArticleInfo:input_type -> article.DelArticleInfoRequest
-	1, // 5: article.articleService.GetArticleInfoFirst:output_type -> article.GetArticleInfoFirstResponse
-	3, // 6: article.articleService.SaveArticleID:output_type -> article.SaveArticleIDResponse
-	5, // 7: article.articleService.GetArticleInfo:output_type -> article.GetArticleInfoResponse
-	8, // 8: article.articleService.DelArticleInfo:output_type -> article.DelArticleInfoResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3,  // 0: article.GetArticleInfoFirstResponse.seo:type_name -> article.ArticleSEO
+	0,  // 1: article.ArticleInfoEvent.type:type_name -> article.ArticleEventType
+	2,  // 2: article.ArticleInfoEvent.result:type_name -> article.GetArticleInfoFirstResponse
+	2,  // 3: article.UpdateArticleInfoResponse.article:type_name -> article.GetArticleInfoFirstResponse
+	11, // 4: article.GetArticleInfoResponse.codes:type_name -> article.Code
+	3,  // 5: article.GetArticleInfoResponse.seo:type_name -> article.ArticleSEO
+	15, // 6: article.GetRelatedArticlesResponse.articles:type_name -> article.RelatedArticle
+	17, // 7: article.ListArticleVersionsResponse.versions:type_name -> article.ArticleVersion
+	17, // 8: article.GetArticleVersionResponse.version:type_name -> article.ArticleVersion
+	17, // 9: article.PinArticleVersionResponse.version:type_name -> article.ArticleVersion
+	30, // 10: article.TranslateArticleResponse.metadata:type_name -> article.TranslationMetadata
+	1,  // 11: article.articleService.GetArticleInfoFirst:input_type -> article.GetArticleInfoFirstRequest
+	1,  // 12: article.articleService.GetArticleInfoFirstStream:input_type -> article.GetArticleInfoFirstRequest
+	5,  // 13: article.articleService.SaveArticleID:input_type -> article.SaveArticleIDRequest
+	7,  // 14: article.articleService.UpdateArticleInfo:input_type -> article.UpdateArticleInfoRequest
+	9,  // 15: article.articleService.GetArticleInfo:input_type -> article.GetArticleInfoRequest
+	12, // 16: article.articleService.DelArticleInfo:input_type -> article.DelArticleInfoRequest
+	14, // 17: article.articleService.GetRelatedArticles:input_type -> article.GetRelatedArticlesRequest
+	18, // 18: article.articleService.ListArticleVersions:input_type -> article.ListArticleVersionsRequest
+	20, // 19: article.articleService.GetArticleVersion:input_type -> article.GetArticleVersionRequest
+	22, // 20: article.articleService.PinArticleVersion:input_type -> article.PinArticleVersionRequest
+	24, // 21: article.articleService.SaveArticleOverride:input_type -> article.SaveArticleOverrideRequest
+	26, // 22: article.articleService.ListArticlesByTag:input_type -> article.ListArticlesByTagRequest
+	28, // 23: article.articleService.TranslateArticle:input_type -> article.TranslateArticleRequest
+	2,  // 24: article.articleService.GetArticleInfoFirst:output_type -> article.GetArticleInfoFirstResponse
+	4,  // 25: article.articleService.GetArticleInfoFirstStream:output_type -> article.ArticleInfoEvent
+	6,  // 26: article.articleService.SaveArticleID:output_type -> article.SaveArticleIDResponse
+	8,  // 27: article.articleService.UpdateArticleInfo:output_type -> article.UpdateArticleInfoResponse
+	10, // 28: article.articleService.GetArticleInfo:output_type -> article.GetArticleInfoResponse
+	13, // 29: article.articleService.DelArticleInfo:output_type -> article.DelArticleInfoResponse
+	16, // 30: article.articleService.GetRelatedArticles:output_type -> article.GetRelatedArticlesResponse
+	19, // 31: article.articleService.ListArticleVersions:output_type -> article.ListArticleVersionsResponse
+	21, // 32: article.articleService.GetArticleVersion:output_type -> article.GetArticleVersionResponse
+	23, // 33: article.articleService.PinArticleVersion:output_type -> article.PinArticleVersionResponse
+	25, // 34: article.articleService.SaveArticleOverride:output_type -> article.SaveArticleOverrideResponse
+	27, // 35: article.articleService.ListArticlesByTag:output_type -> article.ListArticlesByTagResponse
+	29, // 36: article.articleService.TranslateArticle:output_type -> article.TranslateArticleResponse
+	24, // [24:37] is the sub-list for method output_type
+	11, // [11:24] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_article_proto_init() }
@@ -587,13 +2174,14 @@ func file_article_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_article_proto_rawDesc), len(file_article_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      1,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_article_proto_goTypes,
 		DependencyIndexes: file_article_proto_depIdxs,
+		EnumInfos:         file_article_proto_enumTypes,
 		MessageInfos:      file_article_proto_msgTypes,
 	}.Build()
 	File_article_proto = out.File
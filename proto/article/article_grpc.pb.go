@@ -19,10 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ArticleService_GetArticleInfoFirst_FullMethodName = "/article.articleService/GetArticleInfoFirst"
-	ArticleService_SaveArticleID_FullMethodName       = "/article.articleService/SaveArticleID"
-	ArticleService_GetArticleInfo_FullMethodName      = "/article.articleService/GetArticleInfo"
-	ArticleService_DelArticleInfo_FullMethodName      = "/article.articleService/DelArticleInfo"
+	ArticleService_GetArticleInfoFirst_FullMethodName       = "/article.articleService/GetArticleInfoFirst"
+	ArticleService_GetArticleInfoFirstStream_FullMethodName = "/article.articleService/GetArticleInfoFirstStream"
+	ArticleService_SaveArticleID_FullMethodName             = "/article.articleService/SaveArticleID"
+	ArticleService_UpdateArticleInfo_FullMethodName         = "/article.articleService/UpdateArticleInfo"
+	ArticleService_GetArticleInfo_FullMethodName            = "/article.articleService/GetArticleInfo"
+	ArticleService_DelArticleInfo_FullMethodName            = "/article.articleService/DelArticleInfo"
+	ArticleService_GetRelatedArticles_FullMethodName        = "/article.articleService/GetRelatedArticles"
+	ArticleService_ListArticleVersions_FullMethodName       = "/article.articleService/ListArticleVersions"
+	ArticleService_GetArticleVersion_FullMethodName         = "/article.articleService/GetArticleVersion"
+	ArticleService_PinArticleVersion_FullMethodName         = "/article.articleService/PinArticleVersion"
+	ArticleService_SaveArticleOverride_FullMethodName       = "/article.articleService/SaveArticleOverride"
+	ArticleService_ListArticlesByTag_FullMethodName         = "/article.articleService/ListArticlesByTag"
+	ArticleService_TranslateArticle_FullMethodName          = "/article.articleService/TranslateArticle"
 )
 
 // ArticleServiceClient is the client API for ArticleService service.
@@ -31,12 +40,30 @@ const (
 type ArticleServiceClient interface {
 	// 第一次获取文章的摘要、总结、标签
 	GetArticleInfoFirst(ctx context.Context, in *GetArticleInfoFirstRequest, opts ...grpc.CallOption) (*GetArticleInfoFirstResponse, error)
+	// 流式获取文章的摘要、总结、标签，边生成边返回，最后返回完整结果
+	GetArticleInfoFirstStream(ctx context.Context, in *GetArticleInfoFirstRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArticleInfoEvent], error)
 	// 将文章的ID保存到相应的记录中
 	SaveArticleID(ctx context.Context, in *SaveArticleIDRequest, opts ...grpc.CallOption) (*SaveArticleIDResponse, error)
+	// 文章内容修改后更新文章信息，改动较小时沿用原有的摘要和总结
+	UpdateArticleInfo(ctx context.Context, in *UpdateArticleInfoRequest, opts ...grpc.CallOption) (*UpdateArticleInfoResponse, error)
 	// 非首次获取文章的摘要、总结、标签
 	GetArticleInfo(ctx context.Context, in *GetArticleInfoRequest, opts ...grpc.CallOption) (*GetArticleInfoResponse, error)
 	// 删除文章相关信息
 	DelArticleInfo(ctx context.Context, in *DelArticleInfoRequest, opts ...grpc.CallOption) (*DelArticleInfoResponse, error)
+	// 获取相关文章，按摘要和总结的相似度与标签重合度排序
+	GetRelatedArticles(ctx context.Context, in *GetRelatedArticlesRequest, opts ...grpc.CallOption) (*GetRelatedArticlesResponse, error)
+	// 查询文章的全部摘要和总结版本，按生成时间从新到旧排序
+	ListArticleVersions(ctx context.Context, in *ListArticleVersionsRequest, opts ...grpc.CallOption) (*ListArticleVersionsResponse, error)
+	// 查询文章的指定版本
+	GetArticleVersion(ctx context.Context, in *GetArticleVersionRequest, opts ...grpc.CallOption) (*GetArticleVersionResponse, error)
+	// 将指定版本设为文章当前使用的摘要和总结
+	PinArticleVersion(ctx context.Context, in *PinArticleVersionRequest, opts ...grpc.CallOption) (*PinArticleVersionResponse, error)
+	// 保存编辑人工修改的摘要、总结和标签，优先于模型生成的结果
+	SaveArticleOverride(ctx context.Context, in *SaveArticleOverrideRequest, opts ...grpc.CallOption) (*SaveArticleOverrideResponse, error)
+	// 按标签分页查询文章ID
+	ListArticlesByTag(ctx context.Context, in *ListArticlesByTagRequest, opts ...grpc.CallOption) (*ListArticlesByTagResponse, error)
+	// 将文章翻译为指定语言，front matter、代码块、行内代码和链接原样保留，逐段返回译文，最后一条消息携带翻译信息
+	TranslateArticle(ctx context.Context, in *TranslateArticleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslateArticleResponse], error)
 }
 
 type articleServiceClient struct {
@@ -57,6 +84,25 @@ func (c *articleServiceClient) GetArticleInfoFirst(ctx context.Context, in *GetA
 	return out, nil
 }
 
+func (c *articleServiceClient) GetArticleInfoFirstStream(ctx context.Context, in *GetArticleInfoFirstRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArticleInfoEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArticleService_ServiceDesc.Streams[0], ArticleService_GetArticleInfoFirstStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetArticleInfoFirstRequest, ArticleInfoEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArticleService_GetArticleInfoFirstStreamClient = grpc.ServerStreamingClient[ArticleInfoEvent]
+
 func (c *articleServiceClient) SaveArticleID(ctx context.Context, in *SaveArticleIDRequest, opts ...grpc.CallOption) (*SaveArticleIDResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SaveArticleIDResponse)
@@ -67,6 +113,16 @@ func (c *articleServiceClient) SaveArticleID(ctx context.Context, in *SaveArticl
 	return out, nil
 }
 
+func (c *articleServiceClient) UpdateArticleInfo(ctx context.Context, in *UpdateArticleInfoRequest, opts ...grpc.CallOption) (*UpdateArticleInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateArticleInfoResponse)
+	err := c.cc.Invoke(ctx, ArticleService_UpdateArticleInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *articleServiceClient) GetArticleInfo(ctx context.Context, in *GetArticleInfoRequest, opts ...grpc.CallOption) (*GetArticleInfoResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetArticleInfoResponse)
@@ -87,18 +143,115 @@ func (c *articleServiceClient) DelArticleInfo(ctx context.Context, in *DelArticl
 	return out, nil
 }
 
+func (c *articleServiceClient) GetRelatedArticles(ctx context.Context, in *GetRelatedArticlesRequest, opts ...grpc.CallOption) (*GetRelatedArticlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedArticlesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetRelatedArticles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListArticleVersions(ctx context.Context, in *ListArticleVersionsRequest, opts ...grpc.CallOption) (*ListArticleVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArticleVersionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListArticleVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetArticleVersion(ctx context.Context, in *GetArticleVersionRequest, opts ...grpc.CallOption) (*GetArticleVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArticleVersionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetArticleVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) PinArticleVersion(ctx context.Context, in *PinArticleVersionRequest, opts ...grpc.CallOption) (*PinArticleVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinArticleVersionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_PinArticleVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) SaveArticleOverride(ctx context.Context, in *SaveArticleOverrideRequest, opts ...grpc.CallOption) (*SaveArticleOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveArticleOverrideResponse)
+	err := c.cc.Invoke(ctx, ArticleService_SaveArticleOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListArticlesByTag(ctx context.Context, in *ListArticlesByTagRequest, opts ...grpc.CallOption) (*ListArticlesByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArticlesByTagResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListArticlesByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) TranslateArticle(ctx context.Context, in *TranslateArticleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslateArticleResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ArticleService_ServiceDesc.Streams[1], ArticleService_TranslateArticle_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TranslateArticleRequest, TranslateArticleResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArticleService_TranslateArticleClient = grpc.ServerStreamingClient[TranslateArticleResponse]
+
 // ArticleServiceServer is the server API for ArticleService service.
 // All implementations must embed UnimplementedArticleServiceServer
 // for forward compatibility.
 type ArticleServiceServer interface {
 	// 第一次获取文章的摘要、总结、标签
 	GetArticleInfoFirst(context.Context, *GetArticleInfoFirstRequest) (*GetArticleInfoFirstResponse, error)
+	// 流式获取文章的摘要、总结、标签，边生成边返回，最后返回完整结果
+	GetArticleInfoFirstStream(*GetArticleInfoFirstRequest, grpc.ServerStreamingServer[ArticleInfoEvent]) error
 	// 将文章的ID保存到相应的记录中
 	SaveArticleID(context.Context, *SaveArticleIDRequest) (*SaveArticleIDResponse, error)
+	// 文章内容修改后更新文章信息，改动较小时沿用原有的摘要和总结
+	UpdateArticleInfo(context.Context, *UpdateArticleInfoRequest) (*UpdateArticleInfoResponse, error)
 	// 非首次获取文章的摘要、总结、标签
 	GetArticleInfo(context.Context, *GetArticleInfoRequest) (*GetArticleInfoResponse, error)
 	// 删除文章相关信息
 	DelArticleInfo(context.Context, *DelArticleInfoRequest) (*DelArticleInfoResponse, error)
+	// 获取相关文章，按摘要和总结的相似度与标签重合度排序
+	GetRelatedArticles(context.Context, *GetRelatedArticlesRequest) (*GetRelatedArticlesResponse, error)
+	// 查询文章的全部摘要和总结版本，按生成时间从新到旧排序
+	ListArticleVersions(context.Context, *ListArticleVersionsRequest) (*ListArticleVersionsResponse, error)
+	// 查询文章的指定版本
+	GetArticleVersion(context.Context, *GetArticleVersionRequest) (*GetArticleVersionResponse, error)
+	// 将指定版本设为文章当前使用的摘要和总结
+	PinArticleVersion(context.Context, *PinArticleVersionRequest) (*PinArticleVersionResponse, error)
+	// 保存编辑人工修改的摘要、总结和标签，优先于模型生成的结果
+	SaveArticleOverride(context.Context, *SaveArticleOverrideRequest) (*SaveArticleOverrideResponse, error)
+	// 按标签分页查询文章ID
+	ListArticlesByTag(context.Context, *ListArticlesByTagRequest) (*ListArticlesByTagResponse, error)
+	// 将文章翻译为指定语言，front matter、代码块、行内代码和链接原样保留，逐段返回译文，最后一条消息携带翻译信息
+	TranslateArticle(*TranslateArticleRequest, grpc.ServerStreamingServer[TranslateArticleResponse]) error
 	mustEmbedUnimplementedArticleServiceServer()
 }
 
@@ -112,15 +265,42 @@ type UnimplementedArticleServiceServer struct{}
 func (UnimplementedArticleServiceServer) GetArticleInfoFirst(context.Context, *GetArticleInfoFirstRequest) (*GetArticleInfoFirstResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetArticleInfoFirst not implemented")
 }
+func (UnimplementedArticleServiceServer) GetArticleInfoFirstStream(*GetArticleInfoFirstRequest, grpc.ServerStreamingServer[ArticleInfoEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method GetArticleInfoFirstStream not implemented")
+}
 func (UnimplementedArticleServiceServer) SaveArticleID(context.Context, *SaveArticleIDRequest) (*SaveArticleIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveArticleID not implemented")
 }
+func (UnimplementedArticleServiceServer) UpdateArticleInfo(context.Context, *UpdateArticleInfoRequest) (*UpdateArticleInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateArticleInfo not implemented")
+}
 func (UnimplementedArticleServiceServer) GetArticleInfo(context.Context, *GetArticleInfoRequest) (*GetArticleInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetArticleInfo not implemented")
 }
 func (UnimplementedArticleServiceServer) DelArticleInfo(context.Context, *DelArticleInfoRequest) (*DelArticleInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DelArticleInfo not implemented")
 }
+func (UnimplementedArticleServiceServer) GetRelatedArticles(context.Context, *GetRelatedArticlesRequest) (*GetRelatedArticlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedArticles not implemented")
+}
+func (UnimplementedArticleServiceServer) ListArticleVersions(context.Context, *ListArticleVersionsRequest) (*ListArticleVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArticleVersions not implemented")
+}
+func (UnimplementedArticleServiceServer) GetArticleVersion(context.Context, *GetArticleVersionRequest) (*GetArticleVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArticleVersion not implemented")
+}
+func (UnimplementedArticleServiceServer) PinArticleVersion(context.Context, *PinArticleVersionRequest) (*PinArticleVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinArticleVersion not implemented")
+}
+func (UnimplementedArticleServiceServer) SaveArticleOverride(context.Context, *SaveArticleOverrideRequest) (*SaveArticleOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveArticleOverride not implemented")
+}
+func (UnimplementedArticleServiceServer) ListArticlesByTag(context.Context, *ListArticlesByTagRequest) (*ListArticlesByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArticlesByTag not implemented")
+}
+func (UnimplementedArticleServiceServer) TranslateArticle(*TranslateArticleRequest, grpc.ServerStreamingServer[TranslateArticleResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method TranslateArticle not implemented")
+}
 func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
 func (UnimplementedArticleServiceServer) testEmbeddedByValue()                        {}
 
@@ -160,6 +340,17 @@ func _ArticleService_GetArticleInfoFirst_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_GetArticleInfoFirstStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetArticleInfoFirstRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArticleServiceServer).GetArticleInfoFirstStream(m, &grpc.GenericServerStream[GetArticleInfoFirstRequest, ArticleInfoEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArticleService_GetArticleInfoFirstStreamServer = grpc.ServerStreamingServer[ArticleInfoEvent]
+
 func _ArticleService_SaveArticleID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SaveArticleIDRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +369,24 @@ func _ArticleService_SaveArticleID_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_UpdateArticleInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateArticleInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).UpdateArticleInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_UpdateArticleInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).UpdateArticleInfo(ctx, req.(*UpdateArticleInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArticleService_GetArticleInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetArticleInfoRequest)
 	if err := dec(in); err != nil {
@@ -214,6 +423,125 @@ func _ArticleService_DelArticleInfo_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_GetRelatedArticles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedArticlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetRelatedArticles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetRelatedArticles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetRelatedArticles(ctx, req.(*GetRelatedArticlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListArticleVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArticleVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListArticleVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListArticleVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListArticleVersions(ctx, req.(*ListArticleVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetArticleVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArticleVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetArticleVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetArticleVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetArticleVersion(ctx, req.(*GetArticleVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_PinArticleVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinArticleVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).PinArticleVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_PinArticleVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).PinArticleVersion(ctx, req.(*PinArticleVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_SaveArticleOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveArticleOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).SaveArticleOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_SaveArticleOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).SaveArticleOverride(ctx, req.(*SaveArticleOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListArticlesByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArticlesByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListArticlesByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListArticlesByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListArticlesByTag(ctx, req.(*ListArticlesByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_TranslateArticle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TranslateArticleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArticleServiceServer).TranslateArticle(m, &grpc.GenericServerStream[TranslateArticleRequest, TranslateArticleResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ArticleService_TranslateArticleServer = grpc.ServerStreamingServer[TranslateArticleResponse]
+
 // ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +557,10 @@ var ArticleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SaveArticleID",
 			Handler:    _ArticleService_SaveArticleID_Handler,
 		},
+		{
+			MethodName: "UpdateArticleInfo",
+			Handler:    _ArticleService_UpdateArticleInfo_Handler,
+		},
 		{
 			MethodName: "GetArticleInfo",
 			Handler:    _ArticleService_GetArticleInfo_Handler,
@@ -237,7 +569,42 @@ var ArticleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DelArticleInfo",
 			Handler:    _ArticleService_DelArticleInfo_Handler,
 		},
+		{
+			MethodName: "GetRelatedArticles",
+			Handler:    _ArticleService_GetRelatedArticles_Handler,
+		},
+		{
+			MethodName: "ListArticleVersions",
+			Handler:    _ArticleService_ListArticleVersions_Handler,
+		},
+		{
+			MethodName: "GetArticleVersion",
+			Handler:    _ArticleService_GetArticleVersion_Handler,
+		},
+		{
+			MethodName: "PinArticleVersion",
+			Handler:    _ArticleService_PinArticleVersion_Handler,
+		},
+		{
+			MethodName: "SaveArticleOverride",
+			Handler:    _ArticleService_SaveArticleOverride_Handler,
+		},
+		{
+			MethodName: "ListArticlesByTag",
+			Handler:    _ArticleService_ListArticlesByTag_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetArticleInfoFirstStream",
+			Handler:       _ArticleService_GetArticleInfoFirstStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TranslateArticle",
+			Handler:       _ArticleService_TranslateArticle_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "article.proto",
 }
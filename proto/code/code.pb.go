@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.6
 // 	protoc        v5.26.1
 // source: code.proto
 
@@ -26,6 +26,7 @@ type CodeRequest struct {
 	CodeQuestion  string                 `protobuf:"bytes,1,opt,name=codeQuestion,proto3" json:"codeQuestion,omitempty"` // 用户提问的代码
 	UserId        uint32                 `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`            // 用户的id
 	CodeType      string                 `protobuf:"bytes,3,opt,name=codeType,proto3" json:"codeType,omitempty"`         // 代码语言
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`         // 解释的输出语言，如 zh、en、ja，为空时使用中文
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +82,13 @@ func (x *CodeRequest) GetCodeType() string {
 	return ""
 }
 
+func (x *CodeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 type CodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CodeExplain   string                 `protobuf:"bytes,1,opt,name=codeExplain,proto3" json:"codeExplain,omitempty"` // 代码解释
@@ -127,25 +135,19 @@ func (x *CodeResponse) GetCodeExplain() string {
 
 var File_code_proto protoreflect.FileDescriptor
 
-var file_code_proto_rawDesc = string([]byte{
-	0x0a, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x22, 0x65, 0x0a, 0x0b, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x64, 0x65, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x64, 0x65, 0x51, 0x75, 0x65,
-	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a,
-	0x08, 0x63, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x63, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x30, 0x0a, 0x0c, 0x43, 0x6f, 0x64,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x64,
-	0x65, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x63, 0x6f, 0x64, 0x65, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x32, 0x45, 0x0a, 0x0b, 0x43,
-	0x6f, 0x64, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x45, 0x78,
-	0x70, 0x6c, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x11, 0x2e, 0x63, 0x6f, 0x64, 0x65,
-	0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x63,
-	0x6f, 0x64, 0x65, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x30, 0x01, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
-})
+const file_code_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"code.proto\x12\x04code\"\x81\x01\n" +
+	"\vCodeRequest\x12\"\n" +
+	"\fcodeQuestion\x18\x01 \x01(\tR\fcodeQuestion\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\rR\x06userId\x12\x1a\n" +
+	"\bcodeType\x18\x03 \x01(\tR\bcodeType\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\"0\n" +
+	"\fCodeResponse\x12 \n" +
+	"\vcodeExplain\x18\x01 \x01(\tR\vcodeExplain2E\n" +
+	"\vCodeService\x126\n" +
+	"\vExplainCode\x12\x11.code.CodeRequest\x1a\x12.code.CodeResponse0\x01B\bZ\x06./codeb\x06proto3"
 
 var (
 	file_code_proto_rawDescOnce sync.Once
@@ -28,6 +28,7 @@ type GenerateQuestionTitlesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`        // 问题的完整内容（必填）
 	QuestionID    uint32                 `protobuf:"varint,2,opt,name=questionID,proto3" json:"questionID,omitempty"` // 问题ID
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`      // 输出语言，如 zh、en、ja，为空时使用中文
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -76,10 +77,20 @@ func (x *GenerateQuestionTitlesRequest) GetQuestionID() uint32 {
 	return 0
 }
 
+func (x *GenerateQuestionTitlesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 // 获取答案的请求参数
 type GetAnswerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"` // 问题的完整内容（必填）;
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`          // 问题的完整内容（必填）;
+	WithSources   bool                   `protobuf:"varint,2,opt,name=withSources,proto3" json:"withSources,omitempty"` // 检索本站文章和代码解释作为参考资料生成答案，答案中以 [编号] 标注引用
+	TopK          int32                  `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`               // 最多使用的参考资料数量，不大于 0 时使用配置的默认值
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`        // 答案的语言，为空时使用中文
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -121,14 +132,38 @@ func (x *GetAnswerRequest) GetContent() string {
 	return ""
 }
 
+func (x *GetAnswerRequest) GetWithSources() bool {
+	if x != nil {
+		return x.WithSources
+	}
+	return false
+}
+
+func (x *GetAnswerRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *GetAnswerRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 // 生成标题的响应结果
 type GenerateQuestionTitlesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`       // 哈希值（用于唯一标识本次生成任务）
-	Titles        []string               `protobuf:"bytes,2,rep,name=titles,proto3" json:"titles,omitempty"` // 生成的标题列表（至少返回1个）
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`  // 生成的标题总数（与 titles 长度一致）
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // 生成状态（如 "success"/"failed"）
-	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`     // 问题关联的标签（可选，用于优化生成效果）
+	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`                     // 问题内容的哈希值，用于 SaveQuestionID
+	Titles        []string               `protobuf:"bytes,2,rep,name=titles,proto3" json:"titles,omitempty"`               // 生成的标题列表（至少返回1个）
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                // 生成的标题总数（与 titles 长度一致）
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`               // 生成状态（如 "success"/"failed"）
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                   // 问题关联的标签（可选，用于优化生成效果）
+	Model         string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`                 // 生成标题和标签的模型
+	SuggestedTags []string               `protobuf:"bytes,7,rep,name=suggestedTags,proto3" json:"suggestedTags,omitempty"` // 标签体系中没有匹配的标签，已记录为待审核的建议标签
+	Language      string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`           // 标题和标签使用的语言
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -198,10 +233,33 @@ func (x *GenerateQuestionTitlesResponse) GetTags() []string {
 	return nil
 }
 
+func (x *GenerateQuestionTitlesResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerateQuestionTitlesResponse) GetSuggestedTags() []string {
+	if x != nil {
+		return x.SuggestedTags
+	}
+	return nil
+}
+
+func (x *GenerateQuestionTitlesResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 // 获取答案的响应结果
 type GetAnswerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`       // 生成答案的模型
+	Metadata      *AnswerMetadata        `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"` // 生成信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -243,29 +301,603 @@ func (x *GetAnswerResponse) GetContent() string {
 	return ""
 }
 
+func (x *GetAnswerResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetAnswerResponse) GetMetadata() *AnswerMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// 流式获取答案的响应结果
+type StreamAnswerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`   // 答案的一段 markdown 内容，按顺序拼接后为完整答案
+	Metadata      *AnswerMetadata        `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"` // 生成信息，只在最后一条消息中返回
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAnswerResponse) Reset() {
+	*x = StreamAnswerResponse{}
+	mi := &file_question_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAnswerResponse) ProtoMessage() {}
+
+func (x *StreamAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAnswerResponse.ProtoReflect.Descriptor instead.
+func (*StreamAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamAnswerResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *StreamAnswerResponse) GetMetadata() *AnswerMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// 答案的生成信息
+type AnswerMetadata struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Model            string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`                             // 生成答案的模型
+	PromptTokens     int32                  `protobuf:"varint,2,opt,name=promptTokens,proto3" json:"promptTokens,omitempty"`              // 提示词的 token 数
+	CompletionTokens int32                  `protobuf:"varint,3,opt,name=completionTokens,proto3" json:"completionTokens,omitempty"`      // 答案的 token 数
+	FinishReason     string                 `protobuf:"bytes,4,opt,name=finishReason,proto3" json:"finishReason,omitempty"`               // 生成结束的原因，例如 stop、length(达到长度上限被截断)
+	Cached           bool                   `protobuf:"varint,5,opt,name=cached,proto3" json:"cached,omitempty"`                          // 是否为相同问题已生成的答案，此时 token 数为 0
+	Citations        []*Citation            `protobuf:"bytes,6,rep,name=citations,proto3" json:"citations,omitempty"`                     // 提供给模型的参考资料，只在 withSources 时返回
+	CitedArticleIDs  []uint32               `protobuf:"varint,7,rep,packed,name=citedArticleIDs,proto3" json:"citedArticleIDs,omitempty"` // 答案中引用了的文章ID，按编号顺序
+	Language         string                 `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`                       // 答案使用的语言
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AnswerMetadata) Reset() {
+	*x = AnswerMetadata{}
+	mi := &file_question_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerMetadata) ProtoMessage() {}
+
+func (x *AnswerMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerMetadata.ProtoReflect.Descriptor instead.
+func (*AnswerMetadata) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AnswerMetadata) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *AnswerMetadata) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *AnswerMetadata) GetCompletionTokens() int32 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *AnswerMetadata) GetFinishReason() string {
+	if x != nil {
+		return x.FinishReason
+	}
+	return ""
+}
+
+func (x *AnswerMetadata) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *AnswerMetadata) GetCitations() []*Citation {
+	if x != nil {
+		return x.Citations
+	}
+	return nil
+}
+
+func (x *AnswerMetadata) GetCitedArticleIDs() []uint32 {
+	if x != nil {
+		return x.CitedArticleIDs
+	}
+	return nil
+}
+
+func (x *AnswerMetadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// 生成答案时使用的一条参考资料
+type Citation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`  // 答案中引用时使用的编号，从 1 开始
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // 类型: article、code
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`         // 文章ID或代码解释的ID
+	Score         float32                `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"` // 与问题的余弦相似度
+	Cited         bool                   `protobuf:"varint,5,opt,name=cited,proto3" json:"cited,omitempty"`  // 答案中是否引用了该资料
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Citation) Reset() {
+	*x = Citation{}
+	mi := &file_question_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Citation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Citation) ProtoMessage() {}
+
+func (x *Citation) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Citation.ProtoReflect.Descriptor instead.
+func (*Citation) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Citation) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Citation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Citation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Citation) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Citation) GetCited() bool {
+	if x != nil {
+		return x.Cited
+	}
+	return false
+}
+
+type SaveQuestionIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`                // hash值，GenerateQuestionTitles 返回的 Key
+	QuestionID    uint32                 `protobuf:"varint,2,opt,name=questionID,proto3" json:"questionID,omitempty"` // 问题ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveQuestionIDRequest) Reset() {
+	*x = SaveQuestionIDRequest{}
+	mi := &file_question_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveQuestionIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveQuestionIDRequest) ProtoMessage() {}
+
+func (x *SaveQuestionIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveQuestionIDRequest.ProtoReflect.Descriptor instead.
+func (*SaveQuestionIDRequest) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SaveQuestionIDRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SaveQuestionIDRequest) GetQuestionID() uint32 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+type SaveQuestionIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveQuestionIDResponse) Reset() {
+	*x = SaveQuestionIDResponse{}
+	mi := &file_question_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveQuestionIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveQuestionIDResponse) ProtoMessage() {}
+
+func (x *SaveQuestionIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveQuestionIDResponse.ProtoReflect.Descriptor instead.
+func (*SaveQuestionIDResponse) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SaveQuestionIDResponse) GetInform() string {
+	if x != nil {
+		return x.Inform
+	}
+	return ""
+}
+
+type FindSimilarQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`        // 问题的完整内容（必填）
+	QuestionID    uint32                 `protobuf:"varint,2,opt,name=questionID,proto3" json:"questionID,omitempty"` // 编辑已发布的问题时传入该问题的ID，结果中排除问题本身
+	TopK          int32                  `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`             // 最多返回的数量，不大于 0 时使用配置的默认值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarQuestionsRequest) Reset() {
+	*x = FindSimilarQuestionsRequest{}
+	mi := &file_question_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarQuestionsRequest) ProtoMessage() {}
+
+func (x *FindSimilarQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FindSimilarQuestionsRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *FindSimilarQuestionsRequest) GetQuestionID() uint32 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+func (x *FindSimilarQuestionsRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+type SimilarQuestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionID    uint32                 `protobuf:"varint,1,opt,name=questionID,proto3" json:"questionID,omitempty"` // 问题ID
+	Key           string                 `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`                // 问题内容的哈希值
+	Titles        []string               `protobuf:"bytes,3,rep,name=titles,proto3" json:"titles,omitempty"`          // 生成的标题
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`              // 生成的标签
+	Score         float32                `protobuf:"fixed32,5,opt,name=score,proto3" json:"score,omitempty"`          // 余弦相似度，按从高到低排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarQuestion) Reset() {
+	*x = SimilarQuestion{}
+	mi := &file_question_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarQuestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarQuestion) ProtoMessage() {}
+
+func (x *SimilarQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarQuestion.ProtoReflect.Descriptor instead.
+func (*SimilarQuestion) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SimilarQuestion) GetQuestionID() uint32 {
+	if x != nil {
+		return x.QuestionID
+	}
+	return 0
+}
+
+func (x *SimilarQuestion) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SimilarQuestion) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *SimilarQuestion) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SimilarQuestion) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type FindSimilarQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*SimilarQuestion     `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarQuestionsResponse) Reset() {
+	*x = FindSimilarQuestionsResponse{}
+	mi := &file_question_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarQuestionsResponse) ProtoMessage() {}
+
+func (x *FindSimilarQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_question_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_question_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FindSimilarQuestionsResponse) GetQuestions() []*SimilarQuestion {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
 var File_question_proto protoreflect.FileDescriptor
 
 const file_question_proto_rawDesc = "" +
 	"\n" +
-	"\x0equestion.proto\x12\bquestion\"Y\n" +
+	"\x0equestion.proto\x12\bquestion\"u\n" +
 	"\x1dGenerateQuestionTitlesRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x1e\n" +
 	"\n" +
 	"questionID\x18\x02 \x01(\rR\n" +
-	"questionID\",\n" +
+	"questionID\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\"~\n" +
 	"\x10GetAnswerRequest\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent\"\x8c\x01\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12 \n" +
+	"\vwithSources\x18\x02 \x01(\bR\vwithSources\x12\x12\n" +
+	"\x04topK\x18\x03 \x01(\x05R\x04topK\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\"\xe4\x01\n" +
 	"\x1eGenerateQuestionTitlesResponse\x12\x10\n" +
 	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x16\n" +
 	"\x06titles\x18\x02 \x03(\tR\x06titles\x12\x14\n" +
 	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\"-\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\x12$\n" +
+	"\rsuggestedTags\x18\a \x03(\tR\rsuggestedTags\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\"y\n" +
 	"\x11GetAnswerResponse\x12\x18\n" +
-	"\acontent\x18\x01 \x01(\tR\acontent2\xc4\x01\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x124\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x18.question.AnswerMetadataR\bmetadata\"f\n" +
+	"\x14StreamAnswerResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x124\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x18.question.AnswerMetadataR\bmetadata\"\xaa\x02\n" +
+	"\x0eAnswerMetadata\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\"\n" +
+	"\fpromptTokens\x18\x02 \x01(\x05R\fpromptTokens\x12*\n" +
+	"\x10completionTokens\x18\x03 \x01(\x05R\x10completionTokens\x12\"\n" +
+	"\ffinishReason\x18\x04 \x01(\tR\ffinishReason\x12\x16\n" +
+	"\x06cached\x18\x05 \x01(\bR\x06cached\x120\n" +
+	"\tcitations\x18\x06 \x03(\v2\x12.question.CitationR\tcitations\x12(\n" +
+	"\x0fcitedArticleIDs\x18\a \x03(\rR\x0fcitedArticleIDs\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\"p\n" +
+	"\bCitation\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x02R\x05score\x12\x14\n" +
+	"\x05cited\x18\x05 \x01(\bR\x05cited\"I\n" +
+	"\x15SaveQuestionIDRequest\x12\x10\n" +
+	"\x03Key\x18\x01 \x01(\tR\x03Key\x12\x1e\n" +
+	"\n" +
+	"questionID\x18\x02 \x01(\rR\n" +
+	"questionID\"0\n" +
+	"\x16SaveQuestionIDResponse\x12\x16\n" +
+	"\x06inform\x18\x01 \x01(\tR\x06inform\"k\n" +
+	"\x1bFindSimilarQuestionsRequest\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"questionID\x18\x02 \x01(\rR\n" +
+	"questionID\x12\x12\n" +
+	"\x04topK\x18\x03 \x01(\x05R\x04topK\"\x85\x01\n" +
+	"\x0fSimilarQuestion\x12\x1e\n" +
+	"\n" +
+	"questionID\x18\x01 \x01(\rR\n" +
+	"questionID\x12\x10\n" +
+	"\x03Key\x18\x02 \x01(\tR\x03Key\x12\x16\n" +
+	"\x06titles\x18\x03 \x03(\tR\x06titles\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x02R\x05score\"W\n" +
+	"\x1cFindSimilarQuestionsResponse\x127\n" +
+	"\tquestions\x18\x01 \x03(\v2\x19.question.SimilarQuestionR\tquestions2\xce\x03\n" +
 	"\x0fQuestionService\x12k\n" +
 	"\x16GenerateQuestionTitles\x12'.question.GenerateQuestionTitlesRequest\x1a(.question.GenerateQuestionTitlesResponse\x12D\n" +
-	"\tGetAnswer\x12\x1a.question.GetAnswerRequest\x1a\x1b.question.GetAnswerResponseB\x17Z\x15siwuai/proto/questionb\x06proto3"
+	"\tGetAnswer\x12\x1a.question.GetAnswerRequest\x1a\x1b.question.GetAnswerResponse\x12L\n" +
+	"\fStreamAnswer\x12\x1a.question.GetAnswerRequest\x1a\x1e.question.StreamAnswerResponse0\x01\x12S\n" +
+	"\x0eSaveQuestionID\x12\x1f.question.SaveQuestionIDRequest\x1a .question.SaveQuestionIDResponse\x12e\n" +
+	"\x14FindSimilarQuestions\x12%.question.FindSimilarQuestionsRequest\x1a&.question.FindSimilarQuestionsResponseB\x17Z\x15siwuai/proto/questionb\x06proto3"
 
 var (
 	file_question_proto_rawDescOnce sync.Once
@@ -279,23 +911,41 @@ func file_question_proto_rawDescGZIP() []byte {
 	return file_question_proto_rawDescData
 }
 
-var file_question_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_question_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_question_proto_goTypes = []any{
 	(*GenerateQuestionTitlesRequest)(nil),  // 0: question.GenerateQuestionTitlesRequest
 	(*GetAnswerRequest)(nil),               // 1: question.GetAnswerRequest
 	(*GenerateQuestionTitlesResponse)(nil), // 2: question.GenerateQuestionTitlesResponse
 	(*GetAnswerResponse)(nil),              // 3: question.GetAnswerResponse
+	(*StreamAnswerResponse)(nil),           // 4: question.StreamAnswerResponse
+	(*AnswerMetadata)(nil),                 // 5: question.AnswerMetadata
+	(*Citation)(nil),                       // 6: question.Citation
+	(*SaveQuestionIDRequest)(nil),          // 7: question.SaveQuestionIDRequest
+	(*SaveQuestionIDResponse)(nil),         // 8: question.SaveQuestionIDResponse
+	(*FindSimilarQuestionsRequest)(nil),    // 9: question.FindSimilarQuestionsRequest
+	(*SimilarQuestion)(nil),                // 10: question.SimilarQuestion
+	(*FindSimilarQuestionsResponse)(nil),   // 11: question.FindSimilarQuestionsResponse
 }
 var file_question_proto_depIdxs = []int32{
-	0, // 0: question.QuestionService.GenerateQuestionTitles:input_type -> question.GenerateQuestionTitlesRequest
-	1, // 1: question.QuestionService.GetAnswer:input_type -> question.GetAnswerRequest
-	2, // 2: question.QuestionService.GenerateQuestionTitles:output_type -> question.GenerateQuestionTitlesResponse
-	3, // 3: question.QuestionService.GetAnswer:output_type -> question.GetAnswerResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5,  // 0: question.GetAnswerResponse.metadata:type_name -> question.AnswerMetadata
+	5,  // 1: question.StreamAnswerResponse.metadata:type_name -> question.AnswerMetadata
+	6,  // 2: question.AnswerMetadata.citations:type_name -> question.Citation
+	10, // 3: question.FindSimilarQuestionsResponse.questions:type_name -> question.SimilarQuestion
+	0,  // 4: question.QuestionService.GenerateQuestionTitles:input_type -> question.GenerateQuestionTitlesRequest
+	1,  // 5: question.QuestionService.GetAnswer:input_type -> question.GetAnswerRequest
+	1,  // 6: question.QuestionService.StreamAnswer:input_type -> question.GetAnswerRequest
+	7,  // 7: question.QuestionService.SaveQuestionID:input_type -> question.SaveQuestionIDRequest
+	9,  // 8: question.QuestionService.FindSimilarQuestions:input_type -> question.FindSimilarQuestionsRequest
+	2,  // 9: question.QuestionService.GenerateQuestionTitles:output_type -> question.GenerateQuestionTitlesResponse
+	3,  // 10: question.QuestionService.GetAnswer:output_type -> question.GetAnswerResponse
+	4,  // 11: question.QuestionService.StreamAnswer:output_type -> question.StreamAnswerResponse
+	8,  // 12: question.QuestionService.SaveQuestionID:output_type -> question.SaveQuestionIDResponse
+	11, // 13: question.QuestionService.FindSimilarQuestions:output_type -> question.FindSimilarQuestionsResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_question_proto_init() }
@@ -309,7 +959,7 @@ func file_question_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_question_proto_rawDesc), len(file_question_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
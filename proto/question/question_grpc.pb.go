@@ -23,6 +23,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	QuestionService_GenerateQuestionTitles_FullMethodName = "/question.QuestionService/GenerateQuestionTitles"
 	QuestionService_GetAnswer_FullMethodName              = "/question.QuestionService/GetAnswer"
+	QuestionService_StreamAnswer_FullMethodName           = "/question.QuestionService/StreamAnswer"
+	QuestionService_SaveQuestionID_FullMethodName         = "/question.QuestionService/SaveQuestionID"
+	QuestionService_FindSimilarQuestions_FullMethodName   = "/question.QuestionService/FindSimilarQuestions"
 )
 
 // QuestionServiceClient is the client API for QuestionService service.
@@ -34,6 +37,12 @@ type QuestionServiceClient interface {
 	// 根据问题内容生成多个标题
 	GenerateQuestionTitles(ctx context.Context, in *GenerateQuestionTitlesRequest, opts ...grpc.CallOption) (*GenerateQuestionTitlesResponse, error)
 	GetAnswer(ctx context.Context, in *GetAnswerRequest, opts ...grpc.CallOption) (*GetAnswerResponse, error)
+	// 流式生成答案，逐段返回 markdown 内容，最后一条消息携带生成信息
+	StreamAnswer(ctx context.Context, in *GetAnswerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAnswerResponse], error)
+	// 将问题的ID保存到相应的记录中
+	SaveQuestionID(ctx context.Context, in *SaveQuestionIDRequest, opts ...grpc.CallOption) (*SaveQuestionIDResponse, error)
+	// 检索内容相近的已有问题，用于发布问题前提示重复
+	FindSimilarQuestions(ctx context.Context, in *FindSimilarQuestionsRequest, opts ...grpc.CallOption) (*FindSimilarQuestionsResponse, error)
 }
 
 type questionServiceClient struct {
@@ -64,6 +73,45 @@ func (c *questionServiceClient) GetAnswer(ctx context.Context, in *GetAnswerRequ
 	return out, nil
 }
 
+func (c *questionServiceClient) StreamAnswer(ctx context.Context, in *GetAnswerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAnswerResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QuestionService_ServiceDesc.Streams[0], QuestionService_StreamAnswer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAnswerRequest, StreamAnswerResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuestionService_StreamAnswerClient = grpc.ServerStreamingClient[StreamAnswerResponse]
+
+func (c *questionServiceClient) SaveQuestionID(ctx context.Context, in *SaveQuestionIDRequest, opts ...grpc.CallOption) (*SaveQuestionIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveQuestionIDResponse)
+	err := c.cc.Invoke(ctx, QuestionService_SaveQuestionID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionServiceClient) FindSimilarQuestions(ctx context.Context, in *FindSimilarQuestionsRequest, opts ...grpc.CallOption) (*FindSimilarQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarQuestionsResponse)
+	err := c.cc.Invoke(ctx, QuestionService_FindSimilarQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuestionServiceServer is the server API for QuestionService service.
 // All implementations must embed UnimplementedQuestionServiceServer
 // for forward compatibility.
@@ -73,6 +121,12 @@ type QuestionServiceServer interface {
 	// 根据问题内容生成多个标题
 	GenerateQuestionTitles(context.Context, *GenerateQuestionTitlesRequest) (*GenerateQuestionTitlesResponse, error)
 	GetAnswer(context.Context, *GetAnswerRequest) (*GetAnswerResponse, error)
+	// 流式生成答案，逐段返回 markdown 内容，最后一条消息携带生成信息
+	StreamAnswer(*GetAnswerRequest, grpc.ServerStreamingServer[StreamAnswerResponse]) error
+	// 将问题的ID保存到相应的记录中
+	SaveQuestionID(context.Context, *SaveQuestionIDRequest) (*SaveQuestionIDResponse, error)
+	// 检索内容相近的已有问题，用于发布问题前提示重复
+	FindSimilarQuestions(context.Context, *FindSimilarQuestionsRequest) (*FindSimilarQuestionsResponse, error)
 	mustEmbedUnimplementedQuestionServiceServer()
 }
 
@@ -89,6 +143,15 @@ func (UnimplementedQuestionServiceServer) GenerateQuestionTitles(context.Context
 func (UnimplementedQuestionServiceServer) GetAnswer(context.Context, *GetAnswerRequest) (*GetAnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnswer not implemented")
 }
+func (UnimplementedQuestionServiceServer) StreamAnswer(*GetAnswerRequest, grpc.ServerStreamingServer[StreamAnswerResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAnswer not implemented")
+}
+func (UnimplementedQuestionServiceServer) SaveQuestionID(context.Context, *SaveQuestionIDRequest) (*SaveQuestionIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveQuestionID not implemented")
+}
+func (UnimplementedQuestionServiceServer) FindSimilarQuestions(context.Context, *FindSimilarQuestionsRequest) (*FindSimilarQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilarQuestions not implemented")
+}
 func (UnimplementedQuestionServiceServer) mustEmbedUnimplementedQuestionServiceServer() {}
 func (UnimplementedQuestionServiceServer) testEmbeddedByValue()                         {}
 
@@ -146,6 +209,53 @@ func _QuestionService_GetAnswer_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuestionService_StreamAnswer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAnswerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuestionServiceServer).StreamAnswer(m, &grpc.GenericServerStream[GetAnswerRequest, StreamAnswerResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QuestionService_StreamAnswerServer = grpc.ServerStreamingServer[StreamAnswerResponse]
+
+func _QuestionService_SaveQuestionID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveQuestionIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).SaveQuestionID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_SaveQuestionID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).SaveQuestionID(ctx, req.(*SaveQuestionIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionService_FindSimilarQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionServiceServer).FindSimilarQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionService_FindSimilarQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionServiceServer).FindSimilarQuestions(ctx, req.(*FindSimilarQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QuestionService_ServiceDesc is the grpc.ServiceDesc for QuestionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,7 +271,21 @@ var QuestionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAnswer",
 			Handler:    _QuestionService_GetAnswer_Handler,
 		},
+		{
+			MethodName: "SaveQuestionID",
+			Handler:    _QuestionService_SaveQuestionID_Handler,
+		},
+		{
+			MethodName: "FindSimilarQuestions",
+			Handler:    _QuestionService_FindSimilarQuestions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAnswer",
+			Handler:       _QuestionService_StreamAnswer_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "question.proto",
 }
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.6
 // 	protoc        v5.26.1
 // source: token.proto
 
@@ -25,6 +25,8 @@ const (
 type TokenRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	GenerateTokenKey string                 `protobuf:"bytes,1,opt,name=GenerateTokenKey,proto3" json:"GenerateTokenKey,omitempty"` // 生成token时需要验证的密钥
+	Client           string                 `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`                     // 调用方标识，写入 token，用于按调用方限制配额
+	UserId           uint32                 `protobuf:"varint,3,opt,name=userId,proto3" json:"userId,omitempty"`                    // 用户ID，写入 token，用于按用户限制配额和统计用量
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -66,6 +68,20 @@ func (x *TokenRequest) GetGenerateTokenKey() string {
 	return ""
 }
 
+func (x *TokenRequest) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *TokenRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type TokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"` // 令牌
@@ -112,22 +128,17 @@ func (x *TokenResponse) GetToken() string {
 
 var File_token_proto protoreflect.FileDescriptor
 
-var file_token_proto_rawDesc = string([]byte{
-	0x0a, 0x0b, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3a, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4b, 0x65, 0x79,
-	0x22, 0x25, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x32, 0x4a, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x13, 0x2e, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_token_proto_rawDesc = "" +
+	"\n" +
+	"\vtoken.proto\x12\x05token\"j\n" +
+	"\fTokenRequest\x12*\n" +
+	"\x10GenerateTokenKey\x18\x01 \x01(\tR\x10GenerateTokenKey\x12\x16\n" +
+	"\x06client\x18\x02 \x01(\tR\x06client\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\rR\x06userId\"%\n" +
+	"\rTokenResponse\x12\x14\n" +
+	"\x05Token\x18\x01 \x01(\tR\x05Token2J\n" +
+	"\fTokenService\x12:\n" +
+	"\rGenerateToken\x12\x13.token.TokenRequest\x1a\x14.token.TokenResponseB\tZ\a./tokenb\x06proto3"
 
 var (
 	file_token_proto_rawDescOnce sync.Once
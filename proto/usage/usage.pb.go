@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.0
+// source: usage.proto
+
+package usage
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        *uint32                `protobuf:"varint,1,opt,name=userID,proto3,oneof" json:"userID,omitempty"` // 用户ID，不传时统计所有用户，0 表示未知用户
+	AiCode        string                 `protobuf:"bytes,2,opt,name=aiCode,proto3" json:"aiCode,omitempty"`        // 功能，如 article、code，为空时不过滤
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`          // 模型，为空时不过滤
+	StartDay      string                 `protobuf:"bytes,4,opt,name=startDay,proto3" json:"startDay,omitempty"`    // 开始日期(含)，格式 2006-01-02，为空时不限制
+	EndDay        string                 `protobuf:"bytes,5,opt,name=endDay,proto3" json:"endDay,omitempty"`        // 结束日期(含)，格式 2006-01-02，为空时不限制
+	GroupBy       []string               `protobuf:"bytes,6,rep,name=groupBy,proto3" json:"groupBy,omitempty"`      // 分组维度: user、ai_code、model、day，为空时只返回合计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_usage_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usage_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_usage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetUsageRequest) GetUserID() uint32 {
+	if x != nil && x.UserID != nil {
+		return *x.UserID
+	}
+	return 0
+}
+
+func (x *GetUsageRequest) GetAiCode() string {
+	if x != nil {
+		return x.AiCode
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetStartDay() string {
+	if x != nil {
+		return x.StartDay
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetEndDay() string {
+	if x != nil {
+		return x.EndDay
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+type UsageStat struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserID           uint32                 `protobuf:"varint,1,opt,name=userID,proto3" json:"userID,omitempty"`                     // 按 user 分组时有值
+	AiCode           string                 `protobuf:"bytes,2,opt,name=aiCode,proto3" json:"aiCode,omitempty"`                      // 按 ai_code 分组时有值
+	Model            string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`                        // 按 model 分组时有值
+	Day              string                 `protobuf:"bytes,4,opt,name=day,proto3" json:"day,omitempty"`                            // 按 day 分组时有值
+	Calls            int64                  `protobuf:"varint,5,opt,name=calls,proto3" json:"calls,omitempty"`                       // 调用次数
+	PromptTokens     int64                  `protobuf:"varint,6,opt,name=promptTokens,proto3" json:"promptTokens,omitempty"`         // 提示词 token 数
+	CompletionTokens int64                  `protobuf:"varint,7,opt,name=completionTokens,proto3" json:"completionTokens,omitempty"` // 回答 token 数
+	TotalTokens      int64                  `protobuf:"varint,8,opt,name=totalTokens,proto3" json:"totalTokens,omitempty"`           // 总 token 数
+	Cost             float64                `protobuf:"fixed64,9,opt,name=cost,proto3" json:"cost,omitempty"`                        // 按配置的模型单价计算的费用
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UsageStat) Reset() {
+	*x = UsageStat{}
+	mi := &file_usage_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageStat) ProtoMessage() {}
+
+func (x *UsageStat) ProtoReflect() protoreflect.Message {
+	mi := &file_usage_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageStat.ProtoReflect.Descriptor instead.
+func (*UsageStat) Descriptor() ([]byte, []int) {
+	return file_usage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UsageStat) GetUserID() uint32 {
+	if x != nil {
+		return x.UserID
+	}
+	return 0
+}
+
+func (x *UsageStat) GetAiCode() string {
+	if x != nil {
+		return x.AiCode
+	}
+	return ""
+}
+
+func (x *UsageStat) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *UsageStat) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *UsageStat) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *UsageStat) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *UsageStat) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *UsageStat) GetTotalTokens() int64 {
+	if x != nil {
+		return x.TotalTokens
+	}
+	return 0
+}
+
+func (x *UsageStat) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*UsageStat           `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"` // 各分组的用量
+	Total         *UsageStat             `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"` // 合计
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_usage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_usage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetUsageResponse) GetStats() []*UsageStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetTotal() *UsageStat {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+var File_usage_proto protoreflect.FileDescriptor
+
+const file_usage_proto_rawDesc = "" +
+	"\n" +
+	"\vusage.proto\x12\x05usage\"\xb5\x01\n" +
+	"\x0fGetUsageRequest\x12\x1b\n" +
+	"\x06userID\x18\x01 \x01(\rH\x00R\x06userID\x88\x01\x01\x12\x16\n" +
+	"\x06aiCode\x18\x02 \x01(\tR\x06aiCode\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1a\n" +
+	"\bstartDay\x18\x04 \x01(\tR\bstartDay\x12\x16\n" +
+	"\x06endDay\x18\x05 \x01(\tR\x06endDay\x12\x18\n" +
+	"\agroupBy\x18\x06 \x03(\tR\agroupByB\t\n" +
+	"\a_userID\"\xff\x01\n" +
+	"\tUsageStat\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\rR\x06userID\x12\x16\n" +
+	"\x06aiCode\x18\x02 \x01(\tR\x06aiCode\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x10\n" +
+	"\x03day\x18\x04 \x01(\tR\x03day\x12\x14\n" +
+	"\x05calls\x18\x05 \x01(\x03R\x05calls\x12\"\n" +
+	"\fpromptTokens\x18\x06 \x01(\x03R\fpromptTokens\x12*\n" +
+	"\x10completionTokens\x18\a \x01(\x03R\x10completionTokens\x12 \n" +
+	"\vtotalTokens\x18\b \x01(\x03R\vtotalTokens\x12\x12\n" +
+	"\x04cost\x18\t \x01(\x01R\x04cost\"b\n" +
+	"\x10GetUsageResponse\x12&\n" +
+	"\x05stats\x18\x01 \x03(\v2\x10.usage.UsageStatR\x05stats\x12&\n" +
+	"\x05total\x18\x02 \x01(\v2\x10.usage.UsageStatR\x05total2K\n" +
+	"\fUsageService\x12;\n" +
+	"\bGetUsage\x12\x16.usage.GetUsageRequest\x1a\x17.usage.GetUsageResponseB\x14Z\x12siwuai/proto/usageb\x06proto3"
+
+var (
+	file_usage_proto_rawDescOnce sync.Once
+	file_usage_proto_rawDescData []byte
+)
+
+func file_usage_proto_rawDescGZIP() []byte {
+	file_usage_proto_rawDescOnce.Do(func() {
+		file_usage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_usage_proto_rawDesc), len(file_usage_proto_rawDesc)))
+	})
+	return file_usage_proto_rawDescData
+}
+
+var file_usage_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_usage_proto_goTypes = []any{
+	(*GetUsageRequest)(nil),  // 0: usage.GetUsageRequest
+	(*UsageStat)(nil),        // 1: usage.UsageStat
+	(*GetUsageResponse)(nil), // 2: usage.GetUsageResponse
+}
+var file_usage_proto_depIdxs = []int32{
+	1, // 0: usage.GetUsageResponse.stats:type_name -> usage.UsageStat
+	1, // 1: usage.GetUsageResponse.total:type_name -> usage.UsageStat
+	0, // 2: usage.UsageService.GetUsage:input_type -> usage.GetUsageRequest
+	2, // 3: usage.UsageService.GetUsage:output_type -> usage.GetUsageResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_usage_proto_init() }
+func file_usage_proto_init() {
+	if File_usage_proto != nil {
+		return
+	}
+	file_usage_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_usage_proto_rawDesc), len(file_usage_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_usage_proto_goTypes,
+		DependencyIndexes: file_usage_proto_depIdxs,
+		MessageInfos:      file_usage_proto_msgTypes,
+	}.Build()
+	File_usage_proto = out.File
+	file_usage_proto_goTypes = nil
+	file_usage_proto_depIdxs = nil
+}
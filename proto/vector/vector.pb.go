@@ -153,6 +153,426 @@ func (x *VectorData) GetValues() []float32 {
 	return nil
 }
 
+type UpsertVectorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`              // 类型: article、question、code
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                  // 调用方的ID，同类型内唯一
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`        // 内容，未提供向量时根据内容生成向量
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`              // 标签，用于检索时过滤
+	Vector        []float32              `protobuf:"fixed32,5,rep,packed,name=vector,proto3" json:"vector,omitempty"` // 向量，可选
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertVectorRequest) Reset() {
+	*x = UpsertVectorRequest{}
+	mi := &file_vector_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertVectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertVectorRequest) ProtoMessage() {}
+
+func (x *UpsertVectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertVectorRequest.ProtoReflect.Descriptor instead.
+func (*UpsertVectorRequest) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpsertVectorRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpsertVectorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpsertVectorRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpsertVectorRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpsertVectorRequest) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+type UpsertVectorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertVectorResponse) Reset() {
+	*x = UpsertVectorResponse{}
+	mi := &file_vector_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertVectorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertVectorResponse) ProtoMessage() {}
+
+func (x *UpsertVectorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertVectorResponse.ProtoReflect.Descriptor instead.
+func (*UpsertVectorResponse) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpsertVectorResponse) GetInform() string {
+	if x != nil {
+		return x.Inform
+	}
+	return ""
+}
+
+type DeleteVectorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // 类型
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`     // 调用方的ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVectorRequest) Reset() {
+	*x = DeleteVectorRequest{}
+	mi := &file_vector_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVectorRequest) ProtoMessage() {}
+
+func (x *DeleteVectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVectorRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVectorRequest) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteVectorRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DeleteVectorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteVectorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inform        string                 `protobuf:"bytes,1,opt,name=inform,proto3" json:"inform,omitempty"` // 告知客户端是否操作成功
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVectorResponse) Reset() {
+	*x = DeleteVectorResponse{}
+	mi := &file_vector_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVectorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVectorResponse) ProtoMessage() {}
+
+func (x *DeleteVectorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVectorResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVectorResponse) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteVectorResponse) GetInform() string {
+	if x != nil {
+		return x.Inform
+	}
+	return ""
+}
+
+type QueryVectorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`              // 查询文本，与 vector 二选一
+	Vector        []float32              `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"` // 查询向量
+	TopK          uint32                 `protobuf:"varint,3,opt,name=topK,proto3" json:"topK,omitempty"`             // 返回的结果数量，默认 10，最多 100
+	Types         []string               `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`            // 只检索这些类型，为空时不限制
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`              // 只检索带有其中任一标签的内容，为空时不限制
+	MinScore      float32                `protobuf:"fixed32,6,opt,name=minScore,proto3" json:"minScore,omitempty"`    // 最低相似度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryVectorRequest) Reset() {
+	*x = QueryVectorRequest{}
+	mi := &file_vector_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryVectorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryVectorRequest) ProtoMessage() {}
+
+func (x *QueryVectorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryVectorRequest.ProtoReflect.Descriptor instead.
+func (*QueryVectorRequest) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QueryVectorRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *QueryVectorRequest) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *QueryVectorRequest) GetTopK() uint32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *QueryVectorRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *QueryVectorRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *QueryVectorRequest) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+type QueryVectorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*VectorHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"` // 按相似度从高到低排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryVectorResponse) Reset() {
+	*x = QueryVectorResponse{}
+	mi := &file_vector_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryVectorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryVectorResponse) ProtoMessage() {}
+
+func (x *QueryVectorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryVectorResponse.ProtoReflect.Descriptor instead.
+func (*QueryVectorResponse) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QueryVectorResponse) GetHits() []*VectorHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+type VectorHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Score         float32                `protobuf:"fixed32,5,opt,name=score,proto3" json:"score,omitempty"` // 余弦相似度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorHit) Reset() {
+	*x = VectorHit{}
+	mi := &file_vector_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorHit) ProtoMessage() {}
+
+func (x *VectorHit) ProtoReflect() protoreflect.Message {
+	mi := &file_vector_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorHit.ProtoReflect.Descriptor instead.
+func (*VectorHit) Descriptor() ([]byte, []int) {
+	return file_vector_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VectorHit) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VectorHit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VectorHit) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *VectorHit) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *VectorHit) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
 var File_vector_proto protoreflect.FileDescriptor
 
 const file_vector_proto_rawDesc = "" +
@@ -164,9 +584,40 @@ const file_vector_proto_rawDesc = "" +
 	"\x06vector\x18\x01 \x03(\v2\x12.vector.VectorDataR\x06vector\"$\n" +
 	"\n" +
 	"VectorData\x12\x16\n" +
-	"\x06values\x18\x01 \x03(\x02R\x06values2Q\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"\x7f\n" +
+	"\x13UpsertVectorRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06vector\x18\x05 \x03(\x02R\x06vector\".\n" +
+	"\x14UpsertVectorResponse\x12\x16\n" +
+	"\x06inform\x18\x01 \x01(\tR\x06inform\"9\n" +
+	"\x13DeleteVectorRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\".\n" +
+	"\x14DeleteVectorResponse\x12\x16\n" +
+	"\x06inform\x18\x01 \x01(\tR\x06inform\"\x9a\x01\n" +
+	"\x12QueryVectorRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x16\n" +
+	"\x06vector\x18\x02 \x03(\x02R\x06vector\x12\x12\n" +
+	"\x04topK\x18\x03 \x01(\rR\x04topK\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bminScore\x18\x06 \x01(\x02R\bminScore\"<\n" +
+	"\x13QueryVectorResponse\x12%\n" +
+	"\x04hits\x18\x01 \x03(\v2\x11.vector.VectorHitR\x04hits\"s\n" +
+	"\tVectorHit\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x02R\x05score2\xaf\x02\n" +
 	"\rvectorService\x12@\n" +
-	"\tGetVector\x12\x18.vector.GetVectorRequest\x1a\x19.vector.GetVectorResponseB\x04Z\x02./b\x06proto3"
+	"\tGetVector\x12\x18.vector.GetVectorRequest\x1a\x19.vector.GetVectorResponse\x12I\n" +
+	"\fUpsertVector\x12\x1b.vector.UpsertVectorRequest\x1a\x1c.vector.UpsertVectorResponse\x12I\n" +
+	"\fDeleteVector\x12\x1b.vector.DeleteVectorRequest\x1a\x1c.vector.DeleteVectorResponse\x12F\n" +
+	"\vQueryVector\x12\x1a.vector.QueryVectorRequest\x1a\x1b.vector.QueryVectorResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_vector_proto_rawDescOnce sync.Once
@@ -180,21 +631,35 @@ func file_vector_proto_rawDescGZIP() []byte {
 	return file_vector_proto_rawDescData
 }
 
-var file_vector_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_vector_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_vector_proto_goTypes = []any{
-	(*GetVectorRequest)(nil),  // 0: vector.GetVectorRequest
-	(*GetVectorResponse)(nil), // 1: vector.GetVectorResponse
-	(*VectorData)(nil),        // 2: vector.VectorData
+	(*GetVectorRequest)(nil),     // 0: vector.GetVectorRequest
+	(*GetVectorResponse)(nil),    // 1: vector.GetVectorResponse
+	(*VectorData)(nil),           // 2: vector.VectorData
+	(*UpsertVectorRequest)(nil),  // 3: vector.UpsertVectorRequest
+	(*UpsertVectorResponse)(nil), // 4: vector.UpsertVectorResponse
+	(*DeleteVectorRequest)(nil),  // 5: vector.DeleteVectorRequest
+	(*DeleteVectorResponse)(nil), // 6: vector.DeleteVectorResponse
+	(*QueryVectorRequest)(nil),   // 7: vector.QueryVectorRequest
+	(*QueryVectorResponse)(nil),  // 8: vector.QueryVectorResponse
+	(*VectorHit)(nil),            // 9: vector.VectorHit
 }
 var file_vector_proto_depIdxs = []int32{
 	2, // 0: vector.GetVectorResponse.vector:type_name -> vector.VectorData
-	0, // 1: vector.vectorService.GetVector:input_type -> vector.GetVectorRequest
-	1, // 2: vector.vectorService.GetVector:output_type -> vector.GetVectorResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	9, // 1: vector.QueryVectorResponse.hits:type_name -> vector.VectorHit
+	0, // 2: vector.vectorService.GetVector:input_type -> vector.GetVectorRequest
+	3, // 3: vector.vectorService.UpsertVector:input_type -> vector.UpsertVectorRequest
+	5, // 4: vector.vectorService.DeleteVector:input_type -> vector.DeleteVectorRequest
+	7, // 5: vector.vectorService.QueryVector:input_type -> vector.QueryVectorRequest
+	1, // 6: vector.vectorService.GetVector:output_type -> vector.GetVectorResponse
+	4, // 7: vector.vectorService.UpsertVector:output_type -> vector.UpsertVectorResponse
+	6, // 8: vector.vectorService.DeleteVector:output_type -> vector.DeleteVectorResponse
+	8, // 9: vector.vectorService.QueryVector:output_type -> vector.QueryVectorResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_vector_proto_init() }
@@ -208,7 +673,7 @@ func file_vector_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vector_proto_rawDesc), len(file_vector_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
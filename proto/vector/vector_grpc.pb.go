@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VectorService_GetVector_FullMethodName = "/vector.vectorService/GetVector"
+	VectorService_GetVector_FullMethodName    = "/vector.vectorService/GetVector"
+	VectorService_UpsertVector_FullMethodName = "/vector.vectorService/UpsertVector"
+	VectorService_DeleteVector_FullMethodName = "/vector.vectorService/DeleteVector"
+	VectorService_QueryVector_FullMethodName  = "/vector.vectorService/QueryVector"
 )
 
 // VectorServiceClient is the client API for VectorService service.
@@ -28,6 +31,12 @@ const (
 type VectorServiceClient interface {
 	// 获取问题的向量值
 	GetVector(ctx context.Context, in *GetVectorRequest, opts ...grpc.CallOption) (*GetVectorResponse, error)
+	// 保存内容的向量，(type, id) 已存在时覆盖
+	UpsertVector(ctx context.Context, in *UpsertVectorRequest, opts ...grpc.CallOption) (*UpsertVectorResponse, error)
+	// 删除内容的向量
+	DeleteVector(ctx context.Context, in *DeleteVectorRequest, opts ...grpc.CallOption) (*DeleteVectorResponse, error)
+	// 按文本或向量检索最相似的内容
+	QueryVector(ctx context.Context, in *QueryVectorRequest, opts ...grpc.CallOption) (*QueryVectorResponse, error)
 }
 
 type vectorServiceClient struct {
@@ -48,12 +57,48 @@ func (c *vectorServiceClient) GetVector(ctx context.Context, in *GetVectorReques
 	return out, nil
 }
 
+func (c *vectorServiceClient) UpsertVector(ctx context.Context, in *UpsertVectorRequest, opts ...grpc.CallOption) (*UpsertVectorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertVectorResponse)
+	err := c.cc.Invoke(ctx, VectorService_UpsertVector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorServiceClient) DeleteVector(ctx context.Context, in *DeleteVectorRequest, opts ...grpc.CallOption) (*DeleteVectorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVectorResponse)
+	err := c.cc.Invoke(ctx, VectorService_DeleteVector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorServiceClient) QueryVector(ctx context.Context, in *QueryVectorRequest, opts ...grpc.CallOption) (*QueryVectorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryVectorResponse)
+	err := c.cc.Invoke(ctx, VectorService_QueryVector_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VectorServiceServer is the server API for VectorService service.
 // All implementations must embed UnimplementedVectorServiceServer
 // for forward compatibility.
 type VectorServiceServer interface {
 	// 获取问题的向量值
 	GetVector(context.Context, *GetVectorRequest) (*GetVectorResponse, error)
+	// 保存内容的向量，(type, id) 已存在时覆盖
+	UpsertVector(context.Context, *UpsertVectorRequest) (*UpsertVectorResponse, error)
+	// 删除内容的向量
+	DeleteVector(context.Context, *DeleteVectorRequest) (*DeleteVectorResponse, error)
+	// 按文本或向量检索最相似的内容
+	QueryVector(context.Context, *QueryVectorRequest) (*QueryVectorResponse, error)
 	mustEmbedUnimplementedVectorServiceServer()
 }
 
@@ -67,6 +112,15 @@ type UnimplementedVectorServiceServer struct{}
 func (UnimplementedVectorServiceServer) GetVector(context.Context, *GetVectorRequest) (*GetVectorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVector not implemented")
 }
+func (UnimplementedVectorServiceServer) UpsertVector(context.Context, *UpsertVectorRequest) (*UpsertVectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertVector not implemented")
+}
+func (UnimplementedVectorServiceServer) DeleteVector(context.Context, *DeleteVectorRequest) (*DeleteVectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVector not implemented")
+}
+func (UnimplementedVectorServiceServer) QueryVector(context.Context, *QueryVectorRequest) (*QueryVectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryVector not implemented")
+}
 func (UnimplementedVectorServiceServer) mustEmbedUnimplementedVectorServiceServer() {}
 func (UnimplementedVectorServiceServer) testEmbeddedByValue()                       {}
 
@@ -106,6 +160,60 @@ func _VectorService_GetVector_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VectorService_UpsertVector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertVectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorServiceServer).UpsertVector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorService_UpsertVector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorServiceServer).UpsertVector(ctx, req.(*UpsertVectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorService_DeleteVector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorServiceServer).DeleteVector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorService_DeleteVector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorServiceServer).DeleteVector(ctx, req.(*DeleteVectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorService_QueryVector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorServiceServer).QueryVector(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorService_QueryVector_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorServiceServer).QueryVector(ctx, req.(*QueryVectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VectorService_ServiceDesc is the grpc.ServiceDesc for VectorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,6 +225,18 @@ var VectorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVector",
 			Handler:    _VectorService_GetVector_Handler,
 		},
+		{
+			MethodName: "UpsertVector",
+			Handler:    _VectorService_UpsertVector_Handler,
+		},
+		{
+			MethodName: "DeleteVector",
+			Handler:    _VectorService_DeleteVector_Handler,
+		},
+		{
+			MethodName: "QueryVector",
+			Handler:    _VectorService_QueryVector_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vector.proto",